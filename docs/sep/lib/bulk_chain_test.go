@@ -0,0 +1,65 @@
+package lib_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	docs "github.com/Harmeet10000/Fortress_API/docs/sep"
+	"github.com/Harmeet10000/Fortress_API/docs/sep/lib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bulkTodo struct {
+	ID    json.Number `json:"id"`
+	Title string      `json:"title"`
+}
+
+// newBulkServer mounts a bulk endpoint behind the XSS, HPP and security header middleware
+func newBulkServer(t *testing.T, received *[]bulkTodo) http.Handler {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/todos/bulk", func(w http.ResponseWriter, r *http.Request) {
+		decoder := json.NewDecoder(r.Body)
+		decoder.UseNumber()
+		if err := decoder.Decode(received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	hppOptions := lib.HPPOptions{
+		CheckQuery:                  true,
+		CheckBody:                   true,
+		CheckBodyOnlyForContentType: "application/x-www-form-urlencoded",
+	}
+	return docs.ApplyMiddlewares(mux,
+		lib.XSS(lib.XSSOptions{MaxBodyBytes: 1 << 20}),
+		lib.Hpp(hppOptions),
+		lib.SecurityHeaders,
+	)
+}
+
+func TestMiddlewareChain_JSONArray(t *testing.T) {
+	var received []bulkTodo
+	handler := newBulkServer(t, &received)
+
+	req := httptest.NewRequest(http.MethodPost, "/todos/bulk", strings.NewReader(
+		`[{"id":9007199254740993,"title":"<script>alert(1)</script>first"},{"id":2,"title":"<b>second</b>"}]`))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	// Every element is sanitized, and large numbers survive the XSS rewrite intact
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	assert.Equal(t, "DENY", rec.Header().Get("X-Frame-Options"))
+	assert.Equal(t, []bulkTodo{
+		{ID: "9007199254740993", Title: "first"},
+		{ID: "2", Title: "<b>second</b>"},
+	}, received)
+}
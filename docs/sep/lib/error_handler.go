@@ -1,7 +1,7 @@
 package lib
 
 import (
-	"fmt"
+	"errors"
 	"log"
 	"os"
 )
@@ -9,5 +9,5 @@ import (
 func ErrorHandler(err error, message string) error {
 	errorLogger := log.New(os.Stderr, "ERROR: ", log.Ldate|log.Ltime|log.Lshortfile)
 	errorLogger.Println(message, err)
-	return fmt.Errorf(message)
+	return errors.New(message)
 }
@@ -0,0 +1,110 @@
+package lib_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/docs/sep/lib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHpp_BodyContentTypes(t *testing.T) {
+	options := lib.HPPOptions{
+		CheckBody:                true,
+		CheckBodyForContentTypes: []string{"application/x-www-form-urlencoded", "Multipart/Form-Data"},
+		Whitelist:                []string{"name", "tag"},
+	}
+
+	// serve returns the request the handler sees, and its body when that was left unparsed
+	serve := func(t *testing.T, options lib.HPPOptions, req *http.Request) (*http.Request, []byte) {
+		t.Helper()
+
+		var seen *http.Request
+		var body []byte
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			seen = r
+			if r.Form == nil {
+				var err error
+				body, err = io.ReadAll(r.Body)
+				require.NoError(t, err)
+			}
+			w.WriteHeader(http.StatusOK)
+		})
+
+		lib.Hpp(options)(next).ServeHTTP(httptest.NewRecorder(), req)
+		return seen, body
+	}
+
+	t.Run("urlencoded body is filtered", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/students", strings.NewReader("name=ada&name=bob&role=admin"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=UTF-8")
+
+		seen, _ := serve(t, options, req)
+
+		for name, form := range map[string]url.Values{"Form": seen.Form, "PostForm": seen.PostForm} {
+			require.NotNil(t, form, name)
+			assert.Equal(t, []string{"ada"}, form["name"], name)
+			assert.NotContains(t, form, "role", name)
+		}
+	})
+
+	t.Run("multipart body is filtered", func(t *testing.T) {
+		var buf bytes.Buffer
+		mw := multipart.NewWriter(&buf)
+		require.NoError(t, mw.WriteField("tag", "a"))
+		require.NoError(t, mw.WriteField("tag", "b"))
+		require.NoError(t, mw.WriteField("role", "admin"))
+		require.NoError(t, mw.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/students", &buf)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+
+		seen, _ := serve(t, options, req)
+
+		require.NotNil(t, seen.MultipartForm)
+		forms := map[string]url.Values{"Form": seen.Form, "PostForm": seen.PostForm, "MultipartForm": seen.MultipartForm.Value}
+		for name, form := range forms {
+			require.NotNil(t, form, name)
+			assert.Equal(t, []string{"a"}, form["tag"], name)
+			assert.NotContains(t, form, "role", name)
+		}
+	})
+
+	t.Run("JSON body is left alone", func(t *testing.T) {
+		const payload = `{"name":["ada","bob"],"role":"admin"}`
+		req := httptest.NewRequest(http.MethodPost, "/students", strings.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+
+		seen, body := serve(t, options, req)
+
+		assert.Nil(t, seen.Form)
+		assert.Equal(t, payload, string(body))
+	})
+
+	t.Run("form types are filtered by default", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/students", strings.NewReader("name=ada&role=admin"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		seen, _ := serve(t, lib.HPPOptions{CheckBody: true, Whitelist: []string{"name"}}, req)
+
+		require.NotNil(t, seen.Form)
+		assert.NotContains(t, seen.Form, "role")
+	})
+
+	t.Run("single content type option still applies", func(t *testing.T) {
+		single := lib.HPPOptions{CheckBody: true, CheckBodyOnlyForContentType: "multipart/form-data", Whitelist: []string{"name"}}
+		req := httptest.NewRequest(http.MethodPost, "/students", strings.NewReader("name=ada&role=admin"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		seen, _ := serve(t, single, req)
+
+		assert.Nil(t, seen.Form)
+	})
+}
@@ -0,0 +1,98 @@
+package lib_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/docs/sep/lib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrustInternalCallers(t *testing.T) {
+	secret := []byte("internal-signing-secret")
+	const body = `{"title":"<b>Quarterly</b> report","notes":"a < b && c > d"}`
+
+	serve := func(t *testing.T, req *http.Request) (*httptest.ResponseRecorder, string, string, bool) {
+		t.Helper()
+
+		var received, rawQuery string
+		var trusted bool
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			received, rawQuery, trusted = string(data), r.URL.RawQuery, lib.IsTrustedCaller(r)
+			w.WriteHeader(http.StatusOK)
+		})
+
+		// Outermost, as the server wires it: ahead of HPP and XSS
+		handler := lib.TrustInternalCallers(lib.InternalCallerOptions{Secret: secret})(
+			lib.Hpp(lib.HPPOptions{CheckQuery: true, Whitelist: []string{"tag"}})(
+				lib.XSS(lib.XSSOptions{})(next)))
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec, received, rawQuery, trusted
+	}
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/todos?tag=a&source=sync", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("signed internal request skips sanitization", func(t *testing.T) {
+		req := newRequest()
+		require.NoError(t, lib.SignInternalRequest(req, secret, time.Now()))
+
+		rec, received, rawQuery, trusted := serve(t, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.True(t, trusted)
+		assert.Equal(t, body, received)
+		assert.Equal(t, "tag=a&source=sync", rawQuery)
+	})
+
+	t.Run("external request is still sanitized", func(t *testing.T) {
+		rec, received, rawQuery, trusted := serve(t, newRequest())
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.False(t, trusted)
+		assert.NotContains(t, received, "<b>")
+		assert.Equal(t, "tag=a", rawQuery)
+	})
+
+	t.Run("signature over a different body is not trusted", func(t *testing.T) {
+		req := newRequest()
+		require.NoError(t, lib.SignInternalRequest(req, secret, time.Now()))
+		req.Body = io.NopCloser(strings.NewReader(`{"title":"<b>tampered</b>"}`))
+
+		rec, received, _, trusted := serve(t, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.False(t, trusted)
+		assert.NotContains(t, received, "<b>")
+	})
+
+	t.Run("signature with the wrong secret is not trusted", func(t *testing.T) {
+		req := newRequest()
+		require.NoError(t, lib.SignInternalRequest(req, []byte("someone-else"), time.Now()))
+
+		_, _, _, trusted := serve(t, req)
+
+		assert.False(t, trusted)
+	})
+
+	t.Run("expired signature is not trusted", func(t *testing.T) {
+		req := newRequest()
+		require.NoError(t, lib.SignInternalRequest(req, secret, time.Now().Add(-time.Hour)))
+
+		_, _, _, trusted := serve(t, req)
+
+		assert.False(t, trusted)
+	})
+}
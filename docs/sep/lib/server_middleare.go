@@ -3,10 +3,13 @@ package lib
 import (
 	"bytes"
 	"compress/gzip"
+	"container/list"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"mime"
 	"net/http"
 	"net/url"
 	"strings"
@@ -106,96 +109,160 @@ func isOriginAllowed(origin string) bool {
 }
 
 
+type XSSOptions struct {
+	// BypassBodyPaths lists path prefixes (e.g. multipart upload or binary routes)
+	// whose request body is passed through without JSON sanitization
+	BypassBodyPaths []string
+	// MaxBodyBytes caps the request body size for every route, bypassed or not. Zero disables the cap
+	MaxBodyBytes int64
+}
+
 func XSSMiddleware(next http.Handler) http.Handler {
+	return XSS(XSSOptions{})(next)
+}
+
+func XSS(options XSSOptions) func(http.Handler) http.Handler {
 	fmt.Println("****** Intializing XSSMiddleware")
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		fmt.Println("++++++++++++ XSSMiddleware Ran")
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Println("++++++++++++ XSSMiddleware Ran")
 
-		// Sanitize the URL Path
-		sanitizedPath, err := clean(r.URL.Path)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
+			// Signed internal callers send trusted data that sanitization could only corrupt
+			if IsTrustedCaller(r) {
+				if options.MaxBodyBytes > 0 && r.Body != nil {
+					r.Body = http.MaxBytesReader(w, r.Body, options.MaxBodyBytes)
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
 
-		// Sanitize query params
-		params := r.URL.Query()
-		sanitizedQuery := make(map[string][]string)
-		for key, values := range params {
-			sanitizedKey, err := clean(key)
+			// Sanitize the URL Path
+			sanitizedPath, err := clean(r.URL.Path)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
 
-			var sanitizedValues []string
-			for _, value := range values {
-				cleanValue, err := clean(value)
+			// Sanitize query params
+			params := r.URL.Query()
+			sanitizedQuery := make(map[string][]string)
+			for key, values := range params {
+				sanitizedKey, err := clean(key)
 				if err != nil {
 					http.Error(w, err.Error(), http.StatusBadRequest)
 					return
 				}
-				sanitizedValues = append(sanitizedValues, cleanValue.(string))
-			}
-			sanitizedQuery[sanitizedKey.(string)] = sanitizedValues
-		}
-
-		r.URL.Path = sanitizedPath.(string)
-		r.URL.RawQuery = url.Values(sanitizedQuery).Encode()
 
-		// Sanitize request body
-		if r.Header.Get("Content-Type") == "application/json" {
-			if r.Body != nil {
-				bodyBytes, err := io.ReadAll(r.Body)
-				if err != nil {
-					http.Error(w, ErrorHandler(err, "Error reading request body").Error(), http.StatusBadRequest)
-					return
+				var sanitizedValues []string
+				for _, value := range values {
+					cleanValue, err := clean(value)
+					if err != nil {
+						http.Error(w, err.Error(), http.StatusBadRequest)
+						return
+					}
+					sanitizedValues = append(sanitizedValues, cleanValue.(string))
 				}
+				sanitizedQuery[sanitizedKey.(string)] = sanitizedValues
+			}
 
-				bodyString := strings.TrimSpace(string(bodyBytes))
+			r.URL.Path = sanitizedPath.(string)
+			r.URL.RawQuery = url.Values(sanitizedQuery).Encode()
 
-				// Reset the request body
-				r.Body = io.NopCloser(bytes.NewReader([]byte(bodyString)))
+			// Size guard applies to every route, including the bypassed ones
+			if options.MaxBodyBytes > 0 && r.Body != nil {
+				r.Body = http.MaxBytesReader(w, r.Body, options.MaxBodyBytes)
+			}
 
-				if len(bodyString) > 0 {
-					var inputData interface{}
-					err := json.NewDecoder(bytes.NewReader([]byte(bodyString))).Decode(&inputData)
-					if err != nil {
-						http.Error(w, ErrorHandler(err, "Invalid JSON body").Error(), http.StatusBadRequest)
-						return
-					}
+			// Binary/multipart routes skip JSON body sanitization entirely
+			if isBodyBypassed(r.URL.Path, options.BypassBodyPaths) {
+				log.Printf("Skipping body sanitization for %s\n", r.URL.Path)
+				next.ServeHTTP(w, r)
+				return
+			}
 
-					// Sanitize the JSON body
-					sanitizedData, err := clean(inputData)
+			// Sanitize request body
+			if isJSONContentType(r.Header.Get("Content-Type")) {
+				if r.Body != nil {
+					bodyBytes, err := io.ReadAll(r.Body)
 					if err != nil {
-						http.Error(w, err.Error(), http.StatusBadRequest)
+						var maxBytesErr *http.MaxBytesError
+						if errors.As(err, &maxBytesErr) {
+							http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+							return
+						}
+						http.Error(w, ErrorHandler(err, "Error reading request body").Error(), http.StatusBadRequest)
 						return
 					}
 
-					// Marshal the sanitized data back to the body
-					sanitizedBody, err := json.Marshal(sanitizedData)
-					if err != nil {
-						http.Error(w, ErrorHandler(err, "Error sanitizing body").Error(), http.StatusBadRequest)
-						return
+					bodyString := strings.TrimSpace(string(bodyBytes))
+
+					// Reset the request body
+					r.Body = io.NopCloser(bytes.NewReader([]byte(bodyString)))
+
+					if len(bodyString) > 0 {
+						// The body may be an object or, for bulk endpoints, a top-level array.
+						// Numbers are kept as json.Number so large IDs survive the round trip
+						var inputData interface{}
+						dec := json.NewDecoder(bytes.NewReader([]byte(bodyString)))
+						dec.UseNumber()
+						err := dec.Decode(&inputData)
+						if err != nil {
+							http.Error(w, ErrorHandler(err, "Invalid JSON body").Error(), http.StatusBadRequest)
+							return
+						}
+						// Anything after the first value would otherwise be dropped silently
+						if dec.More() {
+							http.Error(w, "Invalid JSON body: unexpected data after the top-level value", http.StatusBadRequest)
+							return
+						}
+
+						// Sanitize the JSON body, element by element for arrays
+						sanitizedData, err := clean(inputData)
+						if err != nil {
+							http.Error(w, err.Error(), http.StatusBadRequest)
+							return
+						}
+
+						// Marshal the sanitized data back to the body
+						sanitizedBody, err := json.Marshal(sanitizedData)
+						if err != nil {
+							http.Error(w, ErrorHandler(err, "Error sanitizing body").Error(), http.StatusBadRequest)
+							return
+						}
+
+						r.Body = io.NopCloser(bytes.NewReader(sanitizedBody))
+						fmt.Println("Sanitized body:", string(sanitizedBody))
+					} else {
+						log.Println("Request body is empty")
 					}
-
-					r.Body = io.NopCloser(bytes.NewReader(sanitizedBody))
-					fmt.Println("Sanitized body:", string(sanitizedBody))
 				} else {
-					log.Println("Request body is empty")
+					log.Println("No body in the request")
 				}
-			} else {
-				log.Println("No body in the request")
+			} else if r.Header.Get("Content-Type") != "" {
+				log.Printf("Received request with unsupported Content-Type: %s. Expected application/json.\n", r.Header.Get("Content-Type"))
+				http.Error(w, "Unsupported Content-Type. Please use application/json.", http.StatusUnsupportedMediaType)
+				return
 			}
-		} else if r.Header.Get("Content-Type") != "" {
-			log.Printf("Received request with unsupported Content-Type: %s. Expected application/json.\n", r.Header.Get("Content-Type"))
-			http.Error(w, "Unsupported Content-Type. Please use application/json.", http.StatusUnsupportedMediaType)
-			return
-		}
 
-		next.ServeHTTP(w, r)
-		fmt.Println("Sending response from XSSMiddleware Ran")
-	})
+			next.ServeHTTP(w, r)
+			fmt.Println("Sending response from XSSMiddleware Ran")
+		})
+	}
+}
+
+// isJSONContentType accepts application/json with or without parameters such as charset
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "application/json"
+}
+
+func isBodyBypassed(path string, bypassPaths []string) bool {
+	for _, prefix := range bypassPaths {
+		if path == prefix || strings.HasPrefix(path, strings.TrimSuffix(prefix, "/")+"/") {
+			return true
+		}
+	}
+	return false
 }
 
 // Clean sanitizes input data to prevent XSS attacks
@@ -278,19 +345,71 @@ func SecurityHeaders(next http.Handler) http.Handler {
 
 
 
+// DefaultMaxQueryParams is used by MaxQueryParams when the limit is not positive
+const DefaultMaxQueryParams = 100
+
+// MaxQueryParams rejects requests carrying more than limit query parameters with 400.
+// Place it outside Hpp and XSS so an oversized query is refused before either of them
+// parses and rewrites it. Parameters are counted on the raw query, without decoding.
+func MaxQueryParams(limit int) func(http.Handler) http.Handler {
+	if limit <= 0 {
+		limit = DefaultMaxQueryParams
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if count := countQueryParams(r.URL.RawQuery); count > limit {
+				log.Printf("Rejected request to %s with %d query parameters (limit %d)\n", r.URL.Path, count, limit)
+				http.Error(w, fmt.Sprintf("Too many query parameters: at most %d are allowed", limit), http.StatusBadRequest)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// countQueryParams counts the non-empty "&"-separated pairs, matching what url.ParseQuery
+// would produce, including repeated keys
+func countQueryParams(rawQuery string) int {
+	count := 0
+	for rawQuery != "" {
+		var pair string
+		pair, rawQuery, _ = strings.Cut(rawQuery, "&")
+		if pair != "" {
+			count++
+		}
+	}
+	return count
+}
+
 type HPPOptions struct {
-	CheckQuery                  bool
-	CheckBody                   bool
+	CheckQuery bool
+	CheckBody  bool
+	// CheckBodyOnlyForContentType limits body filtering to one media type
 	CheckBodyOnlyForContentType string
-	Whitelist                   []string
+	// CheckBodyForContentTypes limits body filtering to these media types, together with
+	// CheckBodyOnlyForContentType. Parameters such as charset or boundary are ignored.
+	// With neither set, form bodies (urlencoded and multipart) are filtered
+	CheckBodyForContentTypes []string
+	Whitelist                []string
 }
 
+// defaultHPPBodyContentTypes are the form encodings whose body HPP filters by default
+var defaultHPPBodyContentTypes = []string{"application/x-www-form-urlencoded", "multipart/form-data"}
+
+// hppMultipartMaxMemory is how much of a multipart body is held in memory while parsing
+const hppMultipartMaxMemory = 32 << 20
+
 func Hpp(options HPPOptions) func(http.Handler) http.Handler {
 	fmt.Println("HPP Middleware...")
+	bodyContentTypes := hppBodyContentTypes(options)
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			fmt.Println("HPP Middleware being returned...")
-			if options.CheckBody && r.Method == http.MethodPost && isCorrectContentType(r, options.CheckBodyOnlyForContentType) {
+			if IsTrustedCaller(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if options.CheckBody && r.Method == http.MethodPost && isCorrectContentType(r, bodyContentTypes) {
 				// filter the body params
 				filterBodyParams(r, options.Whitelist)
 			}
@@ -304,24 +423,58 @@ func Hpp(options HPPOptions) func(http.Handler) http.Handler {
 	}
 }
 
-func isCorrectContentType(r *http.Request, contentType string) bool {
-	return strings.Contains(r.Header.Get("Content-Type"), contentType)
+// hppBodyContentTypes collects the media types whose body is filtered, lower-cased
+func hppBodyContentTypes(options HPPOptions) map[string]bool {
+	contentTypes := options.CheckBodyForContentTypes
+	if options.CheckBodyOnlyForContentType != "" {
+		contentTypes = append([]string{options.CheckBodyOnlyForContentType}, contentTypes...)
+	}
+	if len(contentTypes) == 0 {
+		contentTypes = defaultHPPBodyContentTypes
+	}
+
+	set := make(map[string]bool, len(contentTypes))
+	for _, contentType := range contentTypes {
+		set[strings.ToLower(strings.TrimSpace(contentType))] = true
+	}
+	return set
+}
+
+// isCorrectContentType compares the request's media type, without parameters, to the set
+func isCorrectContentType(r *http.Request, contentTypes map[string]bool) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && contentTypes[mediaType]
 }
 
 func filterBodyParams(r *http.Request, whitelist []string) {
-	err := r.ParseForm()
+	var err error
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		err = r.ParseMultipartForm(hppMultipartMaxMemory)
+	} else {
+		err = r.ParseForm()
+	}
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
 
-	for k, v := range r.Form {
+	// FormValue reads r.Form and PostFormValue r.PostForm, while multipart handlers often
+	// read r.MultipartForm directly, so every copy of the body params is filtered
+	filterValues(r.Form, whitelist)
+	filterValues(r.PostForm, whitelist)
+	if r.MultipartForm != nil {
+		filterValues(r.MultipartForm.Value, whitelist)
+	}
+}
+
+func filterValues(values url.Values, whitelist []string) {
+	for k, v := range values {
 		if len(v) > 1 {
-			r.Form.Set(k, v[0]) // first value
-			// r.Form.Set(k, v[len(v)-1]) // last value
+			values.Set(k, v[0]) // first value
+			// values.Set(k, v[len(v)-1]) // last value
 		}
 		if !isWhiteListed(k, whitelist) {
-			delete(r.Form, k)
+			delete(values, k)
 		}
 	}
 }
@@ -351,18 +504,47 @@ func isWhiteListed(param string, whitelist []string) bool {
 }
 
 
+// DefaultMaxVisitors caps the visitors tracked by NewRateLimiter
+const DefaultMaxVisitors = 10000
+
+type RateLimiterOptions struct {
+	Limit     int
+	ResetTime time.Duration
+	// MaxVisitors caps how many IPs are tracked at once. When a new IP arrives at the
+	// cap the least recently seen one is evicted, so a scan from many unique addresses
+	// cannot grow the store without bound between resets. Zero uses DefaultMaxVisitors
+	MaxVisitors int
+}
+
 type rateLimiter struct {
-	mu        sync.Mutex
-	visitors  map[string]int
-	limit     int
-	resetTime time.Duration
+	mu          sync.Mutex
+	visitors    map[string]*list.Element
+	recent      *list.List // of *visitor, most recently seen first
+	limit       int
+	resetTime   time.Duration
+	maxVisitors int
+}
+
+type visitor struct {
+	ip    string
+	count int
 }
 
 func NewRateLimiter(limit int, resetTime time.Duration) *rateLimiter {
+	return NewRateLimiterWithOptions(RateLimiterOptions{Limit: limit, ResetTime: resetTime})
+}
+
+func NewRateLimiterWithOptions(options RateLimiterOptions) *rateLimiter {
+	maxVisitors := options.MaxVisitors
+	if maxVisitors <= 0 {
+		maxVisitors = DefaultMaxVisitors
+	}
 	rl := &rateLimiter{
-		visitors:  make(map[string]int),
-		limit:     limit,
-		resetTime: resetTime,
+		visitors:    make(map[string]*list.Element),
+		recent:      list.New(),
+		limit:       options.Limit,
+		resetTime:   options.ResetTime,
+		maxVisitors: maxVisitors,
 	}
 	// start the reset routine
 	go rl.resetVisitorCount()
@@ -373,11 +555,38 @@ func (rl *rateLimiter) resetVisitorCount() {
 	for {
 		time.Sleep(rl.resetTime)
 		rl.mu.Lock()
-		rl.visitors = make(map[string]int)
+		rl.visitors = make(map[string]*list.Element)
+		rl.recent.Init()
 		rl.mu.Unlock()
 	}
 }
 
+// VisitorCount reports how many IPs are currently tracked
+func (rl *rateLimiter) VisitorCount() int {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	return len(rl.visitors)
+}
+
+// hit counts a request from ip and returns its count in the current window. Callers
+// must hold rl.mu.
+func (rl *rateLimiter) hit(ip string) int {
+	if elem, ok := rl.visitors[ip]; ok {
+		rl.recent.MoveToFront(elem)
+		v := elem.Value.(*visitor)
+		v.count++
+		return v.count
+	}
+
+	if len(rl.visitors) >= rl.maxVisitors {
+		oldest := rl.recent.Back()
+		rl.recent.Remove(oldest)
+		delete(rl.visitors, oldest.Value.(*visitor).ip)
+	}
+	rl.visitors[ip] = rl.recent.PushFront(&visitor{ip: ip, count: 1})
+	return 1
+}
+
 func (rl *rateLimiter) RLMiddleware(next http.Handler) http.Handler {
 	fmt.Println("Rate Limiter Middleware...")
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -386,10 +595,10 @@ func (rl *rateLimiter) RLMiddleware(next http.Handler) http.Handler {
 		defer rl.mu.Unlock()
 
 		visitorIP := r.RemoteAddr // You might want to extract the IP in a more sophisticated way
-		rl.visitors[visitorIP]++
-		// fmt.Printf("Vistor count from %v is %v\n", visitorIP, rl.visitors[visitorIP])
+		count := rl.hit(visitorIP)
+		// fmt.Printf("Vistor count from %v is %v\n", visitorIP, count)
 
-		if rl.visitors[visitorIP] > rl.limit {
+		if count > rl.limit {
 			http.Error(w, "Too many requests", http.StatusTooManyRequests)
 			return
 		}
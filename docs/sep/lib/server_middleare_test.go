@@ -0,0 +1,195 @@
+package lib_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/docs/sep/lib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveXSS(t *testing.T, options lib.XSSOptions, req *http.Request) (*httptest.ResponseRecorder, []byte) {
+	t.Helper()
+
+	var received []byte
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		received = body
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	lib.XSS(options)(next).ServeHTTP(rec, req)
+	return rec, received
+}
+
+func TestXSS_BypassBodyPaths(t *testing.T) {
+	options := lib.XSSOptions{BypassBodyPaths: []string{"/uploads"}}
+
+	t.Run("multipart request to an upload route passes through", func(t *testing.T) {
+		var buf bytes.Buffer
+		mw := multipart.NewWriter(&buf)
+		part, err := mw.CreateFormFile("file", "avatar.png")
+		require.NoError(t, err)
+		_, err = part.Write([]byte("<script>binary-ish</script>"))
+		require.NoError(t, err)
+		require.NoError(t, mw.Close())
+		payload := buf.Bytes()
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/avatar", bytes.NewReader(payload))
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+
+		rec, received := serveXSS(t, options, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, payload, received)
+	})
+
+	t.Run("multipart request to a non-upload route is still rejected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/todos", strings.NewReader("--x--"))
+		req.Header.Set("Content-Type", "multipart/form-data; boundary=x")
+
+		rec, _ := serveXSS(t, options, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	})
+
+	t.Run("a sibling path sharing the prefix is not bypassed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/uploads-admin", strings.NewReader("--x--"))
+		req.Header.Set("Content-Type", "multipart/form-data; boundary=x")
+
+		rec, _ := serveXSS(t, options, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	})
+
+	t.Run("JSON route is still sanitized", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/todos", strings.NewReader(`{"title":"<script>alert(1)</script>hello"}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		rec, received := serveXSS(t, options, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"title":"hello"}`, string(received))
+	})
+
+	t.Run("size guard still applies to bypassed routes", func(t *testing.T) {
+		limited := lib.XSSOptions{BypassBodyPaths: []string{"/uploads"}, MaxBodyBytes: 8}
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, err := io.ReadAll(r.Body); err != nil {
+				http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads/avatar", strings.NewReader(strings.Repeat("a", 64)))
+		req.Header.Set("Content-Type", "application/octet-stream")
+		rec := httptest.NewRecorder()
+		lib.XSS(limited)(next).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	})
+}
+
+func TestMaxQueryParams(t *testing.T) {
+	serve := func(rawQuery string) (*httptest.ResponseRecorder, bool) {
+		reached := false
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			reached = true
+			w.WriteHeader(http.StatusOK)
+		})
+
+		// Outermost, as the server wires it: ahead of HPP and XSS
+		handler := lib.MaxQueryParams(3)(
+			lib.Hpp(lib.HPPOptions{CheckQuery: true, Whitelist: []string{"tag"}})(
+				lib.XSS(lib.XSSOptions{})(next)))
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/todos?"+rawQuery, nil))
+		return rec, reached
+	}
+
+	t.Run("a request at the limit is served", func(t *testing.T) {
+		rec, reached := serve("tag=a&tag=b&&tag=c")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.True(t, reached)
+	})
+
+	t.Run("a request over the limit is rejected before HPP and XSS", func(t *testing.T) {
+		rec, reached := serve("tag=a&tag=b&tag=c&other=d")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "at most 3")
+		assert.False(t, reached)
+	})
+
+	t.Run("a non-positive limit falls back to the default", func(t *testing.T) {
+		query := strings.Repeat("a=1&", lib.DefaultMaxQueryParams) + "a=1"
+		rec := httptest.NewRecorder()
+		lib.MaxQueryParams(0)(http.NotFoundHandler()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/todos?"+query, nil))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestRateLimiter_MaxVisitors(t *testing.T) {
+	serve := func(handler http.Handler, ip string) int {
+		req := httptest.NewRequest(http.MethodGet, "/todos", nil)
+		req.RemoteAddr = ip
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("the store stays bounded under many unique IPs", func(t *testing.T) {
+		rl := lib.NewRateLimiterWithOptions(lib.RateLimiterOptions{Limit: 5, ResetTime: time.Hour, MaxVisitors: 100})
+		handler := rl.RLMiddleware(ok)
+
+		for i := 0; i < 10000; i++ {
+			serve(handler, "10.0."+strconv.Itoa(i/256)+"."+strconv.Itoa(i%256))
+		}
+
+		assert.Equal(t, 100, rl.VisitorCount())
+	})
+
+	t.Run("recently seen visitors survive eviction and stay limited", func(t *testing.T) {
+		rl := lib.NewRateLimiterWithOptions(lib.RateLimiterOptions{Limit: 3, ResetTime: time.Hour, MaxVisitors: 2})
+		handler := rl.RLMiddleware(ok)
+
+		for i := 0; i < 3; i++ {
+			require.Equal(t, http.StatusOK, serve(handler, "192.0.2.1"))
+		}
+		// Each new IP evicts the least recently seen one; touching 192.0.2.1 in between
+		// keeps it the most recent
+		for i := 0; i < 5; i++ {
+			serve(handler, "198.51.100."+strconv.Itoa(i))
+			assert.Equal(t, http.StatusTooManyRequests, serve(handler, "192.0.2.1"))
+		}
+		assert.Equal(t, 2, rl.VisitorCount())
+	})
+
+	t.Run("defaults the cap", func(t *testing.T) {
+		rl := lib.NewRateLimiter(1, time.Hour)
+		handler := rl.RLMiddleware(ok)
+
+		for i := 0; i < lib.DefaultMaxVisitors+50; i++ {
+			serve(handler, "ip-"+strconv.Itoa(i))
+		}
+
+		assert.Equal(t, lib.DefaultMaxVisitors, rl.VisitorCount())
+	})
+}
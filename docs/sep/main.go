@@ -77,7 +77,7 @@ func main() {
 	// hppOptions := mw.HPPOptions{
 	// 	CheckQuery:                  true,
 	// 	CheckBody:                   true,
-	// 	CheckBodyOnlyForContentType: "application/x-www-form-urlencoded",
+	// 	CheckBodyForContentTypes:    []string{"application/x-www-form-urlencoded", "multipart/form-data"},
 	// 	Whitelist:                   []string{"sortBy", "sortOrder", "name", "age", "class"},
 	// }
 
@@ -88,7 +88,9 @@ func main() {
 	// router := router.MainRouter()
 	// jwtMiddleware := mw.MiddlewaresExcludePaths(mw.JWTMiddleware, "/execs/login", "/execs/forgotpassword", "/execs/resetpassword/reset")
 	// // secureMux := lib.ApplyMiddlewares(router, mw.SecurityHeaders, mw.Compression, mw.Hpp(hppOptions), mw.XSSMiddleware, jwtMiddleware, mw.ResponseTimeMiddleware, rl.Middleware, mw.Cors)
-	// secureMux := lib.ApplyMiddlewares(router, mw.SecurityHeaders, mw.Compression, mw.Hpp(hppOptions), mw.XSSMiddleware, jwtMiddleware, mw.ResponseTimeMiddleware, mw.Cors)
+	// MaxQueryParams and TrustInternalCallers are applied after Hpp and XSS so they wrap them and run first
+	// trustInternal := mw.TrustInternalCallers(mw.InternalCallerOptions{Secret: []byte(os.Getenv("INTERNAL_SIGNING_SECRET"))})
+	// secureMux := lib.ApplyMiddlewares(router, mw.SecurityHeaders, mw.Compression, mw.Hpp(hppOptions), mw.XSSMiddleware, mw.MaxQueryParams(100), trustInternal, jwtMiddleware, mw.ResponseTimeMiddleware, mw.Cors)
 
 	// Create custom server
 	server := &http.Server{
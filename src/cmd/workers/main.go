@@ -49,6 +49,14 @@ func main() {
 		log.Fatal().Err(err).Msg("failed to initialize server")
 	}
 
+	// Report queue depths from the worker process only; every API instance polling the
+	// same queues would just repeat the numbers
+	srv.Job.StartQueueMetrics(loggerService.GetApplication())
+	srv.RegisterShutdownHook("job_queue_metrics", func(ctx context.Context) error {
+		srv.Job.StopQueueMetrics()
+		return nil
+	})
+
 	// Initialize repositories, services, and handlers
 	repos := repository.NewRepositories(srv)
 	services, serviceErr := service.NewServices(srv, repos)
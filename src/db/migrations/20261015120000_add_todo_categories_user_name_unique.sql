@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+-- CreateCategory relies on ON CONFLICT (user_id, name), which needs a unique constraint
+-- to infer. Duplicates are merged into the oldest category of each name first, moving
+-- their todos over. Guarded so it is a no-op until the table exists.
+DO $$
+BEGIN
+    IF to_regclass('todo_categories') IS NULL THEN
+        RETURN;
+    END IF;
+
+    CREATE TEMPORARY TABLE category_duplicates ON COMMIT DROP AS
+    SELECT id, keep_id
+    FROM (
+        SELECT
+            id,
+            first_value(id) OVER (PARTITION BY user_id, name ORDER BY created_at, id) AS keep_id
+        FROM todo_categories
+    ) ranked
+    WHERE id <> keep_id;
+
+    IF to_regclass('todos') IS NOT NULL THEN
+        UPDATE todos t
+        SET category_id = d.keep_id
+        FROM category_duplicates d
+        WHERE t.category_id = d.id;
+    END IF;
+
+    DELETE FROM todo_categories c
+    USING category_duplicates d
+    WHERE c.id = d.id;
+
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint
+        WHERE conname = 'todo_categories_user_id_name_key'
+    ) THEN
+        ALTER TABLE todo_categories
+            ADD CONSTRAINT todo_categories_user_id_name_key UNIQUE (user_id, name);
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE IF EXISTS todo_categories
+    DROP CONSTRAINT IF EXISTS todo_categories_user_id_name_key;
+-- +goose StatementEnd
@@ -24,6 +24,12 @@ type Server struct {
 	Redis         *redis.Client
 	httpServer    *http.Server
 	Job           *job.JobService
+	// ShutdownHookTimeout bounds each hook registered with RegisterShutdownHook.
+	// Defaults to DefaultShutdownHookTimeout
+	ShutdownHookTimeout time.Duration
+
+	startHooks    startHooks
+	shutdownHooks shutdownHooks
 }
 
 func New(cfg *config.Config, logger *zerolog.Logger, loggerService *loggerPkg.LoggerService) (*Server, error) {
@@ -64,7 +70,7 @@ func New(cfg *config.Config, logger *zerolog.Logger, loggerService *loggerPkg.Lo
 		LoggerService: loggerService,
 		DB:            db,
 		Redis:         redisClient,
-		// Job:           jobService,
+		Job:           jobService,
 	}
 
 	// Start metrics collection
@@ -93,16 +99,25 @@ func (s *Server) Start() error {
 		Str("env", s.Config.Primary.Env).
 		Msg("starting server")
 
+	s.runStartHooks(context.Background())
+
 	return s.httpServer.ListenAndServe()
 }
 
 func (s *Server) Shutdown(ctx context.Context) error {
-	if err := s.httpServer.Shutdown(ctx); err != nil {
-		return fmt.Errorf("failed to shutdown HTTP server: %w", err)
+	if s.httpServer != nil {
+		if err := s.httpServer.Shutdown(ctx); err != nil {
+			return fmt.Errorf("failed to shutdown HTTP server: %w", err)
+		}
 	}
 
-	if err := s.DB.Close(); err != nil {
-		return fmt.Errorf("failed to close database connection: %w", err)
+	// Features stop their background work once no request can still be using it
+	s.runShutdownHooks(ctx)
+
+	if s.DB != nil {
+		if err := s.DB.Close(); err != nil {
+			return fmt.Errorf("failed to close database connection: %w", err)
+		}
 	}
 
 	// if s.Job != nil {
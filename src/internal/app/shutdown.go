@@ -0,0 +1,87 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultShutdownHookTimeout bounds each shutdown hook when ShutdownHookTimeout is unset
+const DefaultShutdownHookTimeout = 5 * time.Second
+
+type shutdownHook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+type shutdownHooks struct {
+	mu    sync.Mutex
+	hooks []shutdownHook
+}
+
+// RegisterShutdownHook adds fn to the hooks run by Shutdown, once the HTTP server has
+// drained and before the database closes. Hooks run in reverse registration order, so a
+// feature started later is stopped before the ones it may depend on. Each hook gets its
+// own ShutdownHookTimeout; one that fails or overruns is logged and the rest still run.
+func (s *Server) RegisterShutdownHook(name string, fn func(ctx context.Context) error) {
+	s.shutdownHooks.mu.Lock()
+	defer s.shutdownHooks.mu.Unlock()
+
+	s.shutdownHooks.hooks = append(s.shutdownHooks.hooks, shutdownHook{name: name, fn: fn})
+}
+
+func (s *Server) runShutdownHooks(ctx context.Context) {
+	s.shutdownHooks.mu.Lock()
+	hooks := s.shutdownHooks.hooks
+	s.shutdownHooks.hooks = nil
+	s.shutdownHooks.mu.Unlock()
+
+	timeout := s.ShutdownHookTimeout
+	if timeout <= 0 {
+		timeout = DefaultShutdownHookTimeout
+	}
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hook := hooks[i]
+		start := time.Now()
+
+		err := runShutdownHook(ctx, hook, timeout)
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			s.Logger.Warn().
+				Str("hook", hook.name).
+				Dur("timeout", timeout).
+				Msg("shutdown hook timed out")
+		case err != nil:
+			s.Logger.Error().
+				Err(err).
+				Str("hook", hook.name).
+				Dur("duration", time.Since(start)).
+				Msg("shutdown hook failed")
+		default:
+			s.Logger.Info().
+				Str("hook", hook.name).
+				Dur("duration", time.Since(start)).
+				Msg("shutdown hook completed")
+		}
+	}
+}
+
+// runShutdownHook waits for the hook until its timeout, even if the hook ignores ctx
+func runShutdownHook(ctx context.Context, hook shutdownHook, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- hook.fn(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,105 @@
+package app_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(logs *bytes.Buffer) *app.Server {
+	logger := zerolog.New(logs)
+	return &app.Server{Logger: &logger, ShutdownHookTimeout: 50 * time.Millisecond}
+}
+
+func TestServer_RegisterShutdownHook(t *testing.T) {
+	t.Run("hooks run in reverse order on shutdown", func(t *testing.T) {
+		var logs bytes.Buffer
+		s := newTestServer(&logs)
+
+		var mu sync.Mutex
+		var ran []string
+		for _, name := range []string{"first", "second", "third"} {
+			s.RegisterShutdownHook(name, func(ctx context.Context) error {
+				mu.Lock()
+				defer mu.Unlock()
+				ran = append(ran, name)
+				return nil
+			})
+		}
+
+		require.NoError(t, s.Shutdown(context.Background()))
+
+		assert.Equal(t, []string{"third", "second", "first"}, ran)
+		assert.Contains(t, logs.String(), "shutdown hook completed")
+	})
+
+	t.Run("a failing hook does not stop the others", func(t *testing.T) {
+		var logs bytes.Buffer
+		s := newTestServer(&logs)
+
+		ran := false
+		s.RegisterShutdownHook("flush", func(ctx context.Context) error {
+			ran = true
+			return nil
+		})
+		s.RegisterShutdownHook("relay", func(ctx context.Context) error {
+			return errors.New("relay already closed")
+		})
+
+		require.NoError(t, s.Shutdown(context.Background()))
+
+		assert.True(t, ran)
+		assert.Contains(t, logs.String(), "relay already closed")
+	})
+
+	t.Run("a hook that overruns its timeout is abandoned", func(t *testing.T) {
+		var logs bytes.Buffer
+		s := newTestServer(&logs)
+
+		ran := false
+		s.RegisterShutdownHook("flush", func(ctx context.Context) error {
+			ran = true
+			return nil
+		})
+
+		// Ignores its context entirely, like a stuck poller
+		release := make(chan struct{})
+		defer close(release)
+		s.RegisterShutdownHook("stuck", func(ctx context.Context) error {
+			<-release
+			return nil
+		})
+
+		start := time.Now()
+		require.NoError(t, s.Shutdown(context.Background()))
+
+		assert.Less(t, time.Since(start), time.Second)
+		assert.True(t, ran)
+		assert.Contains(t, logs.String(), "shutdown hook timed out")
+		assert.Contains(t, logs.String(), `"hook":"stuck"`)
+	})
+
+	t.Run("hooks run once", func(t *testing.T) {
+		var logs bytes.Buffer
+		s := newTestServer(&logs)
+
+		calls := 0
+		s.RegisterShutdownHook("once", func(ctx context.Context) error {
+			calls++
+			return nil
+		})
+
+		require.NoError(t, s.Shutdown(context.Background()))
+		require.NoError(t, s.Shutdown(context.Background()))
+
+		assert.Equal(t, 1, calls)
+	})
+}
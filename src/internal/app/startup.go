@@ -0,0 +1,39 @@
+package app
+
+import (
+	"context"
+	"sync"
+)
+
+type startHook struct {
+	name string
+	fn   func(ctx context.Context)
+}
+
+type startHooks struct {
+	mu    sync.Mutex
+	hooks []startHook
+}
+
+// RegisterStartHook adds fn to the hooks run by Start, in registration order, before the
+// HTTP server begins listening. It is where background work tied to serving requests is
+// started, so merely building a router (as tests do) leaves it stopped. Pair it with a
+// shutdown hook that stops the work again.
+func (s *Server) RegisterStartHook(name string, fn func(ctx context.Context)) {
+	s.startHooks.mu.Lock()
+	defer s.startHooks.mu.Unlock()
+
+	s.startHooks.hooks = append(s.startHooks.hooks, startHook{name: name, fn: fn})
+}
+
+func (s *Server) runStartHooks(ctx context.Context) {
+	s.startHooks.mu.Lock()
+	hooks := s.startHooks.hooks
+	s.startHooks.hooks = nil
+	s.startHooks.mu.Unlock()
+
+	for _, hook := range hooks {
+		hook.fn(ctx)
+		s.Logger.Info().Str("hook", hook.name).Msg("start hook completed")
+	}
+}
@@ -0,0 +1,51 @@
+package app_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_RegisterStartHook(t *testing.T) {
+	t.Run("hooks run in order when the server starts", func(t *testing.T) {
+		var logs bytes.Buffer
+		s := newTestServer(&logs)
+		s.Config = &config.Config{Server: config.ServerConfig{Port: "0"}}
+		s.SetupHTTPServer(http.NotFoundHandler())
+
+		var mu sync.Mutex
+		var ran []string
+		for _, name := range []string{"first", "second"} {
+			s.RegisterStartHook(name, func(ctx context.Context) {
+				mu.Lock()
+				defer mu.Unlock()
+				ran = append(ran, name)
+			})
+		}
+
+		mu.Lock()
+		assert.Empty(t, ran, "registering a hook must not run it")
+		mu.Unlock()
+
+		started := make(chan error, 1)
+		go func() { started <- s.Start() }()
+
+		assert.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(ran) == 2
+		}, time.Second, 10*time.Millisecond)
+
+		require.NoError(t, s.Shutdown(context.Background()))
+		assert.True(t, errors.Is(<-started, http.ErrServerClosed))
+		assert.Equal(t, []string{"first", "second"}, ran)
+	})
+}
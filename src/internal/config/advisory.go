@@ -0,0 +1,103 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// MinRecommendedSecretLength is the default minimum auth secret length. Shorter secrets
+// fail validation in production and trigger an advisory elsewhere
+const MinRecommendedSecretLength = 32
+
+// MinSecretEntropyBits is the estimated entropy below which a long enough auth secret
+// still triggers an advisory, e.g. a repeated character or a short repeated pattern. The
+// estimate only sees character frequencies, so it cannot recognise dictionary words.
+const MinSecretEntropyBits = 96
+
+// Advisory describes a setting that passed validation but weakens the security posture
+type Advisory struct {
+	Field   string
+	Message string
+}
+
+// CheckAdvisories runs the non-fatal security pass over an already validated config
+func CheckAdvisories(cfg *Config) []Advisory {
+	var advisories []Advisory
+
+	if cfg.S3.Prefix == "" {
+		advisories = append(advisories, Advisory{
+			Field:   "s3.prefix",
+			Message: "S3 prefix is empty, objects will be written to the bucket root",
+		})
+	}
+
+	for _, origin := range strings.Split(cfg.Server.CORSAllowedOrigins, ",") {
+		if strings.TrimSpace(origin) == "*" {
+			message := "CORS allows any origin"
+			if cfg.Server.CORSAllowAnyOrigin {
+				message = "CORS reflects any origin with credentials (cors_allow_any_origin)"
+			}
+			advisories = append(advisories, Advisory{
+				Field:   "server.cors_allowed_origins",
+				Message: message,
+			})
+			break
+		}
+	}
+
+	if required := cfg.Auth.RequiredSecretLength(); len(cfg.Auth.SecretKey) < required {
+		advisories = append(advisories, Advisory{
+			Field: "auth.secret_key",
+			Message: fmt.Sprintf("auth secret is shorter than %d characters (got %d)",
+				required, len(cfg.Auth.SecretKey)),
+		})
+	} else if bits := entropyBits(cfg.Auth.SecretKey); bits < MinSecretEntropyBits {
+		advisories = append(advisories, Advisory{
+			Field: "auth.secret_key",
+			Message: fmt.Sprintf("auth secret has low entropy (about %d bits, want %d)",
+				int(bits), MinSecretEntropyBits),
+		})
+	}
+
+	if cfg.Database.SSLMode == "disable" {
+		advisories = append(advisories, Advisory{
+			Field:   "database.ssl_mode",
+			Message: "database connections are not encrypted (ssl_mode=disable)",
+		})
+	}
+
+	return advisories
+}
+
+// entropyBits estimates the entropy of s from its own character frequencies. It
+// underestimates short random strings, so it only flags obviously weak values.
+func entropyBits(s string) float64 {
+	counts := map[rune]int{}
+	total := 0
+	for _, r := range s {
+		counts[r]++
+		total++
+	}
+
+	var perChar float64
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		perChar -= p * math.Log2(p)
+	}
+	return perChar * float64(total)
+}
+
+// WarnInsecureDefaults logs every advisory as a warning and returns them
+func WarnInsecureDefaults(cfg *Config, logger zerolog.Logger) []Advisory {
+	advisories := CheckAdvisories(cfg)
+	for _, advisory := range advisories {
+		logger.Warn().
+			Str("field", advisory.Field).
+			Str("env", cfg.Primary.Env).
+			Msg(advisory.Message)
+	}
+	return advisories
+}
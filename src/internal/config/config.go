@@ -2,8 +2,11 @@ package config
 
 import (
 	"fmt"
+	"math"
 	"os"
+	"reflect"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	_ "github.com/joho/godotenv/autoload"
@@ -27,6 +30,13 @@ type Config struct {
 	Auth          AuthConfig           `koanf:"auth" validate:"required"`
 	Observability *ObservabilityConfig `koanf:"observability"`
 	Cron          *CronConfig          `koanf:"cron"`
+	RateLimit     *RateLimitConfig     `koanf:"rate_limit"`
+	Worker        *WorkerConfig        `koanf:"worker"`
+	Security      *SecurityConfig      `koanf:"security"`
+	Comments      *CommentsConfig      `koanf:"comments"`
+	Compression   *CompressionConfig   `koanf:"compression"`
+	Breaker       *BreakerConfig       `koanf:"breaker"`
+	Deprecation   *DeprecationConfig   `koanf:"deprecation"`
 }
 
 // PrimaryConfig contains basic environment configuration
@@ -42,6 +52,48 @@ type ServerConfig struct {
 	WriteTimeout       int    `koanf:"write_timeout" validate:"required,min=1"`
 	IdleTimeout        int    `koanf:"idle_timeout" validate:"required,min=1"`
 	CORSAllowedOrigins string `koanf:"cors_allowed_origins" validate:"required"`
+	// CORSAllowAnyOrigin makes a "*" origin reflect the request's Origin and allow
+	// credentials, i.e. any site can make authenticated cross-origin requests. Without it
+	// "*" is sent literally and browsers never attach credentials
+	CORSAllowAnyOrigin bool `koanf:"cors_allow_any_origin"`
+	// RequestIDHeader is read for an incoming request ID and used to return it.
+	// Defaults to X-Request-ID
+	RequestIDHeader string `koanf:"request_id_header"`
+	// RequestIDCandidateHeaders is a comma-separated list of further headers to take the
+	// request ID from when RequestIDHeader is absent, e.g. "X-Correlation-ID,Request-Id"
+	RequestIDCandidateHeaders string `koanf:"request_id_candidate_headers"`
+	// ErrorDetails controls how much of a 5xx error reaches the client: "full" adds the
+	// underlying error, "message" sends the error message as is and "generic" only the
+	// status text. Defaults to full in development, generic in production and message
+	// otherwise
+	ErrorDetails string `koanf:"error_details" validate:"omitempty,oneof=full message generic"`
+	// RequestTimeout is the deadline given to every request's context. Zero leaves
+	// requests without one unless the client sends a budget
+	RequestTimeout time.Duration `koanf:"request_timeout" validate:"omitempty,min=1ms"`
+	// MaxRequestBudget caps the X-Request-Budget-Ms a client may ask for. Defaults to 30s
+	MaxRequestBudget time.Duration `koanf:"max_request_budget" validate:"omitempty,min=1ms"`
+}
+
+const (
+	ErrorDetailsFull    = "full"
+	ErrorDetailsMessage = "message"
+	ErrorDetailsGeneric = "generic"
+)
+
+// ErrorDetailsMode returns the configured ErrorDetails or the default for the environment
+func (c *Config) ErrorDetailsMode() string {
+	if c.Server.ErrorDetails != "" {
+		return c.Server.ErrorDetails
+	}
+
+	switch c.Primary.Env {
+	case "development":
+		return ErrorDetailsFull
+	case "production":
+		return ErrorDetailsGeneric
+	default:
+		return ErrorDetailsMessage
+	}
 }
 
 // DatabaseConfig contains PostgreSQL database configuration
@@ -56,7 +108,18 @@ type DatabaseConfig struct {
 	MaxOpenConns    int    `koanf:"max_open_conns" validate:"required,min=1"`
 	MaxIdleConns    int    `koanf:"max_idle_conns" validate:"required,min=0"`
 	ConnMaxLifetime int    `koanf:"conn_max_lifetime" validate:"required,min=1"`
-	ConnMaxIdleTime int    `koanf:"conn_max_idle_time" validate:"required,min=0"`
+	// ConnMaxIdleTime is how many seconds a connection may sit idle before the pool reaps it
+	ConnMaxIdleTime int `koanf:"conn_max_idle_time" validate:"required,min=1"`
+	// HealthCheckPeriod is how often the pool checks idle connections and reaps expired
+	// ones. Defaults to one minute
+	HealthCheckPeriod time.Duration `koanf:"health_check_period" validate:"omitempty,min=1s"`
+	// ApplicationName shows up in pg_stat_activity. Defaults to Fortress_API
+	ApplicationName string `koanf:"application_name" validate:"omitempty,max=63"`
+	// StatementTimeout aborts statements running longer than this many milliseconds.
+	// 0 leaves the server default in place
+	StatementTimeout int `koanf:"statement_timeout" validate:"omitempty,min=0"`
+	// SearchPath is a comma-separated list of schemas, e.g. "app,public"
+	SearchPath string `koanf:"search_path"`
 }
 
 // RedisConfig contains Redis configuration
@@ -82,16 +145,52 @@ type EmailConfig struct {
 	ResendKey string `koanf:"resend_key" validate:"required"`
 }
 
-// S3Config contains AWS S3 backup configuration
+// S3Config contains AWS S3 backup and attachment configuration. The credentials are
+// only required while a feature that uses S3 is on, see validateS3Config.
 type S3Config struct {
-	BackupEnabled bool   `koanf:"backup_enabled"`
-	AccessKey     string `koanf:"access_key" validate:"required"`
-	SecretKey     string `koanf:"secret_key" validate:"required"`
-	Region        string `koanf:"region" validate:"required"`
-	Bucket        string `koanf:"bucket" validate:"required"`
-	Prefix        string `koanf:"prefix"`
-	EndpointURL   string `koanf:"endpoint_url"`
+	BackupEnabled bool `koanf:"backup_enabled"`
+	// AttachmentsEnabled turns todo attachment uploads on or off. Defaults to on
+	AttachmentsEnabled *bool  `koanf:"attachments_enabled"`
+	AccessKey          string `koanf:"access_key"`
+	SecretKey          string `koanf:"secret_key"`
+	Region             string `koanf:"region"`
+	Bucket             string `koanf:"bucket"`
+	Prefix             string `koanf:"prefix"`
+	EndpointURL        string `koanf:"endpoint_url"`
+}
+
+// AttachmentsOn reports whether todo attachments are enabled
+func (s S3Config) AttachmentsOn() bool {
+	return s.AttachmentsEnabled == nil || *s.AttachmentsEnabled
 }
+
+// Enabled reports whether any feature that talks to S3 is on
+func (s S3Config) Enabled() bool {
+	return s.BackupEnabled || s.AttachmentsOn()
+}
+
+// validateS3Config requires the S3 credentials only while S3 is in use, so a
+// deployment without backups or attachments needs no placeholder values
+func validateS3Config(sl validator.StructLevel) {
+	s3 := sl.Current().Interface().(S3Config)
+	if !s3.Enabled() {
+		return
+	}
+
+	for _, field := range []struct {
+		key, name, value string
+	}{
+		{"access_key", "AccessKey", s3.AccessKey},
+		{"secret_key", "SecretKey", s3.SecretKey},
+		{"region", "Region", s3.Region},
+		{"bucket", "Bucket", s3.Bucket},
+	} {
+		if field.value == "" {
+			sl.ReportError(field.value, field.key, field.name, "required", "")
+		}
+	}
+}
+
 type CronConfig struct {
 	ArchiveDaysThreshold        int `koanf:"archive_days_threshold"`
 	BatchSize                   int `koanf:"batch_size"`
@@ -108,9 +207,129 @@ func DefaultCronConfig() *CronConfig {
 	}
 }
 
+// RateLimitConfig contains the global request rate limiter configuration
+type RateLimitConfig struct {
+	Store             string  `koanf:"store" validate:"omitempty,oneof=memory redis"`
+	RequestsPerSecond float64 `koanf:"requests_per_second" validate:"omitempty,gt=0"`
+	// Burst is how many requests a client may make at once on top of the steady rate.
+	// Zero falls back to one second's worth of requests
+	Burst int `koanf:"burst" validate:"omitempty,min=1"`
+}
+
+func DefaultRateLimitConfig() *RateLimitConfig {
+	return &RateLimitConfig{
+		Store:             "memory",
+		RequestsPerSecond: 20,
+		Burst:             40,
+	}
+}
+
+// BurstSize returns the configured burst, or the steady rate rounded up when none is set
+func (c *RateLimitConfig) BurstSize() int {
+	if c.Burst > 0 {
+		return c.Burst
+	}
+	return int(math.Ceil(c.RequestsPerSecond))
+}
+
+// CompressionConfig controls gzip compression of responses. Server-Sent Events are
+// never compressed, since buffering in the compressor would hold events back
+type CompressionConfig struct {
+	Level int `koanf:"level" validate:"omitempty,min=1,max=9"`
+	// MinLength is the smallest response body, in bytes, worth compressing
+	MinLength int `koanf:"min_length" validate:"omitempty,min=0"`
+	// StreamingPaths is a comma-separated list of path prefixes (e.g. "/api/v1/events")
+	// whose responses are streamed and must not be compressed
+	StreamingPaths string `koanf:"streaming_paths"`
+}
+
+func DefaultCompressionConfig() *CompressionConfig {
+	return &CompressionConfig{
+		Level:     5,
+		MinLength: 1024,
+	}
+}
+
+// WorkerConfig contains background job worker configuration
+type WorkerConfig struct {
+	// Concurrency is required once the worker section is set at all; asynq would
+	// otherwise treat 0 as "use runtime.NumCPU()" without saying so
+	Concurrency int `koanf:"concurrency" validate:"required,min=1"`
+	// TaskConcurrency caps how many tasks of a given type (e.g. "email:welcome") run at
+	// once, regardless of the overall worker concurrency. Types not listed are unlimited
+	TaskConcurrency map[string]int `koanf:"task_concurrency" validate:"omitempty,dive,min=1"`
+	// MetricsPollInterval controls how often queue depths and dead-letter counts are sampled
+	MetricsPollInterval time.Duration `koanf:"metrics_poll_interval" validate:"omitempty,min=1s"`
+}
+
+func DefaultWorkerConfig() *WorkerConfig {
+	return &WorkerConfig{
+		Concurrency: 10,
+		TaskConcurrency: map[string]int{
+			"email:welcome": 5,
+		},
+		MetricsPollInterval: 30 * time.Second,
+	}
+}
+
+// CommentsConfig contains limits applied to todo comments
+type CommentsConfig struct {
+	// MaxPerTodo caps how many comments a single todo can hold
+	MaxPerTodo int `koanf:"max_per_todo" validate:"omitempty,min=1"`
+}
+
+func DefaultCommentsConfig() *CommentsConfig {
+	return &CommentsConfig{
+		MaxPerTodo: 500,
+	}
+}
+
+// BreakerConfig controls the circuit breakers around external dependencies (Clerk,
+// email). While a breaker is open, dependent endpoints answer 503 with Retry-After
+type BreakerConfig struct {
+	// Threshold is how many consecutive failures open the breaker
+	Threshold int `koanf:"threshold" validate:"omitempty,min=1"`
+	// Cooldown is how long the breaker stays open before a trial call is let through
+	Cooldown time.Duration `koanf:"cooldown" validate:"omitempty,min=1s"`
+}
+
+func DefaultBreakerConfig() *BreakerConfig {
+	return &BreakerConfig{
+		Threshold: 5,
+		Cooldown:  30 * time.Second,
+	}
+}
+
 // AuthConfig contains authentication configuration
 type AuthConfig struct {
 	SecretKey string `koanf:"secret_key" validate:"required"`
+	// JWKSRefreshInterval controls how often Clerk's signing keys are re-fetched in the
+	// background. Defaults to one hour
+	JWKSRefreshInterval time.Duration `koanf:"jwks_refresh_interval" validate:"omitempty,min=1m"`
+	// MinSecretLength is the shortest SecretKey accepted in production. Defaults to
+	// MinRecommendedSecretLength
+	MinSecretLength int `koanf:"min_secret_length" validate:"omitempty,min=1"`
+}
+
+// RequiredSecretLength returns MinSecretLength or its default
+func (a AuthConfig) RequiredSecretLength() int {
+	if a.MinSecretLength > 0 {
+		return a.MinSecretLength
+	}
+	return MinRecommendedSecretLength
+}
+
+// ValidateSecret rejects a SecretKey shorter than RequiredSecretLength in production.
+// Other environments only get an advisory, see CheckAdvisories.
+func (a AuthConfig) ValidateSecret(env string) error {
+	if env != "production" {
+		return nil
+	}
+	if required := a.RequiredSecretLength(); len(a.SecretKey) < required {
+		return fmt.Errorf("auth.secret_key must be at least %d characters in production (got %d)",
+			required, len(a.SecretKey))
+	}
+	return nil
 }
 
 
@@ -122,8 +341,8 @@ func LoadConfig(envFilePath string) (*Config, error) {
 
 	k := koanf.New(".")
 
-	err := k.Load(env.Provider("BOILERPLATE_", ".", func(s string) string {
-		return strings.ToLower(strings.TrimPrefix(s, "BOILERPLATE_"))
+	err := k.Load(env.Provider(EnvPrefix, ".", func(s string) string {
+		return strings.ToLower(strings.TrimPrefix(s, EnvPrefix))
 	}), nil)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("could not load initial env variables")
@@ -139,11 +358,37 @@ func LoadConfig(envFilePath string) (*Config, error) {
 	if err := ValidateConfig(mainConfig); err != nil {
 		return nil, err
 	}
+	// Surface insecure-but-valid settings without blocking startup
+	WarnInsecureDefaults(mainConfig, logger)
 	// Set default observability config if not provided
 	if mainConfig.Observability == nil {
 		mainConfig.Observability = DefaultObservabilityConfig()
 	}
 
+	if mainConfig.RateLimit == nil {
+		mainConfig.RateLimit = DefaultRateLimitConfig()
+	}
+
+	if mainConfig.Worker == nil {
+		mainConfig.Worker = DefaultWorkerConfig()
+	}
+
+	if mainConfig.Security == nil {
+		mainConfig.Security = DefaultSecurityConfig()
+	}
+
+	if mainConfig.Comments == nil {
+		mainConfig.Comments = DefaultCommentsConfig()
+	}
+
+	if mainConfig.Compression == nil {
+		mainConfig.Compression = DefaultCompressionConfig()
+	}
+
+	if mainConfig.Breaker == nil {
+		mainConfig.Breaker = DefaultBreakerConfig()
+	}
+
 	// Override service name and environment from primary config
 	mainConfig.Observability.ServiceName = "Fortress_API"
 	mainConfig.Observability.Environment = mainConfig.Primary.Env
@@ -152,30 +397,51 @@ func LoadConfig(envFilePath string) (*Config, error) {
 	if err := mainConfig.Observability.Validate(); err != nil {
 		// logger.Fatal().Err(err).Msg("invalid observability config")
 	}
+
+	LogEffectiveConfig(mainConfig, envFilePath, logger)
+
 	return mainConfig, nil
 }
 
 func ValidateConfig(cfg *Config) error {
 	validate := validator.New()
+	// Report fields by their koanf key (e.g. "database.host") so they can be mapped back
+	// to the environment variable they are read from
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return field.Tag.Get("koanf")
+	})
+	validate.RegisterStructValidation(validateS3Config, S3Config{})
 
 	if err := validate.Struct(cfg); err != nil {
-		var errMessages []string
+		var errMessages, failedKeys []string
 		for _, validationErr := range err.(validator.ValidationErrors) {
 			errMessages = append(errMessages, formatValidationError(validationErr))
+			failedKeys = append(failedKeys, configKey(validationErr))
 		}
-		return fmt.Errorf("config validation failed:\n  - %s",
-			strings.Join(errMessages, "\n  - "))
+		return fmt.Errorf("config validation failed:\n  - %s\n%s",
+			strings.Join(errMessages, "\n  - "), envDiagnostics(failedKeys, os.Environ()))
+	}
+
+	if err := cfg.Auth.ValidateSecret(cfg.Primary.Env); err != nil {
+		return fmt.Errorf("config validation failed:\n  - %w", err)
 	}
 
 	return nil
 }
 
-// formatValidationError formats a validation error for better readability
+// configKey turns a namespace such as "Config.database.host" into the koanf key
+func configKey(err validator.FieldError) string {
+	_, key, _ := strings.Cut(err.Namespace(), ".")
+	return key
+}
+
+// formatValidationError formats a validation error for better readability. Secret
+// values are redacted.
 func formatValidationError(err validator.FieldError) string {
 	return fmt.Sprintf(
 		"field '%s' failed validation '%s' (value: %v)",
 		err.StructNamespace(),
 		err.Tag(),
-		err.Value(),
+		redactConfigValue(configKey(err), err.Value()),
 	)
 }
@@ -0,0 +1,12 @@
+package config
+
+// DeprecationConfig marks routes that are being sunset. Requests to them are answered
+// with Deprecation and Sunset headers (RFC 8594) and their use is logged, so clients
+// can be found and migrated before the route is removed.
+type DeprecationConfig struct {
+	// Routes is a comma-separated list of "METHOD /route/template=YYYY-MM-DD" entries,
+	// e.g. "GET /api/v1/todos/export=2027-01-31,/api/v1/todos/:id/legacy". Routes are
+	// matched by their template as registered, not the request path. The method can be
+	// left out to match every method, and the sunset date to send no Sunset header.
+	Routes string `koanf:"routes"`
+}
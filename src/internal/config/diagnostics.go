@@ -0,0 +1,109 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// EnvPrefix is stripped from environment variable names before they are mapped to keys
+const EnvPrefix = "BOILERPLATE_"
+
+// EnvVarName returns the environment variable a config key is read from. Keys are the
+// lowercased variable names without the prefix, and "." separates sections, so
+// "database.host" is read from BOILERPLATE_DATABASE.HOST.
+func EnvVarName(key string) string {
+	return EnvPrefix + strings.ToUpper(key)
+}
+
+// envDiagnostics explains config validation failures in terms of environment variables:
+// for every failing key the variable that was expected and any similarly named variable
+// that was set instead, followed by which required variables were found and which are
+// missing. Only variable names are reported, never values.
+func envDiagnostics(failedKeys []string, environ []string) string {
+	set := make(map[string]bool)
+	for _, entry := range environ {
+		name, _, _ := strings.Cut(entry, "=")
+		set[name] = true
+	}
+
+	var b strings.Builder
+
+	b.WriteString("environment variables for the failing fields:")
+	for _, key := range failedKeys {
+		name := EnvVarName(key)
+		fmt.Fprintf(&b, "\n  - %s: expected %s", key, name)
+		if set[name] {
+			b.WriteString(" (set, but its value is invalid)")
+			continue
+		}
+		b.WriteString(" (not set")
+		if similar := similarEnvVars(name, set); len(similar) > 0 {
+			fmt.Fprintf(&b, "; found %s, which is not read for this key", strings.Join(similar, ", "))
+		}
+		b.WriteString(")")
+	}
+
+	var found, missing []string
+	for _, key := range requiredConfigKeys(reflect.TypeOf(Config{}), "") {
+		if set[EnvVarName(key)] {
+			found = append(found, EnvVarName(key))
+		} else {
+			missing = append(missing, EnvVarName(key))
+		}
+	}
+	fmt.Fprintf(&b, "\nrequired environment variables found: %s", joinOrNone(found))
+	fmt.Fprintf(&b, "\nrequired environment variables missing: %s", joinOrNone(missing))
+
+	return b.String()
+}
+
+// similarEnvVars finds set variables that differ from name only in case, in using "_"
+// instead of "." between sections, or in a missing prefix
+func similarEnvVars(name string, set map[string]bool) []string {
+	normalize := func(s string) string {
+		s = strings.ToUpper(strings.ReplaceAll(s, ".", "_"))
+		return strings.TrimPrefix(s, EnvPrefix)
+	}
+
+	want := normalize(name)
+	var similar []string
+	for candidate := range set {
+		if candidate != name && normalize(candidate) == want {
+			similar = append(similar, candidate)
+		}
+	}
+	slices.Sort(similar)
+	return similar
+}
+
+// requiredConfigKeys lists the keys of required fields, skipping optional (pointer)
+// sections, which fall back to defaults when absent
+func requiredConfigKeys(t reflect.Type, prefix string) []string {
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Type.Kind() == reflect.Pointer {
+			continue
+		}
+
+		key := joinConfigKey(prefix, field.Tag.Get("koanf"))
+		if field.Type.Kind() == reflect.Struct && field.Type.PkgPath() == t.PkgPath() {
+			keys = append(keys, requiredConfigKeys(field.Type, key)...)
+			continue
+		}
+
+		if slices.Contains(strings.Split(field.Tag.Get("validate"), ","), "required") {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func joinOrNone(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
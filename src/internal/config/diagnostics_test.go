@@ -0,0 +1,54 @@
+package config_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvVarName(t *testing.T) {
+	assert.Equal(t, "BOILERPLATE_DATABASE.HOST", config.EnvVarName("database.host"))
+	assert.Equal(t, "BOILERPLATE_SERVER.CORS_ALLOWED_ORIGINS", config.EnvVarName("server.cors_allowed_origins"))
+}
+
+func TestLoadConfig_EnvDiagnostics(t *testing.T) {
+	t.Setenv("BOILERPLATE_PRIMARY.ENV", "development")
+	t.Setenv("BOILERPLATE_DATABASE.PASSWORD", "db-hunter2")
+	// Misnamed: "_" instead of "." between section and field, so it maps to "database_host"
+	t.Setenv("BOILERPLATE_DATABASE_HOST", "db.internal")
+
+	_, err := config.LoadConfig(filepath.Join(t.TempDir(), ".env"))
+	require.Error(t, err)
+	msg := err.Error()
+
+	assert.Contains(t, msg, "database.host: expected BOILERPLATE_DATABASE.HOST (not set; found BOILERPLATE_DATABASE_HOST, which is not read for this key)")
+	assert.Contains(t, msg, "server.port: expected BOILERPLATE_SERVER.PORT (not set)")
+
+	found := lineStartingWith(t, msg, "required environment variables found:")
+	assert.Contains(t, found, "BOILERPLATE_PRIMARY.ENV")
+	assert.Contains(t, found, "BOILERPLATE_DATABASE.PASSWORD")
+
+	missing := lineStartingWith(t, msg, "required environment variables missing:")
+	assert.Contains(t, missing, "BOILERPLATE_DATABASE.HOST")
+	assert.NotContains(t, missing, "BOILERPLATE_DATABASE.PASSWORD")
+
+	// Only names are reported, never values
+	assert.NotContains(t, msg, "db-hunter2")
+	assert.NotContains(t, msg, "db.internal")
+}
+
+func lineStartingWith(t *testing.T, text, prefix string) string {
+	t.Helper()
+
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(line, prefix) {
+			return line
+		}
+	}
+	t.Fatalf("no line starting with %q in:\n%s", prefix, text)
+	return ""
+}
@@ -0,0 +1,26 @@
+package config
+
+// FeatureSet reports which optional subsystems a deployment has turned on
+type FeatureSet struct {
+	// Backup is on when scheduled S3 backups are enabled
+	Backup bool `json:"backup"`
+	// Attachments is on when todo attachments can be uploaded to S3
+	Attachments bool `json:"attachments"`
+	// Observability is on when a New Relic license key is configured
+	Observability bool `json:"observability"`
+	// Email is on when an email provider key is configured
+	Email bool `json:"email"`
+	// Cache is on when Redis is available for the response cache
+	Cache bool `json:"cache"`
+}
+
+// Features derives the enabled optional subsystems from the config
+func (c *Config) Features() FeatureSet {
+	return FeatureSet{
+		Backup:        c.S3.BackupEnabled,
+		Attachments:   c.S3.AttachmentsOn(),
+		Observability: c.Observability != nil && c.Observability.NewRelic.LicenseKey != "",
+		Email:         c.Email.ResendKey != "",
+		Cache:         c.Redis.Address != "",
+	}
+}
@@ -17,6 +17,9 @@ type LoggingConfig struct {
 	Level              string        `koanf:"level" validate:"required"`
 	Format             string        `koanf:"format" validate:"required"`
 	SlowQueryThreshold time.Duration `koanf:"slow_query_threshold"`
+	// AccessFormat selects the access-log line format: json (structured, default),
+	// logfmt, or cef for SIEM ingestion
+	AccessFormat string `koanf:"access_format" validate:"omitempty,oneof=json logfmt cef"`
 }
 
 type NewRelicConfig struct {
@@ -31,6 +34,45 @@ type HealthChecksConfig struct {
 	Interval time.Duration `koanf:"interval" validate:"min=1s"`
 	Timeout  time.Duration `koanf:"timeout" validate:"min=1s"`
 	Checks   []string      `koanf:"checks"`
+	// DiskPath is the directory whose volume the disk check probes, e.g. the data or
+	// backup directory. Defaults to the working directory
+	DiskPath string `koanf:"disk_path"`
+	// Timeouts overrides Timeout per dependency, so a slow-but-acceptable dependency is
+	// given longer while critical ones fail fast
+	Timeouts HealthCheckTimeouts `koanf:"timeouts"`
+}
+
+// HealthCheckTimeouts bounds each dependency's check. Zero falls back to Timeout
+type HealthCheckTimeouts struct {
+	Database time.Duration `koanf:"database" validate:"omitempty,min=1ms"`
+	Redis    time.Duration `koanf:"redis" validate:"omitempty,min=1ms"`
+}
+
+const (
+	HealthCheckDatabase = "database"
+	HealthCheckRedis    = "redis"
+)
+
+// DefaultHealthCheckTimeout applies when neither Timeout nor a per-dependency timeout is set
+const DefaultHealthCheckTimeout = 5 * time.Second
+
+// TimeoutFor returns how long the check of dependency may take
+func (c HealthChecksConfig) TimeoutFor(dependency string) time.Duration {
+	var timeout time.Duration
+	switch dependency {
+	case HealthCheckDatabase:
+		timeout = c.Timeouts.Database
+	case HealthCheckRedis:
+		timeout = c.Timeouts.Redis
+	}
+
+	if timeout > 0 {
+		return timeout
+	}
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+	return DefaultHealthCheckTimeout
 }
 
 func DefaultObservabilityConfig() *ObservabilityConfig {
@@ -41,6 +83,7 @@ func DefaultObservabilityConfig() *ObservabilityConfig {
 			Level:              "info",
 			Format:             "json",
 			SlowQueryThreshold: 100 * time.Millisecond,
+			AccessFormat:       "json",
 		},
 		NewRelic: NewRelicConfig{
 			LicenseKey:                "",
@@ -53,6 +96,7 @@ func DefaultObservabilityConfig() *ObservabilityConfig {
 			Interval: 30 * time.Second,
 			Timeout:  5 * time.Second,
 			Checks:   []string{"database", "redis"},
+			DiskPath: ".",
 		},
 	}
 }
@@ -0,0 +1,20 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthChecksConfig_TimeoutFor(t *testing.T) {
+	checks := config.HealthChecksConfig{
+		Timeout:  5 * time.Second,
+		Timeouts: config.HealthCheckTimeouts{Redis: time.Second},
+	}
+
+	assert.Equal(t, time.Second, checks.TimeoutFor(config.HealthCheckRedis))
+	assert.Equal(t, 5*time.Second, checks.TimeoutFor(config.HealthCheckDatabase))
+	assert.Equal(t, config.DefaultHealthCheckTimeout, config.HealthChecksConfig{}.TimeoutFor(config.HealthCheckRedis))
+}
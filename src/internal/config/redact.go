@@ -0,0 +1,134 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/rs/zerolog"
+)
+
+// RedactedValue replaces secret values in logged configuration
+const RedactedValue = "[REDACTED]"
+
+// Redacted flattens the config into koanf keys (e.g. "database.password") with secrets
+// masked, so it can be logged or shown to operators. Unset secrets stay empty, which
+// keeps a missing value distinguishable from a configured one.
+func (c *Config) Redacted() map[string]any {
+	values := make(map[string]any)
+	flattenConfig("", reflect.ValueOf(c), values)
+	return values
+}
+
+// LogEffectiveConfig logs the redacted configuration at info level, along with the keys
+// that were taken from the env file and those set (or overridden) in the environment
+func LogEffectiveConfig(cfg *Config, envFilePath string, logger zerolog.Logger) {
+	fromFile, fromEnv := configSources(envFilePath)
+
+	logger.Info().
+		Str("env_file", envFilePath).
+		Interface("config", cfg.Redacted()).
+		Strs("from_file", fromFile).
+		Strs("from_env", fromEnv).
+		Msg("effective configuration loaded")
+}
+
+// configSources splits the BOILERPLATE_ variables in the environment by origin. The env
+// file is loaded without overriding existing variables, so a key whose value differs
+// from the file was set in the environment.
+func configSources(envFilePath string) (fromFile, fromEnv []string) {
+	fileValues, err := godotenv.Read(envFilePath)
+	if err != nil {
+		fileValues = map[string]string{}
+	}
+
+	for _, entry := range os.Environ() {
+		name, value, _ := strings.Cut(entry, "=")
+		if !strings.HasPrefix(name, EnvPrefix) {
+			continue
+		}
+
+		key := strings.ToLower(strings.TrimPrefix(name, EnvPrefix))
+		if fileValue, ok := fileValues[name]; ok && fileValue == value {
+			fromFile = append(fromFile, key)
+		} else {
+			fromEnv = append(fromEnv, key)
+		}
+	}
+
+	slices.Sort(fromFile)
+	slices.Sort(fromEnv)
+	return fromFile, fromEnv
+}
+
+func flattenConfig(key string, v reflect.Value, values map[string]any) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			flattenConfig(key, v.Elem(), values)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := field.Tag.Get("koanf")
+			if name == "" {
+				name = strings.ToLower(field.Name)
+			}
+			flattenConfig(joinConfigKey(key, name), v.Field(i), values)
+		}
+	case reflect.Map:
+		for _, mapKey := range v.MapKeys() {
+			flattenConfig(joinConfigKey(key, fmt.Sprint(mapKey.Interface())), v.MapIndex(mapKey), values)
+		}
+	default:
+		values[key] = redactConfigValue(key, v.Interface())
+	}
+}
+
+func joinConfigKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+func redactConfigValue(key string, value any) any {
+	if d, ok := value.(time.Duration); ok {
+		return d.String()
+	}
+
+	s, ok := value.(string)
+	if !ok || s == "" {
+		return value
+	}
+
+	name := key[strings.LastIndex(key, ".")+1:]
+	switch {
+	case isSecretConfigKey(name):
+		return RedactedValue
+	case strings.HasSuffix(name, "url"):
+		// Connection URLs may embed credentials, keep everything but the password
+		if u, err := url.Parse(s); err == nil {
+			return u.Redacted()
+		}
+	}
+	return s
+}
+
+// isSecretConfigKey matches key names holding credentials: passwords, secrets, tokens
+// and API/license/access keys
+func isSecretConfigKey(name string) bool {
+	return name == "password" ||
+		strings.Contains(name, "secret") ||
+		strings.Contains(name, "token") ||
+		strings.HasSuffix(name, "_key")
+}
@@ -0,0 +1,58 @@
+package config
+
+// SecurityConfig contains HTTP security header configuration
+type SecurityConfig struct {
+	CSP  CSPConfig  `koanf:"csp"`
+	HSTS HSTSConfig `koanf:"hsts"`
+	// PermissionsPolicy is sent verbatim, e.g. "geolocation=(self), microphone=()".
+	// Left empty, no Permissions-Policy header is sent
+	PermissionsPolicy string `koanf:"permissions_policy"`
+	// PlainHTTPAction decides what happens to plain-http requests in production, as seen
+	// through X-Forwarded-Proto behind a TLS-terminating proxy: redirect, reject or allow.
+	// Redirects go to the host of server.url
+	PlainHTTPAction string `koanf:"plain_http_action" validate:"omitempty,oneof=redirect reject allow"`
+	// MaxDecompressionRatio caps how far a gzip-encoded request body may expand
+	// (decompressed/compressed) before it is rejected as a decompression bomb
+	MaxDecompressionRatio int `koanf:"max_decompression_ratio" validate:"omitempty,min=1"`
+	// InternalCIDRs is a comma-separated list of networks (e.g. "10.0.0.0/8,127.0.0.1/32")
+	// trusted to read operational endpoints such as detailed health without a token
+	InternalCIDRs string `koanf:"internal_cidrs"`
+}
+
+// CSPConfig holds the Content-Security-Policy directives. Each directive is a
+// space-separated source list, e.g. "'self' https://fonts.googleapis.com".
+// Directives left empty are omitted from the header.
+type CSPConfig struct {
+	DefaultSrc     string `koanf:"default_src"`
+	ScriptSrc      string `koanf:"script_src"`
+	StyleSrc       string `koanf:"style_src"`
+	ImgSrc         string `koanf:"img_src"`
+	FontSrc        string `koanf:"font_src"`
+	ConnectSrc     string `koanf:"connect_src"`
+	FrameAncestors string `koanf:"frame_ancestors"`
+	ReportURI      string `koanf:"report_uri"`
+	// ReportOnly sends the policy as Content-Security-Policy-Report-Only so violations
+	// are reported without being enforced
+	ReportOnly bool `koanf:"report_only"`
+}
+
+// HSTSConfig controls the Strict-Transport-Security header, which is only sent on
+// HTTPS requests. Preload is off by default: once a domain is on the browsers' preload
+// list, removing it takes months, so it should be an explicit decision.
+type HSTSConfig struct {
+	MaxAge            int  `koanf:"max_age" validate:"omitempty,min=0"`
+	IncludeSubDomains bool `koanf:"include_subdomains"`
+	Preload           bool `koanf:"preload"`
+}
+
+func DefaultSecurityConfig() *SecurityConfig {
+	return &SecurityConfig{
+		HSTS: HSTSConfig{
+			MaxAge:            31536000,
+			IncludeSubDomains: true,
+			Preload:           false,
+		},
+		PlainHTTPAction:       "redirect",
+		MaxDecompressionRatio: 100,
+	}
+}
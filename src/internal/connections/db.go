@@ -3,9 +3,6 @@ package connections
 import (
 	"context"
 	"fmt"
-	"net"
-	"net/url"
-	"strconv"
 	"time"
 
 	pgxzero "github.com/jackc/pgx-zerolog"
@@ -53,24 +50,53 @@ func (mt *multiTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data p
 
 const DatabasePingTimeout = 10
 
-func New(cfg *config.Config, logger *zerolog.Logger, loggerService *loggerConfig.LoggerService) (*Database, error) {
-	hostPort := net.JoinHostPort(cfg.Database.Host, strconv.Itoa(cfg.Database.Port))
-
-	// URL-encode the password
-	encodedPassword := url.QueryEscape(cfg.Database.Password)
-	dsn := fmt.Sprintf("postgres://%s:%s@%s/%s?sslmode=%s",
-		cfg.Database.User,
-		encodedPassword,
-		hostPort,
-		cfg.Database.Name,
-		cfg.Database.SSLMode,
-	)
+// DefaultHealthCheckPeriod is used when DatabaseConfig.HealthCheckPeriod is unset
+const DefaultHealthCheckPeriod = time.Minute
+
+// NewPoolConfig builds the pgx pool configuration for the database: the DSN plus the
+// idle-connection reaping settings. Connections closed by the pool are logged at debug.
+func NewPoolConfig(cfg config.DatabaseConfig, logger *zerolog.Logger) (*pgxpool.Config, error) {
+	if cfg.HealthCheckPeriod < 0 {
+		return nil, fmt.Errorf("invalid health_check_period %s: must be positive", cfg.HealthCheckPeriod)
+	}
+	if cfg.ConnMaxIdleTime <= 0 {
+		return nil, fmt.Errorf("invalid conn_max_idle_time %d: must be positive", cfg.ConnMaxIdleTime)
+	}
+
+	dsn, err := BuildDSN(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build database DSN: %w", err)
+	}
 
 	pgxPoolConfig, err := pgxpool.ParseConfig(dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse pgx pool config: %w", err)
 	}
 
+	pgxPoolConfig.HealthCheckPeriod = DefaultHealthCheckPeriod
+	if cfg.HealthCheckPeriod > 0 {
+		pgxPoolConfig.HealthCheckPeriod = cfg.HealthCheckPeriod
+	}
+	pgxPoolConfig.MaxConnIdleTime = time.Duration(cfg.ConnMaxIdleTime) * time.Second
+
+	// pgxpool does not say why it closes a connection; idle reaping and lifetime expiry
+	// both happen on the health check, so this is where reaped connections show up
+	pgxPoolConfig.BeforeClose = func(conn *pgx.Conn) {
+		logger.Debug().
+			Uint32("pid", conn.PgConn().PID()).
+			Dur("max_conn_idle_time", pgxPoolConfig.MaxConnIdleTime).
+			Msg("database connection closed by pool")
+	}
+
+	return pgxPoolConfig, nil
+}
+
+func New(cfg *config.Config, logger *zerolog.Logger, loggerService *loggerConfig.LoggerService) (*Database, error) {
+	pgxPoolConfig, err := NewPoolConfig(cfg.Database, logger)
+	if err != nil {
+		return nil, err
+	}
+
 	// Add New Relic PostgreSQL instrumentation
 	if loggerService != nil && loggerService.GetApplication() != nil {
 		pgxPoolConfig.ConnConfig.Tracer = nrpgx5.NewTracer()
@@ -114,6 +140,10 @@ func New(cfg *config.Config, logger *zerolog.Logger, loggerService *loggerConfig
 	}
 
 	logger.Info().Msg("connected to the database")
+	logger.Debug().
+		Dur("health_check_period", pgxPoolConfig.HealthCheckPeriod).
+		Dur("max_conn_idle_time", pgxPoolConfig.MaxConnIdleTime).
+		Msg("database pool reaper configured")
 
 	return database, nil
 }
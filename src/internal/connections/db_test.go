@@ -0,0 +1,52 @@
+package connections_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/connections"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPoolConfig(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("applies the configured reaper periods", func(t *testing.T) {
+		cfg := databaseConfig()
+		cfg.HealthCheckPeriod = 15 * time.Second
+		cfg.ConnMaxIdleTime = 300
+
+		poolConfig, err := connections.NewPoolConfig(cfg, &logger)
+		require.NoError(t, err)
+
+		assert.Equal(t, 15*time.Second, poolConfig.HealthCheckPeriod)
+		assert.Equal(t, 5*time.Minute, poolConfig.MaxConnIdleTime)
+		assert.NotNil(t, poolConfig.BeforeClose, "reaped connections are logged")
+		assert.Equal(t, "db.internal", poolConfig.ConnConfig.Host)
+	})
+
+	t.Run("defaults the health check period", func(t *testing.T) {
+		cfg := databaseConfig()
+		cfg.ConnMaxIdleTime = 60
+
+		poolConfig, err := connections.NewPoolConfig(cfg, &logger)
+		require.NoError(t, err)
+
+		assert.Equal(t, connections.DefaultHealthCheckPeriod, poolConfig.HealthCheckPeriod)
+		assert.Equal(t, time.Minute, poolConfig.MaxConnIdleTime)
+	})
+
+	t.Run("rejects non-positive periods", func(t *testing.T) {
+		cfg := databaseConfig()
+		cfg.ConnMaxIdleTime = 60
+		cfg.HealthCheckPeriod = -time.Second
+		_, err := connections.NewPoolConfig(cfg, &logger)
+		assert.ErrorContains(t, err, "health_check_period")
+
+		cfg = databaseConfig()
+		_, err = connections.NewPoolConfig(cfg, &logger)
+		assert.ErrorContains(t, err, "conn_max_idle_time")
+	})
+}
@@ -0,0 +1,88 @@
+package connections
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+)
+
+// DefaultApplicationName identifies the service's sessions in pg_stat_activity
+const DefaultApplicationName = "Fortress_API"
+
+// Postgres truncates application_name to NAMEDATALEN-1 bytes
+const maxApplicationNameLength = 63
+
+var searchPathSchemaPattern = regexp.MustCompile(`^(\$user|[A-Za-z_][A-Za-z0-9_$]*)$`)
+
+// BuildDSN builds the connection URL for the configured database, carrying
+// application_name, statement_timeout and search_path as runtime parameters.
+// Credentials and parameters are escaped; invalid parameter values are rejected.
+func BuildDSN(cfg config.DatabaseConfig) (string, error) {
+	params := url.Values{}
+	params.Set("sslmode", cfg.SSLMode)
+
+	applicationName := cfg.ApplicationName
+	if applicationName == "" {
+		applicationName = DefaultApplicationName
+	}
+	if err := validateApplicationName(applicationName); err != nil {
+		return "", err
+	}
+	params.Set("application_name", applicationName)
+
+	if cfg.StatementTimeout < 0 {
+		return "", fmt.Errorf("invalid statement_timeout %d: must not be negative", cfg.StatementTimeout)
+	}
+	if cfg.StatementTimeout > 0 {
+		// Postgres reads a unitless statement_timeout as milliseconds
+		params.Set("statement_timeout", strconv.Itoa(cfg.StatementTimeout))
+	}
+
+	if cfg.SearchPath != "" {
+		searchPath, err := normalizeSearchPath(cfg.SearchPath)
+		if err != nil {
+			return "", err
+		}
+		params.Set("search_path", searchPath)
+	}
+
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(cfg.User, cfg.Password),
+		Host:     net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		Path:     "/" + cfg.Name,
+		RawQuery: params.Encode(),
+	}
+
+	return dsn.String(), nil
+}
+
+func validateApplicationName(name string) error {
+	if len(name) > maxApplicationNameLength {
+		return fmt.Errorf("invalid application_name: longer than %d characters", maxApplicationNameLength)
+	}
+	for _, r := range name {
+		if r < 0x20 || r > 0x7e {
+			return fmt.Errorf("invalid application_name %q: only printable ASCII is allowed", name)
+		}
+	}
+	return nil
+}
+
+// normalizeSearchPath accepts a comma-separated list of plain schema names
+func normalizeSearchPath(searchPath string) (string, error) {
+	schemas := strings.Split(searchPath, ",")
+	for i, schema := range schemas {
+		schema = strings.TrimSpace(schema)
+		if !searchPathSchemaPattern.MatchString(schema) {
+			return "", fmt.Errorf("invalid search_path schema %q", schema)
+		}
+		schemas[i] = schema
+	}
+	return strings.Join(schemas, ","), nil
+}
@@ -0,0 +1,75 @@
+package connections_test
+
+import (
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/connections"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func databaseConfig() config.DatabaseConfig {
+	return config.DatabaseConfig{
+		Host:     "db.internal",
+		Port:     5432,
+		User:     "app",
+		Password: "p@ss word/&?",
+		Name:     "fortress",
+		SSLMode:  "require",
+	}
+}
+
+func TestBuildDSN(t *testing.T) {
+	t.Run("carries runtime parameters", func(t *testing.T) {
+		cfg := databaseConfig()
+		cfg.ApplicationName = "fortress-api worker"
+		cfg.StatementTimeout = 5000
+		cfg.SearchPath = "app, public"
+
+		dsn, err := connections.BuildDSN(cfg)
+		require.NoError(t, err)
+
+		poolConfig, err := pgxpool.ParseConfig(dsn)
+		require.NoError(t, err)
+
+		conn := poolConfig.ConnConfig
+		assert.Equal(t, "db.internal", conn.Host)
+		assert.Equal(t, "app", conn.User)
+		assert.Equal(t, "p@ss word/&?", conn.Password)
+		assert.Equal(t, "fortress", conn.Database)
+		assert.Equal(t, "fortress-api worker", conn.RuntimeParams["application_name"])
+		assert.Equal(t, "5000", conn.RuntimeParams["statement_timeout"])
+		assert.Equal(t, "app,public", conn.RuntimeParams["search_path"])
+	})
+
+	t.Run("defaults application_name and omits unset parameters", func(t *testing.T) {
+		dsn, err := connections.BuildDSN(databaseConfig())
+		require.NoError(t, err)
+
+		poolConfig, err := pgxpool.ParseConfig(dsn)
+		require.NoError(t, err)
+
+		params := poolConfig.ConnConfig.RuntimeParams
+		assert.Equal(t, connections.DefaultApplicationName, params["application_name"])
+		assert.NotContains(t, params, "statement_timeout")
+		assert.NotContains(t, params, "search_path")
+	})
+
+	t.Run("rejects invalid values", func(t *testing.T) {
+		for name, mutate := range map[string]func(*config.DatabaseConfig){
+			"negative statement_timeout": func(c *config.DatabaseConfig) { c.StatementTimeout = -1 },
+			"injected search_path":       func(c *config.DatabaseConfig) { c.SearchPath = "public; DROP TABLE todos" },
+			"non-ascii application_name": func(c *config.DatabaseConfig) { c.ApplicationName = "fortress\n" },
+		} {
+			t.Run(name, func(t *testing.T) {
+				cfg := databaseConfig()
+				mutate(&cfg)
+
+				_, err := connections.BuildDSN(cfg)
+				assert.Error(t, err)
+			})
+		}
+	})
+}
@@ -4,6 +4,10 @@ import (
 	"strings"
 )
 
+// StatusClientClosedRequest is the non-standard 499 status (from nginx) recorded when the
+// client disconnects before the response is written
+const StatusClientClosedRequest = 499
+
 type FieldError struct {
 	Field string `json:"field"`
 	Error string `json:"error"`
@@ -30,6 +34,12 @@ type HTTPError struct {
 	Errors []FieldError `json:"errors"`
 	// action to be taken
 	Action *Action `json:"action"`
+	// RetryAfter is the number of seconds after which the client may retry, sent as the
+	// Retry-After header as well
+	RetryAfter int `json:"retryAfter,omitempty"`
+	// Debug carries the underlying error of a 5xx where error details are exposed,
+	// i.e. in development
+	Debug string `json:"debug,omitempty"`
 }
 
 func (e *HTTPError) Error() string {
@@ -44,12 +54,14 @@ func (e *HTTPError) Is(target error) bool {
 
 func (e *HTTPError) WithMessage(message string) *HTTPError {
 	return &HTTPError{
-		Code:     e.Code,
-		Message:  message,
-		Status:   e.Status,
-		Override: e.Override,
-		Errors:   e.Errors,
-		Action:   e.Action,
+		Code:       e.Code,
+		Message:    message,
+		Status:     e.Status,
+		Override:   e.Override,
+		Errors:     e.Errors,
+		Action:     e.Action,
+		RetryAfter: e.RetryAfter,
+		Debug:      e.Debug,
 	}
 }
 
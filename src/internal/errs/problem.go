@@ -0,0 +1,48 @@
+package errs
+
+import (
+	"net/http"
+)
+
+// MIMEApplicationProblemJSON is the media type of RFC 7807 problem details
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// ProblemDetails is the RFC 7807 representation of an HTTPError. The application
+// specific code, field errors and action are carried as extension members.
+type ProblemDetails struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Code     string       `json:"code"`
+	Errors   []FieldError `json:"errors,omitempty"`
+	Action   *Action      `json:"action,omitempty"`
+	// RetryAfter mirrors the Retry-After header, in seconds
+	RetryAfter int `json:"retryAfter,omitempty"`
+	// Debug is the underlying error where error details are exposed
+	Debug string `json:"debug,omitempty"`
+}
+
+// Problem converts the error to problem details for the request path given as instance
+func (e *HTTPError) Problem(instance string) ProblemDetails {
+	// Non-standard statuses such as 499 have no registered text
+	title := http.StatusText(e.Status)
+	if title == "" {
+		title = e.Message
+	}
+
+	return ProblemDetails{
+		// No problem type documentation is published, so the status alone describes it
+		Type:       "about:blank",
+		Title:      title,
+		Status:     e.Status,
+		Detail:     e.Message,
+		Instance:   instance,
+		Code:       e.Code,
+		Errors:     e.Errors,
+		Action:     e.Action,
+		RetryAfter: e.RetryAfter,
+		Debug:      e.Debug,
+	}
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/backup"
+	"github.com/Harmeet10000/Fortress_API/src/internal/service"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
+	"github.com/labstack/echo/v4"
+)
+
+type BackupHandler struct {
+	Handler
+	backupService *service.BackupService
+}
+
+func NewBackupHandler(s *app.Server, v *validation.Validator, backupService *service.BackupService) *BackupHandler {
+	return &BackupHandler{
+		Handler:       NewHandler(s, v),
+		backupService: backupService,
+	}
+}
+
+func (h *BackupHandler) TriggerBackup(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *backup.TriggerBackupPayload) (*backup.BackupResponse, error) {
+			return h.backupService.TriggerBackup(c)
+		},
+		http.StatusCreated,
+		&backup.TriggerBackupPayload{},
+	)(c)
+}
@@ -0,0 +1,162 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/Harmeet10000/Fortress_API/src/internal/handler"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/backup"
+	"github.com/Harmeet10000/Fortress_API/src/internal/service"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
+	"github.com/Harmeet10000/Fortress_API/tests/redisfake"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingDumper writes a fixed dump. When started is set, the first dump signals it
+// and waits for release, holding that backup in progress
+type blockingDumper struct {
+	started chan struct{}
+	release chan struct{}
+	once    sync.Once
+	// err, when set, fails the dump after part of it was written
+	err error
+}
+
+func (d *blockingDumper) Dump(ctx context.Context, w io.Writer) error {
+	if d.started != nil {
+		d.once.Do(func() {
+			close(d.started)
+			<-d.release
+		})
+	}
+	if _, err := io.WriteString(w, "-- PostgreSQL database dump\n"); err != nil {
+		return err
+	}
+	return d.err
+}
+
+type recordingUploader struct {
+	mu      sync.Mutex
+	uploads map[string][]byte
+}
+
+func (u *recordingUploader) UploadFile(ctx context.Context, bucket string, fileName string, file io.Reader) (string, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return "", err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	key := bucket + "/" + fileName
+	u.uploads[key] = data
+	return key, nil
+}
+
+func newBackupHandler(t *testing.T, dumper *blockingDumper) (*handler.BackupHandler, *recordingUploader) {
+	t.Helper()
+
+	client, _ := redisfake.NewClient()
+	logger := zerolog.Nop()
+	s := &app.Server{
+		Logger: &logger,
+		Redis:  client,
+		Config: &config.Config{
+			S3: config.S3Config{BackupEnabled: true, Bucket: "fortress", Prefix: "prod"},
+		},
+	}
+
+	uploader := &recordingUploader{uploads: make(map[string][]byte)}
+	backupService := service.NewBackupService(s, dumper, uploader)
+	return handler.NewBackupHandler(s, validation.NewValidator(), backupService), uploader
+}
+
+func triggerBackup(h *handler.BackupHandler) (*httptest.ResponseRecorder, error) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/admin/backup", nil)
+	rec := httptest.NewRecorder()
+
+	return rec, h.TriggerBackup(e.NewContext(req, rec))
+}
+
+func TestBackupHandler_TriggerBackup(t *testing.T) {
+	t.Run("uploads the dump and returns its key and size", func(t *testing.T) {
+		h, uploader := newBackupHandler(t, &blockingDumper{})
+
+		rec, err := triggerBackup(h)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+
+		var res backup.BackupResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		require.Contains(t, uploader.uploads, res.Key)
+		assert.Regexp(t, `^fortress/prod/backups/db_\d{8}T\d{6}Z\.sql$`, res.Key)
+		assert.Equal(t, "-- PostgreSQL database dump\n", string(uploader.uploads[res.Key]))
+		assert.Equal(t, int64(len(uploader.uploads[res.Key])), res.Size)
+	})
+
+	t.Run("a failed dump is not uploaded and releases the lock", func(t *testing.T) {
+		dumper := &blockingDumper{err: errors.New("pg_dump: connection refused")}
+		h, uploader := newBackupHandler(t, dumper)
+
+		_, err := triggerBackup(h)
+		require.ErrorContains(t, err, "failed to dump database")
+		assert.Empty(t, uploader.uploads)
+
+		dumper.err = nil
+		rec, err := triggerBackup(h)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("a concurrent trigger is rejected while a backup runs", func(t *testing.T) {
+		dumper := &blockingDumper{started: make(chan struct{}), release: make(chan struct{})}
+		h, uploader := newBackupHandler(t, dumper)
+
+		type result struct {
+			rec *httptest.ResponseRecorder
+			err error
+		}
+		first := make(chan result, 1)
+		go func() {
+			rec, err := triggerBackup(h)
+			first <- result{rec, err}
+		}()
+
+		select {
+		case <-dumper.started:
+		case <-time.After(time.Second):
+			t.Fatal("the first backup never started")
+		}
+
+		_, err := triggerBackup(h)
+		var httpErr *errs.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusConflict, httpErr.Status)
+		assert.Equal(t, "BACKUP_IN_PROGRESS", httpErr.Code)
+
+		close(dumper.release)
+		res := <-first
+		require.NoError(t, res.err)
+		assert.Equal(t, http.StatusCreated, res.rec.Code)
+		assert.Len(t, uploader.uploads, 1)
+
+		// The lock is released once the backup finishes
+		rec, err := triggerBackup(h)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+}
@@ -1,24 +1,26 @@
 package handler
 
 import (
+	"net/http"
 	"time"
 
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
 	"github.com/labstack/echo/v4"
 	"github.com/newrelic/go-agent/v3/integrations/nrpkgerrors"
 	"github.com/newrelic/go-agent/v3/newrelic"
-	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
-	"github.com/Harmeet10000/Fortress_API/src/internal/app"
-	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
 )
 
 // Handler provides base functionality for all handlers
 type Handler struct {
-	server *app.Server
+	server    *app.Server
+	validator *validation.Validator
 }
 
-// NewHandler creates a new base handler
-func NewHandler(s *app.Server) Handler {
-	return Handler{server: s}
+// NewHandler creates a new base handler that validates requests with v
+func NewHandler(s *app.Server, v *validation.Validator) Handler {
+	return Handler{server: s, validator: v}
 }
 
 // HandlerFunc represents a typed handler function that processes a request and returns a response
@@ -68,6 +70,62 @@ func (h NoContentResponseHandler) AddAttributes(txn *newrelic.Transaction, resul
 	// http.status_code is already set by tracing middleware
 }
 
+// ConditionalResponseHandler handles JSON responses for single-resource reads,
+// answering 304 Not Modified when the client's If-Modified-Since is still current
+type ConditionalResponseHandler struct {
+	status       int
+	lastModified func(result interface{}) time.Time
+}
+
+func (h ConditionalResponseHandler) Handle(c echo.Context, result interface{}) error {
+	modifiedAt := h.lastModified(result)
+	if modifiedAt.IsZero() {
+		return c.JSON(h.status, result)
+	}
+
+	// HTTP dates only carry second precision, so compare on the truncated value
+	modifiedAt = modifiedAt.UTC().Truncate(time.Second)
+	c.Response().Header().Set(echo.HeaderLastModified, modifiedAt.Format(http.TimeFormat))
+
+	if isNotModified(c.Request(), modifiedAt) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return c.JSON(h.status, result)
+}
+
+func (h ConditionalResponseHandler) GetOperation() string {
+	return "handler_conditional"
+}
+
+func (h ConditionalResponseHandler) AddAttributes(txn *newrelic.Transaction, result interface{}) {
+	// http.status_code is already set by tracing middleware
+}
+
+// isNotModified evaluates If-Modified-Since against the resource's last modification time.
+// If-None-Match takes precedence when present, so the date is ignored in that case.
+func isNotModified(r *http.Request, modifiedAt time.Time) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	if r.Header.Get("If-None-Match") != "" {
+		return false
+	}
+
+	since := r.Header.Get("If-Modified-Since")
+	if since == "" {
+		return false
+	}
+
+	sinceTime, err := http.ParseTime(since)
+	if err != nil {
+		return false
+	}
+
+	return !modifiedAt.After(sinceTime)
+}
+
 // FileResponseHandler handles file responses
 type FileResponseHandler struct {
 	status      int
@@ -96,8 +154,24 @@ func (h FileResponseHandler) AddAttributes(txn *newrelic.Transaction, result int
 	}
 }
 
+// StreamResponseHandler is used by handlers that write the response body themselves
+type StreamResponseHandler struct{}
+
+func (h StreamResponseHandler) Handle(c echo.Context, result interface{}) error {
+	return nil
+}
+
+func (h StreamResponseHandler) GetOperation() string {
+	return "handler_stream"
+}
+
+func (h StreamResponseHandler) AddAttributes(txn *newrelic.Transaction, result interface{}) {
+	// http.status_code is already set by tracing middleware
+}
+
 // handleRequest is the unified handler function that eliminates code duplication
 func handleRequest[Req validation.Validatable](
+	h Handler,
 	c echo.Context,
 	req Req,
 	handler func(c echo.Context, req Req) (interface{}, error),
@@ -138,7 +212,7 @@ func handleRequest[Req validation.Validatable](
 
 	// Validation with observability
 	validationStart := time.Now()
-	if err := validation.BindAndValidate(c, req); err != nil {
+	if err := h.validator.BindAndValidate(c, req); err != nil {
 		validationDuration := time.Since(validationStart)
 
 		logger.Error().
@@ -214,12 +288,36 @@ func Handle[Req validation.Validatable, Res any](
 	req Req,
 ) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		return handleRequest(c, req, func(c echo.Context, req Req) (interface{}, error) {
+		return handleRequest(h, c, req, func(c echo.Context, req Req) (interface{}, error) {
 			return handler(c, req)
 		}, JSONResponseHandler{status: status})
 	}
 }
 
+// HandleConditional wraps a single-resource read with Last-Modified/If-Modified-Since support
+func HandleConditional[Req validation.Validatable, Res any](
+	h Handler,
+	handler HandlerFunc[Req, Res],
+	status int,
+	req Req,
+	lastModified func(Res) time.Time,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return handleRequest(h, c, req, func(c echo.Context, req Req) (interface{}, error) {
+			return handler(c, req)
+		}, ConditionalResponseHandler{
+			status: status,
+			lastModified: func(result interface{}) time.Time {
+				res, ok := result.(Res)
+				if !ok {
+					return time.Time{}
+				}
+				return lastModified(res)
+			},
+		})
+	}
+}
+
 func HandleFile[Req validation.Validatable](
 	h Handler,
 	handler HandlerFunc[Req, []byte],
@@ -229,7 +327,7 @@ func HandleFile[Req validation.Validatable](
 	contentType string,
 ) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		return handleRequest(c, req, func(c echo.Context, req Req) (interface{}, error) {
+		return handleRequest(h, c, req, func(c echo.Context, req Req) (interface{}, error) {
 			return handler(c, req)
 		}, FileResponseHandler{
 			status:      status,
@@ -247,9 +345,24 @@ func HandleNoContent[Req validation.Validatable](
 	req Req,
 ) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		return handleRequest(c, req, func(c echo.Context, req Req) (interface{}, error) {
+		return handleRequest(h, c, req, func(c echo.Context, req Req) (interface{}, error) {
 			err := handler(c, req)
 			return nil, err
 		}, NoContentResponseHandler{status: status})
 	}
 }
+
+// HandleStream wraps a handler that streams its own response. An error returned after
+// the body has started is still logged and traced, but can no longer change the response.
+func HandleStream[Req validation.Validatable](
+	h Handler,
+	handler HandlerFuncNoContent[Req],
+	req Req,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return handleRequest(h, c, req, func(c echo.Context, req Req) (interface{}, error) {
+			err := handler(c, req)
+			return nil, err
+		}, StreamResponseHandler{})
+	}
+}
@@ -0,0 +1,85 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/handler"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type conditionalPayload struct{}
+
+func (p *conditionalPayload) Validate() error {
+	return nil
+}
+
+type conditionalResource struct {
+	Name      string    `json:"name"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func serveConditional(t *testing.T, updatedAt time.Time, ifModifiedSince string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/resources/1", nil)
+	if ifModifiedSince != "" {
+		req.Header.Set("If-Modified-Since", ifModifiedSince)
+	}
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	h := handler.HandleConditional(
+		handler.NewHandler(nil, validation.NewValidator()),
+		func(c echo.Context, payload *conditionalPayload) (*conditionalResource, error) {
+			return &conditionalResource{Name: "resource", UpdatedAt: updatedAt}, nil
+		},
+		http.StatusOK,
+		&conditionalPayload{},
+		func(r *conditionalResource) time.Time {
+			return r.UpdatedAt
+		},
+	)
+
+	require.NoError(t, h(c))
+	return rec
+}
+
+func TestHandleConditional(t *testing.T) {
+	// Sub-second precision must not defeat the comparison against an HTTP date
+	updatedAt := time.Date(2024, time.March, 10, 12, 30, 45, 987654321, time.UTC)
+
+	t.Run("sets Last-Modified without a conditional header", func(t *testing.T) {
+		rec := serveConditional(t, updatedAt, "")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "Sun, 10 Mar 2024 12:30:45 GMT", rec.Header().Get(echo.HeaderLastModified))
+		assert.Contains(t, rec.Body.String(), `"name":"resource"`)
+	})
+
+	t.Run("matching If-Modified-Since returns 304", func(t *testing.T) {
+		rec := serveConditional(t, updatedAt, updatedAt.Format(http.TimeFormat))
+
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("older If-Modified-Since returns the resource", func(t *testing.T) {
+		rec := serveConditional(t, updatedAt, updatedAt.Add(-time.Minute).Format(http.TimeFormat))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"name":"resource"`)
+	})
+
+	t.Run("invalid If-Modified-Since is ignored", func(t *testing.T) {
+		rec := serveConditional(t, updatedAt, "not-a-date")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/cache"
+	"github.com/Harmeet10000/Fortress_API/src/internal/service"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
+	"github.com/labstack/echo/v4"
+)
+
+type CacheHandler struct {
+	Handler
+	cacheService *service.CacheService
+}
+
+func NewCacheHandler(s *app.Server, v *validation.Validator, cacheService *service.CacheService) *CacheHandler {
+	return &CacheHandler{
+		Handler:      NewHandler(s, v),
+		cacheService: cacheService,
+	}
+}
+
+func (h *CacheHandler) PurgeCache(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *cache.PurgeCachePayload) (*cache.PurgeCacheResponse, error) {
+			return h.cacheService.PurgeCache(c, payload.Prefix)
+		},
+		http.StatusOK,
+		&cache.PurgeCachePayload{},
+	)(c)
+}
@@ -0,0 +1,88 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/handler"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/cache"
+	"github.com/Harmeet10000/Fortress_API/src/internal/service"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
+	"github.com/Harmeet10000/Fortress_API/tests/redisfake"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCacheHandler(t *testing.T) (*handler.CacheHandler, *redisfake.Fake) {
+	t.Helper()
+
+	client, fake := redisfake.NewClient()
+	s := &app.Server{Redis: client}
+
+	// Seed two users' cached responses plus a non-cache key that must survive every purge
+	for _, key := range []string{
+		"http_cache:user_1:GET:/api/v1/categories?",
+		"http_cache:user_1:GET:/api/v1/todos?page=2",
+		"http_cache:user_2:GET:/api/v1/categories?",
+		"ratelimit:203.0.113.7",
+	} {
+		require.NoError(t, client.Set(context.Background(), key, "{}", 0).Err())
+	}
+
+	return handler.NewCacheHandler(s, validation.NewValidator(), service.NewCacheService(s)), fake
+}
+
+func purgeCache(t *testing.T, h *handler.CacheHandler, body string) (*httptest.ResponseRecorder, error) {
+	t.Helper()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/admin/cache/purge", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	return rec, h.PurgeCache(e.NewContext(req, rec))
+}
+
+func TestCacheHandler_PurgeCache(t *testing.T) {
+	t.Run("purges keys under the prefix", func(t *testing.T) {
+		h, fake := newCacheHandler(t)
+
+		rec, err := purgeCache(t, h, `{"prefix":"http_cache:user_1:"}`)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var res cache.PurgeCacheResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, int64(2), res.Purged)
+		assert.ElementsMatch(t, []string{
+			"http_cache:user_2:GET:/api/v1/categories?",
+			"ratelimit:203.0.113.7",
+		}, fake.Keys())
+	})
+
+	t.Run("purges the whole cache namespace without a prefix", func(t *testing.T) {
+		h, fake := newCacheHandler(t)
+
+		rec, err := purgeCache(t, h, `{}`)
+		require.NoError(t, err)
+
+		var res cache.PurgeCacheResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, int64(3), res.Purged)
+		assert.Equal(t, []string{"ratelimit:203.0.113.7"}, fake.Keys())
+	})
+
+	t.Run("rejects prefixes outside the cache namespace", func(t *testing.T) {
+		h, fake := newCacheHandler(t)
+
+		_, err := purgeCache(t, h, `{"prefix":"ratelimit:"}`)
+		require.Error(t, err)
+		assert.Len(t, fake.Keys(), 4)
+	})
+}
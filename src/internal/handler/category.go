@@ -2,13 +2,14 @@ package handler
 
 import (
 	"net/http"
+	"time"
 
-	"github.com/labstack/echo/v4"
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
 	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
-	"github.com/Harmeet10000/Fortress_API/src/internal/model"
 	"github.com/Harmeet10000/Fortress_API/src/internal/model/category"
-	"github.com/Harmeet10000/Fortress_API/src/internal/app"
 	"github.com/Harmeet10000/Fortress_API/src/internal/service"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
+	"github.com/labstack/echo/v4"
 )
 
 type CategoryHandler struct {
@@ -16,9 +17,9 @@ type CategoryHandler struct {
 	categoryService *service.CategoryService
 }
 
-func NewCategoryHandler(s *app.Server, categoryService *service.CategoryService) *CategoryHandler {
+func NewCategoryHandler(s *app.Server, v *validation.Validator, categoryService *service.CategoryService) *CategoryHandler {
 	return &CategoryHandler{
-		Handler:         NewHandler(s),
+		Handler:         NewHandler(s, v),
 		categoryService: categoryService,
 	}
 }
@@ -35,13 +36,30 @@ func (h *CategoryHandler) CreateCategory(c echo.Context) error {
 	)(c)
 }
 
+func (h *CategoryHandler) GetCategoryByID(c echo.Context) error {
+	return HandleConditional(
+		h.Handler,
+		func(c echo.Context, payload *category.GetCategoryByIDPayload) (*category.Category, error) {
+			userID := middleware.GetUserID(c)
+			return h.categoryService.GetCategoryByID(c, userID, payload.ID)
+		},
+		http.StatusOK,
+		&category.GetCategoryByIDPayload{},
+		func(cat *category.Category) time.Time {
+			return cat.UpdatedAt
+		},
+	)(c)
+}
+
 func (h *CategoryHandler) GetCategories(c echo.Context) error {
 	return Handle(
 		h.Handler,
-		func(c echo.Context, query *category.GetCategoriesQuery) (
-			*model.PaginatedResponse[category.Category], error,
-		) {
+		// The two variants differ in element type, hence the untyped response
+		func(c echo.Context, query *category.GetCategoriesQuery) (any, error) {
 			userID := middleware.GetUserID(c)
+			if query.WithCounts != nil && *query.WithCounts {
+				return h.categoryService.GetCategoriesWithTodoCounts(c, userID, query)
+			}
 			return h.categoryService.GetCategories(c, userID, query)
 		},
 		http.StatusOK,
@@ -0,0 +1,72 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/model"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/category"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/todo"
+	"github.com/Harmeet10000/Fortress_API/src/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategoryHandler_GetCategories_CountsAfterTodoMutations(t *testing.T) {
+	_, testServer, cleanup := testutil.SetupTest(t)
+	defer cleanup()
+
+	srv := testutil.NewTestServer(t, testutil.Options{DB: testServer.DB})
+	token := srv.Token(t, "user_counts", "")
+
+	do := func(method, path string, body any, out any) *http.Response {
+		t.Helper()
+
+		var payload bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&payload).Encode(body))
+		}
+		req, err := http.NewRequest(method, srv.URL+path, &payload)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := srv.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		if out != nil {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+		}
+		return resp
+	}
+
+	todoCount := func() int {
+		t.Helper()
+
+		var page model.PaginatedResponse[category.CategoryWithTodoCount]
+		resp := do(http.MethodGet, "/api/v1/categories?with_counts=true", nil, &page)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Len(t, page.Data, 1)
+		return page.Data[0].TodoCount
+	}
+
+	var work category.Category
+	resp := do(http.MethodPost, "/api/v1/categories", category.CreateCategoryPayload{Name: "Work", Color: "#336699"}, &work)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// The first listing is cached, so every later count relies on the todo routes
+	// invalidating it
+	assert.Equal(t, 0, todoCount())
+
+	var created todo.Todo
+	resp = do(http.MethodPost, "/api/v1/todos", todo.CreateTodoPayload{Title: "Report", CategoryID: &work.ID}, &created)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, 1, todoCount())
+
+	resp = do(http.MethodDelete, "/api/v1/todos/"+created.ID.String(), nil, nil)
+	require.Less(t, resp.StatusCode, http.StatusBadRequest)
+	assert.Equal(t, 0, todoCount())
+}
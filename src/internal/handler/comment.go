@@ -1,13 +1,16 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
 
-	"github.com/labstack/echo/v4"
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/helper/markdown"
 	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
 	"github.com/Harmeet10000/Fortress_API/src/internal/model/comment"
-	"github.com/Harmeet10000/Fortress_API/src/internal/app"
 	"github.com/Harmeet10000/Fortress_API/src/internal/service"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
+	"github.com/labstack/echo/v4"
 )
 
 type CommentHandler struct {
@@ -15,9 +18,9 @@ type CommentHandler struct {
 	commentService *service.CommentService
 }
 
-func NewCommentHandler(s *app.Server, commentService *service.CommentService) *CommentHandler {
+func NewCommentHandler(s *app.Server, v *validation.Validator, commentService *service.CommentService) *CommentHandler {
 	return &CommentHandler{
-		Handler:        NewHandler(s),
+		Handler:        NewHandler(s, v),
 		commentService: commentService,
 	}
 }
@@ -39,7 +42,18 @@ func (h *CommentHandler) GetCommentsByTodoID(c echo.Context) error {
 		h.Handler,
 		func(c echo.Context, payload *comment.GetCommentsByTodoIDPayload) ([]comment.Comment, error) {
 			userID := middleware.GetUserID(c)
-			return h.commentService.GetCommentsByTodoID(c, userID, payload.TodoID)
+			comments, err := h.commentService.GetCommentsByTodoID(c, userID, payload.TodoID)
+			if err != nil {
+				return nil, err
+			}
+
+			if payload.Render != nil && *payload.Render == "html" {
+				if err := renderCommentsHTML(comments); err != nil {
+					return nil, err
+				}
+			}
+
+			return comments, nil
 		},
 		http.StatusOK,
 		&comment.GetCommentsByTodoIDPayload{},
@@ -51,7 +65,7 @@ func (h *CommentHandler) UpdateComment(c echo.Context) error {
 		h.Handler,
 		func(c echo.Context, payload *comment.UpdateCommentPayload) (*comment.Comment, error) {
 			userID := middleware.GetUserID(c)
-			return h.commentService.UpdateComment(c, userID, payload.ID, payload.Content)
+			return h.commentService.UpdateComment(c, userID, payload.ID, payload.Content, payload.ExpectedUpdatedAt)
 		},
 		http.StatusOK,
 		&comment.UpdateCommentPayload{},
@@ -69,3 +83,15 @@ func (h *CommentHandler) DeleteComment(c echo.Context) error {
 		&comment.DeleteCommentPayload{},
 	)(c)
 }
+
+// renderCommentsHTML fills ContentHTML with the sanitized markdown rendering of each comment
+func renderCommentsHTML(comments []comment.Comment) error {
+	for i := range comments {
+		html, err := markdown.ToSafeHTML(comments[i].Content)
+		if err != nil {
+			return fmt.Errorf("failed to render comment_id=%s: %w", comments[i].ID.String(), err)
+		}
+		comments[i].ContentHTML = &html
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/system"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
+	"github.com/labstack/echo/v4"
+)
+
+type FeaturesHandler struct {
+	Handler
+}
+
+func NewFeaturesHandler(s *app.Server, v *validation.Validator) *FeaturesHandler {
+	return &FeaturesHandler{
+		Handler: NewHandler(s, v),
+	}
+}
+
+// GetFeatures reports which optional subsystems this deployment has enabled
+func (h *FeaturesHandler) GetFeatures(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *system.GetFeaturesPayload) (config.FeatureSet, error) {
+			return h.server.Config.Features(), nil
+		},
+		http.StatusOK,
+		&system.GetFeaturesPayload{},
+	)(c)
+}
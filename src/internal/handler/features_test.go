@@ -0,0 +1,52 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/handler"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getFeatures(t *testing.T, cfg *config.Config) config.FeatureSet {
+	t.Helper()
+
+	h := handler.NewFeaturesHandler(&app.Server{Config: cfg}, validation.NewValidator())
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/features", nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, h.GetFeatures(e.NewContext(req, rec)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var features config.FeatureSet
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &features))
+	return features
+}
+
+func TestFeaturesHandler_GetFeatures(t *testing.T) {
+	cfg := &config.Config{
+		Email:         config.EmailConfig{ResendKey: "re_test"},
+		Redis:         config.RedisConfig{Address: "localhost:6379"},
+		Observability: config.DefaultObservabilityConfig(),
+	}
+
+	assert.Equal(t, config.FeatureSet{Attachments: true, Email: true, Cache: true}, getFeatures(t, cfg))
+
+	cfg.S3.BackupEnabled = true
+	cfg.Observability.NewRelic.LicenseKey = "nr-license"
+
+	assert.Equal(t, config.FeatureSet{Backup: true, Attachments: true, Observability: true, Email: true, Cache: true}, getFeatures(t, cfg))
+
+	attachments := false
+	cfg.S3.AttachmentsEnabled = &attachments
+
+	assert.False(t, getFeatures(t, cfg).Attachments)
+}
@@ -3,22 +3,39 @@ package handler
 import (
 	"github.com/Harmeet10000/Fortress_API/src/internal/app"
 	"github.com/Harmeet10000/Fortress_API/src/internal/service"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
 )
 
 type Handlers struct {
-	Health   *HealthHandler
-	OpenAPI  *OpenAPIHandler
-	Todo     *TodoHandler
-	Comment  *CommentHandler
-	Category *CategoryHandler
+	Health    *HealthHandler
+	OpenAPI   *OpenAPIHandler
+	Todo      *TodoHandler
+	Comment   *CommentHandler
+	Category  *CategoryHandler
+	RateLimit *RateLimitHandler
+	Cache     *CacheHandler
+	Backup    *BackupHandler
+	Features  *FeaturesHandler
+	Time      *TimeHandler
+
+	// Validator is shared by every handler and installed as the Echo validator
+	Validator *validation.Validator
 }
 
 func NewHandlers(s *app.Server, services *service.Services) *Handlers {
+	v := validation.NewValidator()
+
 	return &Handlers{
-		Health:   NewHealthHandler(s),
-		OpenAPI:  NewOpenAPIHandler(s),
-		Todo:     NewTodoHandler(s, services.Todo),
-		Category: NewCategoryHandler(s, services.Category),
-		Comment:  NewCommentHandler(s, services.Comment),
+		Health:    NewHealthHandler(s, v),
+		OpenAPI:   NewOpenAPIHandler(s, v),
+		Todo:      NewTodoHandler(s, v, services.Todo),
+		Category:  NewCategoryHandler(s, v, services.Category),
+		Comment:   NewCommentHandler(s, v, services.Comment),
+		RateLimit: NewRateLimitHandler(s, v, services.RateLimit),
+		Cache:     NewCacheHandler(s, v, services.Cache),
+		Backup:    NewBackupHandler(s, v, services.Backup),
+		Features:  NewFeaturesHandler(s, v),
+		Time:      NewTimeHandler(s, v),
+		Validator: v,
 	}
 }
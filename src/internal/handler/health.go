@@ -2,53 +2,93 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"os"
 	"time"
 
-	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
 	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
 
 	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
 )
 
 type HealthHandler struct {
 	Handler
 }
 
-func NewHealthHandler(s *app.Server) *HealthHandler {
+func NewHealthHandler(s *app.Server, v *validation.Validator) *HealthHandler {
 	return &HealthHandler{
-		Handler: NewHandler(s),
+		Handler: NewHandler(s, v),
 	}
 }
 
+// CheckHealth is the public probe: only the overall status and a timestamp, so nothing
+// about the environment or the dependencies reaches an unauthenticated caller. The
+// per-dependency report is served by CheckHealthDetailed.
 func (h *HealthHandler) CheckHealth(c echo.Context) error {
 	start := time.Now()
 	logger := middleware.GetLogger(c).With().
 		Str("operation", "health_check").
 		Logger()
 
+	_, isHealthy := h.runChecks(c)
+
+	response := map[string]interface{}{
+		"status":    "healthy",
+		"timestamp": time.Now().UTC(),
+	}
+
+	return h.respond(c, &logger, start, response, isHealthy)
+}
+
+// CheckHealthDetailed reports every dependency check and the environment. It exposes
+// error messages and infrastructure details, so it is only routed behind
+// RequireInternalOrRole.
+func (h *HealthHandler) CheckHealthDetailed(c echo.Context) error {
+	start := time.Now()
+	logger := middleware.GetLogger(c).With().
+		Str("operation", "health_check").
+		Logger()
+
+	checks, isHealthy := h.runChecks(c)
+
 	response := map[string]interface{}{
 		"status":      "healthy",
 		"timestamp":   time.Now().UTC(),
 		"environment": h.server.Config.Primary.Env,
-		"checks":      make(map[string]interface{}),
+		"checks":      checks,
 	}
 
-	checks := response["checks"].(map[string]interface{})
+	return h.respond(c, &logger, start, response, isHealthy)
+}
+
+// runChecks checks every dependency and reports whether the service is healthy
+func (h *HealthHandler) runChecks(c echo.Context) (map[string]interface{}, bool) {
+	logger := middleware.GetLogger(c).With().
+		Str("operation", "health_check").
+		Logger()
+
+	checks := make(map[string]interface{})
 	isHealthy := true
 
+	var healthChecks config.HealthChecksConfig
+	if observability := h.server.Config.Observability; observability != nil {
+		healthChecks = observability.HealthChecks
+	}
+
 	// Check database connectivity
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	dbTimeout := healthChecks.TimeoutFor(config.HealthCheckDatabase)
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
 	defer cancel()
 
 	dbStart := time.Now()
 	if err := h.server.DB.Pool.Ping(ctx); err != nil {
-		checks["database"] = map[string]interface{}{
-			"status":        "unhealthy",
-			"response_time": time.Since(dbStart).String(),
-			"error":         err.Error(),
-		}
+		checks["database"] = unhealthyCheck(err, time.Since(dbStart), dbTimeout)
 		isHealthy = false
 		logger.Error().Err(err).Dur("response_time", time.Since(dbStart)).Msg("database health check failed")
 		if h.server.LoggerService != nil && h.server.LoggerService.GetApplication() != nil {
@@ -73,16 +113,13 @@ func (h *HealthHandler) CheckHealth(c echo.Context) error {
 
 	// Check Redis connectivity
 	if h.server.Redis != nil {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		redisTimeout := healthChecks.TimeoutFor(config.HealthCheckRedis)
+		ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
 		defer cancel()
 
 		redisStart := time.Now()
 		if err := h.server.Redis.Ping(ctx).Err(); err != nil {
-			checks["redis"] = map[string]interface{}{
-				"status":        "unhealthy",
-				"response_time": time.Since(redisStart).String(),
-				"error":         err.Error(),
-			}
+			checks["redis"] = unhealthyCheck(err, time.Since(redisStart), redisTimeout)
 			logger.Error().Err(err).Dur("response_time", time.Since(redisStart)).Msg("redis health check failed")
 			if h.server.LoggerService != nil && h.server.LoggerService.GetApplication() != nil {
 				h.server.LoggerService.GetApplication().RecordCustomEvent(
@@ -103,6 +140,43 @@ func (h *HealthHandler) CheckHealth(c echo.Context) error {
 		}
 	}
 
+	// Check the volume the service writes to
+	diskPath := healthChecks.DiskPath
+	if diskPath == "" {
+		diskPath = "."
+	}
+	if err := checkDisk(diskPath); err != nil {
+		checks["disk"] = map[string]interface{}{
+			"status": "unhealthy",
+			"path":   diskPath,
+			"error":  err.Error(),
+		}
+		isHealthy = false
+		logger.Error().Err(err).Str("path", diskPath).Msg("disk health check failed")
+		if h.server.LoggerService != nil && h.server.LoggerService.GetApplication() != nil {
+			h.server.LoggerService.GetApplication().RecordCustomEvent(
+				"HealthCheckError", map[string]interface{}{
+					"check_type":    "disk",
+					"operation":     "health_check",
+					"error_type":    "disk_unhealthy",
+					"error_message": err.Error(),
+				})
+		}
+	} else {
+		checks["disk"] = map[string]interface{}{
+			"status": "healthy",
+			"path":   diskPath,
+		}
+		logger.Info().Str("path", diskPath).Msg("disk health check passed")
+	}
+
+	return checks, isHealthy
+}
+
+// respond writes response with 200, or with 503 and an "unhealthy" status
+func (h *HealthHandler) respond(c echo.Context, logger *zerolog.Logger, start time.Time,
+	response map[string]interface{}, isHealthy bool,
+) error {
 	// Set overall status
 	if !isHealthy {
 		response["status"] = "unhealthy"
@@ -142,3 +216,35 @@ func (h *HealthHandler) CheckHealth(c echo.Context) error {
 
 	return nil
 }
+
+// checkDisk checks that path is a directory that can be opened
+func checkDisk(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", path)
+	}
+
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	return dir.Close()
+}
+
+// unhealthyCheck describes a failed dependency check, naming the timeout as the reason
+// when the dependency did not answer within it
+func unhealthyCheck(err error, responseTime, timeout time.Duration) map[string]interface{} {
+	check := map[string]interface{}{
+		"status":        "unhealthy",
+		"response_time": responseTime.String(),
+		"error":         err.Error(),
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		check["reason"] = "timeout"
+		check["timeout"] = timeout.String()
+	}
+	return check
+}
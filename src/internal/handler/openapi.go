@@ -1,12 +1,16 @@
 package handler
 
 import (
+	"bytes"
 	"fmt"
+	"html/template"
 	"net/http"
-	"os"
 	"path/filepath"
 
 	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
 
 	"github.com/labstack/echo/v4"
 )
@@ -15,23 +19,44 @@ type OpenAPIHandler struct {
 	Handler
 }
 
-func NewOpenAPIHandler(s *app.Server) *OpenAPIHandler {
+func NewOpenAPIHandler(s *app.Server, v *validation.Validator) *OpenAPIHandler {
 	return &OpenAPIHandler{
-		Handler: NewHandler(s),
+		Handler: NewHandler(s, v),
 	}
 }
 
+// openAPITemplateData is rendered into the OpenAPI UI template
+type openAPITemplateData struct {
+	Nonce string
+}
+
 func (h *OpenAPIHandler) ServeOpenAPIUI(c echo.Context) error {
 	templatePath := filepath.Join("src", "static", "openapi.html")
-	templateBytes, err := os.ReadFile(templatePath)
+	tmpl, err := template.ParseFiles(templatePath)
 	c.Response().Header().Set("Cache-Control", "no-cache")
 	if err != nil {
 		return fmt.Errorf("failed to read OpenAPI UI template: %w", err)
 	}
 
-	templateString := string(templateBytes)
+	// A fresh nonce per response lets the page's scripts run under a strict CSP
+	nonce, err := middleware.NewCSPNonce()
+	if err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, openAPITemplateData{Nonce: nonce}); err != nil {
+		return fmt.Errorf("failed to render OpenAPI UI template: %w", err)
+	}
+
+	var cspConfig config.CSPConfig
+	if h.server != nil && h.server.Config != nil && h.server.Config.Security != nil {
+		cspConfig = h.server.Config.Security.CSP
+	}
+	csp := middleware.NewCSPBuilderFromConfig(cspConfig).WithNonce(nonce)
+	c.Response().Header().Set(csp.HeaderName(), csp.String())
 
-	err = c.HTML(http.StatusOK, templateString)
+	err = c.HTML(http.StatusOK, body.String())
 	if err != nil {
 		return fmt.Errorf("failed to write HTML response: %w", err)
 	}
@@ -0,0 +1,60 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/handler"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var scriptNoncePattern = regexp.MustCompile(`<script nonce="([^"]+)"`)
+
+func serveOpenAPIUI(t *testing.T, h *handler.OpenAPIHandler) *httptest.ResponseRecorder {
+	t.Helper()
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/docs", nil), rec)
+
+	require.NoError(t, h.ServeOpenAPIUI(c))
+	return rec
+}
+
+func TestOpenAPIHandler_ServeOpenAPIUI(t *testing.T) {
+	// The template path is relative to the repository root
+	t.Chdir(filepath.Join("..", "..", ".."))
+
+	h := handler.NewOpenAPIHandler(&app.Server{Config: &config.Config{
+		Security: &config.SecurityConfig{CSP: config.CSPConfig{DefaultSrc: "'self'"}},
+	}}, validation.NewValidator())
+
+	first := serveOpenAPIUI(t, h)
+	second := serveOpenAPIUI(t, h)
+
+	nonces := make([]string, 0, 2)
+	for _, rec := range []*httptest.ResponseRecorder{first, second} {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		match := scriptNoncePattern.FindStringSubmatch(rec.Body.String())
+		require.Len(t, match, 2, "served HTML should carry a script nonce")
+		nonce := match[1]
+
+		csp := rec.Header().Get(echo.HeaderContentSecurityPolicy)
+		assert.Contains(t, csp, "default-src 'self'")
+		assert.Contains(t, csp, "script-src 'nonce-"+nonce+"'")
+		assert.NotContains(t, csp, "unsafe-inline")
+
+		nonces = append(nonces, nonce)
+	}
+
+	assert.NotEqual(t, nonces[0], nonces[1], "each request should get a fresh nonce")
+}
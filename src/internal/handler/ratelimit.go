@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/me"
+	"github.com/Harmeet10000/Fortress_API/src/internal/ratelimit"
+	"github.com/Harmeet10000/Fortress_API/src/internal/service"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
+	"github.com/labstack/echo/v4"
+)
+
+type RateLimitHandler struct {
+	Handler
+	rateLimitService *service.RateLimitService
+}
+
+func NewRateLimitHandler(s *app.Server, v *validation.Validator, rateLimitService *service.RateLimitService) *RateLimitHandler {
+	return &RateLimitHandler{
+		Handler:          NewHandler(s, v),
+		rateLimitService: rateLimitService,
+	}
+}
+
+// GetRateLimitStatus reports the authenticated user's bucket, as kept by the per-user limiter
+func (h *RateLimitHandler) GetRateLimitStatus(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *me.GetRateLimitStatusPayload) (*ratelimit.Status, error) {
+			return h.rateLimitService.GetStatus(c, middleware.UserRateLimitKey(c))
+		},
+		http.StatusOK,
+		&me.GetRateLimitStatusPayload{},
+	)(c)
+}
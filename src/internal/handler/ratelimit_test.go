@@ -0,0 +1,100 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/handler"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/Harmeet10000/Fortress_API/src/internal/ratelimit"
+	"github.com/Harmeet10000/Fortress_API/src/internal/service"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitHandler_GetRateLimitStatus(t *testing.T) {
+	s := &app.Server{
+		Config: &config.Config{
+			RateLimit: &config.RateLimitConfig{Store: "memory", RequestsPerSecond: 10},
+		},
+	}
+	rateLimitService := service.NewRateLimitService(s)
+	h := handler.NewRateLimitHandler(s, validation.NewValidator(), rateLimitService)
+
+	const userID = "user_2NNEqL2nrIRdJ194ndJqAHwEfxC"
+
+	// Simulate prior requests that went through the per-user limiter
+	for i := 0; i < 4; i++ {
+		allowed, err := rateLimitService.Store().Allow("user:" + userID)
+		require.NoError(t, err)
+		require.True(t, allowed)
+	}
+	// Requests from the same address count against the global limiter only
+	_, err := rateLimitService.Store().Allow("203.0.113.7")
+	require.NoError(t, err)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/me/rate-limit", nil)
+	req.RemoteAddr = "203.0.113.7:52100"
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set(middleware.UserIDKey, userID)
+
+	require.NoError(t, h.GetRateLimitStatus(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var status ratelimit.Status
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	assert.Equal(t, 10, status.Limit)
+	// Tokens refill continuously, so allow for a partial refill between calls
+	assert.InDelta(t, 6, status.Remaining, 1)
+	assert.False(t, status.Reset.IsZero())
+}
+
+func TestRateLimit_BurstAndSteadyRate(t *testing.T) {
+	const (
+		requestsPerSecond = 10
+		burst             = 5
+	)
+
+	s := &app.Server{
+		Config: &config.Config{
+			RateLimit: &config.RateLimitConfig{Store: "memory", RequestsPerSecond: requestsPerSecond, Burst: burst},
+		},
+	}
+	rateLimitService := service.NewRateLimitService(s)
+
+	e := echo.New()
+	e.Use(echoMiddleware.RateLimiterWithConfig(echoMiddleware.RateLimiterConfig{
+		Store: rateLimitService.Store(),
+	}))
+	e.GET("/ping", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	call := func() int {
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.RemoteAddr = "203.0.113.9:52100"
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	for i := 0; i < burst; i++ {
+		assert.Equal(t, http.StatusOK, call(), "request %d is within the burst", i+1)
+	}
+	assert.Equal(t, http.StatusTooManyRequests, call(), "the burst is exhausted")
+
+	// Past the burst, tokens come back at the steady rate: one every 100ms
+	time.Sleep(120 * time.Millisecond)
+	assert.Equal(t, http.StatusOK, call(), "one token has refilled")
+	assert.Equal(t, http.StatusTooManyRequests, call(), "sustained traffic is held to the steady rate")
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/system"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
+	"github.com/labstack/echo/v4"
+)
+
+type TimeHandler struct {
+	Handler
+}
+
+func NewTimeHandler(s *app.Server, v *validation.Validator) *TimeHandler {
+	return &TimeHandler{
+		Handler: NewHandler(s, v),
+	}
+}
+
+// GetTime reports the server's clock so clients can detect skew before signing requests
+// or deriving idempotency windows. The Date header carries the same instant.
+func (h *TimeHandler) GetTime(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *system.GetTimePayload) (system.ServerTime, error) {
+			serverTime := system.NewServerTime(time.Now())
+
+			header := c.Response().Header()
+			header.Set("Date", serverTime.Time.Format(http.TimeFormat))
+			header.Set("Cache-Control", "no-store")
+
+			return serverTime, nil
+		},
+		http.StatusOK,
+		&system.GetTimePayload{},
+	)(c)
+}
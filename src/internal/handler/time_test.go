@@ -0,0 +1,43 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/handler"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/system"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeHandler_GetTime(t *testing.T) {
+	h := handler.NewTimeHandler(&app.Server{}, validation.NewValidator())
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/time", nil)
+	rec := httptest.NewRecorder()
+
+	before := time.Now()
+	require.NoError(t, h.GetTime(e.NewContext(req, rec)))
+	after := time.Now()
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+	var res system.ServerTime
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+
+	assert.WithinRange(t, res.Time, before.Truncate(time.Millisecond), after)
+	assert.Equal(t, time.UTC, res.Time.Location())
+	assert.Equal(t, res.Time.UnixMilli(), res.EpochMillis)
+
+	date, err := http.ParseTime(rec.Header().Get("Date"))
+	require.NoError(t, err)
+	assert.WithinDuration(t, res.Time, date, time.Second)
+}
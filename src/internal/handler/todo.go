@@ -2,14 +2,17 @@ package handler
 
 import (
 	"net/http"
+	"time"
 
-	"github.com/labstack/echo/v4"
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
 	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/Harmeet10000/Fortress_API/src/internal/helper/export"
 	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
 	"github.com/Harmeet10000/Fortress_API/src/internal/model"
 	"github.com/Harmeet10000/Fortress_API/src/internal/model/todo"
-	"github.com/Harmeet10000/Fortress_API/src/internal/app"
 	"github.com/Harmeet10000/Fortress_API/src/internal/service"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
+	"github.com/labstack/echo/v4"
 )
 
 type TodoHandler struct {
@@ -17,9 +20,9 @@ type TodoHandler struct {
 	todoService *service.TodoService
 }
 
-func NewTodoHandler(s *app.Server, todoService *service.TodoService) *TodoHandler {
+func NewTodoHandler(s *app.Server, v *validation.Validator, todoService *service.TodoService) *TodoHandler {
 	return &TodoHandler{
-		Handler:     NewHandler(s),
+		Handler:     NewHandler(s, v),
 		todoService: todoService,
 	}
 }
@@ -37,7 +40,7 @@ func (h *TodoHandler) CreateTodo(c echo.Context) error {
 }
 
 func (h *TodoHandler) GetTodoByID(c echo.Context) error {
-	return Handle(
+	return HandleConditional(
 		h.Handler,
 		func(c echo.Context, payload *todo.GetTodoByIDPayload) (*todo.PopulatedTodo, error) {
 			userID := middleware.GetUserID(c)
@@ -45,6 +48,9 @@ func (h *TodoHandler) GetTodoByID(c echo.Context) error {
 		},
 		http.StatusOK,
 		&todo.GetTodoByIDPayload{},
+		func(t *todo.PopulatedTodo) time.Time {
+			return t.UpdatedAt
+		},
 	)(c)
 }
 
@@ -72,6 +78,18 @@ func (h *TodoHandler) UpdateTodo(c echo.Context) error {
 	)(c)
 }
 
+func (h *TodoHandler) UpdateTodoStatus(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *todo.UpdateTodoStatusPayload) (*todo.Todo, error) {
+			userID := middleware.GetUserID(c)
+			return h.todoService.UpdateTodoStatus(c, userID, payload)
+		},
+		http.StatusOK,
+		&todo.UpdateTodoStatusPayload{},
+	)(c)
+}
+
 func (h *TodoHandler) DeleteTodo(c echo.Context) error {
 	return HandleNoContent(
 		h.Handler,
@@ -96,6 +114,49 @@ func (h *TodoHandler) GetTodoStats(c echo.Context) error {
 	)(c)
 }
 
+// todoExportColumns are the CSV columns of a todo export
+var todoExportColumns = []export.Column[todo.Todo]{
+	{Name: "id", Value: func(t todo.Todo) string { return t.ID.String() }},
+	{Name: "title", Value: func(t todo.Todo) string { return t.Title }},
+	{Name: "description", Value: func(t todo.Todo) string { return stringOrEmpty(t.Description) }},
+	{Name: "status", Value: func(t todo.Todo) string { return string(t.Status) }},
+	{Name: "priority", Value: func(t todo.Todo) string { return string(t.Priority) }},
+	{Name: "dueDate", Value: func(t todo.Todo) string { return timeOrEmpty(t.DueDate) }},
+	{Name: "completedAt", Value: func(t todo.Todo) string { return timeOrEmpty(t.CompletedAt) }},
+	{Name: "createdAt", Value: func(t todo.Todo) string { return t.CreatedAt.Format(time.RFC3339) }},
+	{Name: "updatedAt", Value: func(t todo.Todo) string { return t.UpdatedAt.Format(time.RFC3339) }},
+}
+
+// ExportTodos streams all of the caller's todos as CSV or JSON
+func (h *TodoHandler) ExportTodos(c echo.Context) error {
+	return HandleStream(
+		h.Handler,
+		func(c echo.Context, query *todo.ExportTodosQuery) error {
+			userID := middleware.GetUserID(c)
+			cursor, err := h.todoService.ExportTodos(c, userID)
+			if err != nil {
+				return err
+			}
+			return export.Write(c.Response(), export.Format(*query.Format), "todos", cursor, todoExportColumns)
+		},
+		&todo.ExportTodosQuery{},
+	)(c)
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func timeOrEmpty(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
 func (h *TodoHandler) UploadTodoAttachment(c echo.Context) error {
 	return Handle(
 		h.Handler,
@@ -0,0 +1,109 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/Harmeet10000/Fortress_API/src/internal/handler"
+	"github.com/Harmeet10000/Fortress_API/src/internal/service"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlers_RejectInvalidPayloadsUniformly(t *testing.T) {
+	// Services are left empty: a payload that slips past validation would panic on use
+	s := &app.Server{}
+	v := validation.NewValidator()
+	services := &service.Services{}
+
+	todos := handler.NewTodoHandler(s, v, services.Todo)
+	categories := handler.NewCategoryHandler(s, v, services.Category)
+	comments := handler.NewCommentHandler(s, v, services.Comment)
+	caches := handler.NewCacheHandler(s, v, services.Cache)
+
+	todoID := "0b9f6a3c-4a57-4f7e-9d55-0c4a7f0d2b11"
+
+	tests := []struct {
+		name    string
+		handle  echo.HandlerFunc
+		route   string
+		target  string
+		body    string
+		field   string
+		message string
+	}{
+		{
+			name:    "create todo without a title",
+			handle:  todos.CreateTodo,
+			body:    `{"description":"no title"}`,
+			field:   "title",
+			message: "is required",
+		},
+		{
+			name:    "update todo with an unknown status",
+			handle:  todos.UpdateTodo,
+			route:   "/todos/:id",
+			target:  "/todos/" + todoID,
+			body:    `{"status":"done"}`,
+			field:   "status",
+			message: "must be one of: draft active completed archived",
+		},
+		{
+			name:    "create category with an invalid color",
+			handle:  categories.CreateCategory,
+			body:    `{"name":"Work","color":"blue"}`,
+			field:   "color",
+			message: "color: hexcolor",
+		},
+		{
+			name:    "add empty comment",
+			handle:  comments.AddComment,
+			route:   "/todos/:id/comments",
+			target:  "/todos/" + todoID + "/comments",
+			body:    `{"content":""}`,
+			field:   "content",
+			message: "is required",
+		},
+		{
+			name:    "purge cache with an oversized prefix",
+			handle:  caches.PurgeCache,
+			body:    `{"prefix":"` + strings.Repeat("x", 257) + `"}`,
+			field:   "prefix",
+			message: "must not exceed 256 characters",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.route == "" {
+				tt.route, tt.target = "/", "/"
+			}
+
+			// Route through Echo so path parameters are bound exactly as in production
+			var err error
+			e := echo.New()
+			e.Validator = v
+			e.POST(tt.route, func(c echo.Context) error {
+				err = tt.handle(c)
+				return nil
+			})
+
+			req := httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			e.ServeHTTP(httptest.NewRecorder(), req)
+
+			var httpErr *errs.HTTPError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+			assert.Equal(t, "Validation failed", httpErr.Message)
+			assert.True(t, httpErr.Override)
+			assert.Equal(t, []errs.FieldError{{Field: tt.field, Error: tt.message}}, httpErr.Errors)
+		})
+	}
+}
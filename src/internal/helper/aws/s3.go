@@ -3,6 +3,7 @@ package aws
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/Harmeet10000/Fortress_API/src/internal/app"
 )
 
@@ -25,26 +27,93 @@ func NewS3Client(server *app.Server, cfg aws.Config) *S3Client {
 	}
 }
 
+// uploadPartSize is how much of a file is held in memory at once. Files that fit in one
+// part go up in a single PutObject; larger ones are streamed as a multipart upload.
+const uploadPartSize = 8 << 20
+
 func (s *S3Client) UploadFile(ctx context.Context, bucket string, fileName string, file io.Reader) (string, error) {
 	fileKey := fmt.Sprintf("%s_%d", fileName, time.Now().Unix())
 
-	var buffer bytes.Buffer
-	_, err := io.Copy(&buffer, file)
-	if err != nil {
+	buffer := make([]byte, uploadPartSize)
+	n, err := io.ReadFull(file, buffer)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
 		return "", fmt.Errorf("failed to read file: %w", err)
 	}
+	if n < uploadPartSize {
+		_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:      aws.String(bucket),
+			Key:         aws.String(fileKey),
+			Body:        bytes.NewReader(buffer[:n]),
+			ContentType: aws.String(http.DetectContentType(buffer[:n])),
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to upload file to S3: %w", err)
+		}
+		return fileKey, nil
+	}
+
+	if err := s.uploadMultipart(ctx, bucket, fileKey, buffer, file); err != nil {
+		return "", fmt.Errorf("failed to upload file to S3: %w", err)
+	}
+
+	return fileKey, nil
+}
 
-	_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
+// uploadMultipart uploads first, a full part already read, followed by the rest of file
+// one part at a time. A failed upload is aborted so S3 does not keep the orphaned parts.
+func (s *S3Client) uploadMultipart(ctx context.Context, bucket string, key string, first []byte, file io.Reader) error {
+	upload, err := s.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
 		Bucket:      aws.String(bucket),
-		Key:         aws.String(fileKey),
-		Body:        bytes.NewReader(buffer.Bytes()),
-		ContentType: aws.String(http.DetectContentType(buffer.Bytes())),
+		Key:         aws.String(key),
+		ContentType: aws.String(http.DetectContentType(first)),
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to upload file to S3: %w", err)
+		return err
 	}
 
-	return fileKey, nil
+	var parts []types.CompletedPart
+	err = func() error {
+		buffer, n := first, len(first)
+		for partNumber := int32(1); n > 0; partNumber++ {
+			part, err := s.client.UploadPart(ctx, &s3.UploadPartInput{
+				Bucket:     aws.String(bucket),
+				Key:        aws.String(key),
+				UploadId:   upload.UploadId,
+				PartNumber: aws.Int32(partNumber),
+				Body:       bytes.NewReader(buffer[:n]),
+			})
+			if err != nil {
+				return err
+			}
+			parts = append(parts, types.CompletedPart{ETag: part.ETag, PartNumber: aws.Int32(partNumber)})
+
+			n, err = io.ReadFull(file, buffer)
+			if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+				return fmt.Errorf("failed to read file: %w", err)
+			}
+		}
+
+		_, err := s.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:          aws.String(bucket),
+			Key:             aws.String(key),
+			UploadId:        upload.UploadId,
+			MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+		})
+		return err
+	}()
+	if err != nil {
+		_, abortErr := s.client.AbortMultipartUpload(context.WithoutCancel(ctx), &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(bucket),
+			Key:      aws.String(key),
+			UploadId: upload.UploadId,
+		})
+		if abortErr != nil {
+			s.server.Logger.Warn().Err(abortErr).Str("key", key).Msg("failed to abort multipart upload")
+		}
+		return err
+	}
+
+	return nil
 }
 
 func (s *S3Client) CreatePresignedUrl(ctx context.Context, bucket string, objectKey string) (string, error) {
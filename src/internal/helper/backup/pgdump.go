@@ -0,0 +1,48 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+)
+
+// PgDump writes a plain-SQL dump of the configured database using pg_dump, the same
+// tool the db-backup make target runs
+type PgDump struct {
+	cfg config.DatabaseConfig
+}
+
+func NewPgDump(cfg config.DatabaseConfig) *PgDump {
+	return &PgDump{cfg: cfg}
+}
+
+func (p *PgDump) Dump(ctx context.Context, w io.Writer) error {
+	cmd := exec.CommandContext(ctx, "pg_dump",
+		"--host", p.cfg.Host,
+		"--port", strconv.Itoa(p.cfg.Port),
+		"--username", p.cfg.User,
+		"--dbname", p.cfg.Name,
+		"--no-password",
+	)
+	// The password goes through the environment so it never shows up in the process list
+	cmd.Env = append(os.Environ(),
+		"PGPASSWORD="+p.cfg.Password,
+		"PGSSLMODE="+p.cfg.SSLMode,
+	)
+	cmd.Stdout = w
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("pg_dump failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+)
+
+// ErrOpen is matched by every OpenError
+var ErrOpen = errors.New("circuit breaker open")
+
+// OpenError is returned instead of calling a dependency whose breaker is open.
+// RetryAfter is the time left until the breaker lets a trial call through.
+type OpenError struct {
+	Name       string
+	RetryAfter time.Duration
+}
+
+func (e *OpenError) Error() string {
+	return fmt.Sprintf("%s unavailable: circuit breaker open, retry in %s", e.Name, e.RetryAfter.Round(time.Second))
+}
+
+func (e *OpenError) Unwrap() error {
+	return ErrOpen
+}
+
+// HTTPError maps the open breaker to a 503 whose Retry-After is the remaining cooldown,
+// rounded up to whole seconds
+func (e *OpenError) HTTPError() *errs.HTTPError {
+	code := "DEPENDENCY_UNAVAILABLE"
+	seconds := int(math.Ceil(e.RetryAfter.Seconds()))
+	return errs.NewServiceUnavailableError(
+		fmt.Sprintf("%s is temporarily unavailable, please retry later", e.Name), true, &code, max(seconds, 1))
+}
+
+// StatusError carries the HTTP status a dependency answered with, for clients whose
+// errors do not expose it in a form the breaker can inspect
+type StatusError struct {
+	Status int
+	Err    error
+}
+
+func (e *StatusError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// Breaker fails calls to a dependency fast once it has failed threshold times in a row.
+// After cooldown a single trial call is let through: success closes the breaker again,
+// failure reopens it for another cooldown.
+type Breaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	trial     bool
+}
+
+func New(name string, threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{name: name, threshold: threshold, cooldown: cooldown}
+}
+
+// FromConfig builds a breaker from the breaker config section, falling back to the
+// default for each setting left unset
+func FromConfig(name string, cfg *config.BreakerConfig) *Breaker {
+	defaults := config.DefaultBreakerConfig()
+	threshold, cooldown := defaults.Threshold, defaults.Cooldown
+	if cfg != nil && cfg.Threshold > 0 {
+		threshold = cfg.Threshold
+	}
+	if cfg != nil && cfg.Cooldown > 0 {
+		cooldown = cfg.Cooldown
+	}
+	return New(name, threshold, cooldown)
+}
+
+// Do calls fn unless the breaker is open, in which case it returns an *OpenError without
+// calling it. Only transport errors, timeouts and 5xx answers (see StatusError) count
+// against the dependency; a 4xx shows it is up, and other errors give no verdict.
+func (b *Breaker) Do(fn func() error) error {
+	if err := b.acquire(); err != nil {
+		return err
+	}
+
+	err := fn()
+	b.record(err)
+	return err
+}
+
+func (b *Breaker) acquire() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openUntil.IsZero() {
+		return nil
+	}
+	if remaining := time.Until(b.openUntil); remaining > 0 || b.trial {
+		// While a trial call is in flight everyone else keeps failing fast
+		return &OpenError{Name: b.name, RetryAfter: max(remaining, time.Second)}
+	}
+	b.trial = true
+	return nil
+}
+
+func (b *Breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasTrial := b.trial
+	b.trial = false
+
+	switch {
+	case err == nil, isClientError(err):
+		b.failures = 0
+		b.openUntil = time.Time{}
+	case !isDependencyFailure(err):
+		// No verdict on the dependency; after such a trial the next call tries again
+	default:
+		b.failures++
+		if wasTrial || b.failures >= b.threshold {
+			b.failures = 0
+			b.openUntil = time.Now().Add(b.cooldown)
+		}
+	}
+}
+
+// isDependencyFailure reports whether err shows the dependency is down or struggling
+func isDependencyFailure(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Status >= http.StatusInternalServerError
+	}
+
+	// Covers *url.Error and every dial, TLS and read failure, timeouts included
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr)
+}
+
+// isClientError reports whether the dependency answered, rejecting the request itself
+func isClientError(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.Status < http.StatusInternalServerError
+}
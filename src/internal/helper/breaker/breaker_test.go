@@ -0,0 +1,130 @@
+package breaker_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/helper/breaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errDown = &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+func TestBreaker(t *testing.T) {
+	fail := func() error { return errDown }
+	succeed := func() error { return nil }
+
+	t.Run("opens after threshold consecutive failures", func(t *testing.T) {
+		b := breaker.New("Clerk", 3, time.Minute)
+
+		for i := 0; i < 3; i++ {
+			assert.ErrorIs(t, b.Do(fail), errDown)
+		}
+
+		called := false
+		err := b.Do(func() error { called = true; return nil })
+		assert.False(t, called, "an open breaker does not call the dependency")
+
+		var openErr *breaker.OpenError
+		require.ErrorAs(t, err, &openErr)
+		assert.ErrorIs(t, err, breaker.ErrOpen)
+		assert.Equal(t, "Clerk", openErr.Name)
+		assert.InDelta(t, time.Minute.Seconds(), openErr.RetryAfter.Seconds(), 1)
+	})
+
+	t.Run("a success resets the failure count", func(t *testing.T) {
+		b := breaker.New("Clerk", 2, time.Minute)
+
+		_ = b.Do(fail)
+		require.NoError(t, b.Do(succeed))
+		_ = b.Do(fail)
+
+		assert.NoError(t, b.Do(succeed))
+	})
+
+	t.Run("a cancelled caller does not count against the dependency", func(t *testing.T) {
+		b := breaker.New("Clerk", 1, time.Minute)
+
+		_ = b.Do(func() error { return context.Canceled })
+
+		assert.NoError(t, b.Do(succeed))
+	})
+
+	t.Run("only 5xx answers and timeouts count against the dependency", func(t *testing.T) {
+		b := breaker.New("Clerk", 1, time.Minute)
+
+		notFound := &breaker.StatusError{Status: http.StatusNotFound, Err: errors.New("user not found")}
+		assert.ErrorIs(t, b.Do(func() error { return notFound }), notFound)
+		_ = b.Do(func() error { return errors.New("template missing") })
+		assert.NoError(t, b.Do(succeed), "client errors and unclassified errors leave the breaker closed")
+
+		_ = b.Do(func() error {
+			return &breaker.StatusError{Status: http.StatusBadGateway, Err: errors.New("bad gateway")}
+		})
+		assert.ErrorIs(t, b.Do(succeed), breaker.ErrOpen)
+
+		b = breaker.New("Clerk", 1, time.Minute)
+		_ = b.Do(func() error { return context.DeadlineExceeded })
+		assert.ErrorIs(t, b.Do(succeed), breaker.ErrOpen)
+	})
+
+	t.Run("after the cooldown a trial call decides", func(t *testing.T) {
+		b := breaker.New("Email delivery", 1, 20*time.Millisecond)
+
+		_ = b.Do(fail)
+		assert.ErrorIs(t, b.Do(succeed), breaker.ErrOpen)
+
+		time.Sleep(30 * time.Millisecond)
+		_ = b.Do(fail)
+		assert.ErrorIs(t, b.Do(succeed), breaker.ErrOpen, "a failed trial reopens the breaker")
+
+		time.Sleep(30 * time.Millisecond)
+		require.NoError(t, b.Do(succeed))
+		assert.NoError(t, b.Do(succeed), "a successful trial closes the breaker")
+	})
+}
+
+func TestFromConfig(t *testing.T) {
+	defaults := config.DefaultBreakerConfig()
+	fail := func() error { return errDown }
+
+	t.Run("an unset threshold uses the default", func(t *testing.T) {
+		b := breaker.FromConfig("Clerk", &config.BreakerConfig{Cooldown: time.Minute})
+
+		for i := 0; i < defaults.Threshold-1; i++ {
+			_ = b.Do(fail)
+		}
+		require.NoError(t, b.Do(func() error { return nil }), "fewer failures than the default threshold leave it closed")
+
+		for i := 0; i < defaults.Threshold; i++ {
+			_ = b.Do(fail)
+		}
+		var openErr *breaker.OpenError
+		require.ErrorAs(t, b.Do(fail), &openErr)
+		assert.InDelta(t, time.Minute.Seconds(), openErr.RetryAfter.Seconds(), 1)
+	})
+
+	t.Run("an unset cooldown uses the default", func(t *testing.T) {
+		b := breaker.FromConfig("Clerk", &config.BreakerConfig{Threshold: 1})
+
+		_ = b.Do(fail)
+		var openErr *breaker.OpenError
+		require.ErrorAs(t, b.Do(fail), &openErr)
+		assert.InDelta(t, defaults.Cooldown.Seconds(), openErr.RetryAfter.Seconds(), 1)
+	})
+}
+
+func TestOpenError_HTTPError(t *testing.T) {
+	httpErr := (&breaker.OpenError{Name: "Clerk", RetryAfter: 1500 * time.Millisecond}).HTTPError()
+
+	assert.Equal(t, http.StatusServiceUnavailable, httpErr.Status)
+	assert.Equal(t, "DEPENDENCY_UNAVAILABLE", httpErr.Code)
+	assert.Equal(t, 2, httpErr.RetryAfter, "rounded up to whole seconds")
+	assert.Contains(t, httpErr.Message, "Clerk")
+}
@@ -2,24 +2,36 @@ package email
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"html/template"
+	"net/http"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/resend/resend-go/v2"
 	"github.com/rs/zerolog"
 	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/helper/breaker"
+	"github.com/Harmeet10000/Fortress_API/src/internal/helper/httpclient"
+)
+
+const (
+	resendHTTPTimeout = 10 * time.Second
+	resendHTTPRetries = 2
 )
 
 type Client struct {
-	client *resend.Client
-	logger *zerolog.Logger
+	client  *resend.Client
+	logger  *zerolog.Logger
+	breaker *breaker.Breaker
 }
 
 func NewClient(cfg *config.Config, logger *zerolog.Logger) *Client {
 	return &Client{
-		client: resend.NewClient(cfg.Email.ResendKey),
-		logger: logger,
+		client:  resend.NewCustomClient(recordStatus(httpclient.New(resendHTTPTimeout, resendHTTPRetries)), cfg.Email.ResendKey),
+		logger:  logger,
+		breaker: breaker.FromConfig("Email delivery", cfg.Breaker),
 	}
 }
 
@@ -43,10 +55,44 @@ func (c *Client) SendEmail(to, subject string, templateName Template, data map[s
 		Html:    body.String(),
 	}
 
-	_, err = c.client.Emails.Send(params)
+	err = c.breaker.Do(func() error {
+		var status int
+		_, err := c.client.Emails.SendWithContext(withStatus(context.Background(), &status), params)
+		if err != nil && status != 0 {
+			return &breaker.StatusError{Status: status, Err: err}
+		}
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to send email: %w", err)
 	}
 
 	return nil
 }
+
+type statusKey struct{}
+
+// withStatus makes a request sent with ctx store its response status in status
+func withStatus(ctx context.Context, status *int) context.Context {
+	return context.WithValue(ctx, statusKey{}, status)
+}
+
+// recordStatus wraps client's transport to fill in the status asked for by withStatus.
+// Resend's errors only carry the message, so this is how the breaker tells a rejected
+// email (4xx) from Resend being down (5xx).
+func recordStatus(client *http.Client) *http.Client {
+	client.Transport = statusTransport{next: client.Transport}
+	return client
+}
+
+type statusTransport struct {
+	next http.RoundTripper
+}
+
+func (t statusTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if status, ok := req.Context().Value(statusKey{}).(*int); ok && resp != nil {
+		*status = resp.StatusCode
+	}
+	return resp, err
+}
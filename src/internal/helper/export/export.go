@@ -0,0 +1,180 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+const (
+	// Trailers announced on every export and set once the last row is written, so a
+	// client can tell a complete export from one cut short after the 200 went out
+	TrailerStatus = "X-Export-Status"
+	TrailerRows   = "X-Export-Rows"
+
+	StatusComplete   = "complete"
+	StatusIncomplete = "incomplete"
+
+	// The last line of every CSV export is one of these sentinels, for consumers that
+	// cannot read trailers
+	CSVSentinelComplete   = "# export complete"
+	CSVSentinelIncomplete = "# export incomplete"
+
+	// Rows written between flushes, so a large export reaches the client steadily
+	flushEvery = 100
+)
+
+// Cursor yields records one at a time from an open database cursor
+type Cursor[T any] interface {
+	Next() bool
+	Record() (T, error)
+	Err() error
+	Close()
+}
+
+// Column maps a record to one CSV column
+type Column[T any] struct {
+	Name  string
+	Value func(T) string
+}
+
+// Write streams every record from cursor to the response as CSV or JSON and always
+// closes the cursor. An error once the body has started cannot change the status any
+// more, so the export is terminated with an "incomplete" sentinel and trailer instead,
+// and the error is returned for logging.
+func Write[T any](res *echo.Response, format Format, filename string, cursor Cursor[T], columns []Column[T]) error {
+	defer cursor.Close()
+
+	header := res.Header()
+	header.Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename+"."+string(format)))
+	header.Add("Trailer", TrailerStatus)
+	header.Add("Trailer", TrailerRows)
+
+	var (
+		rows int
+		err  error
+	)
+	switch format {
+	case FormatJSON:
+		header.Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		res.WriteHeader(http.StatusOK)
+		rows, err = writeJSON(res, cursor)
+	default:
+		header.Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+		res.WriteHeader(http.StatusOK)
+		rows, err = writeCSV(res, cursor, columns)
+	}
+
+	status := StatusComplete
+	if err != nil {
+		status = StatusIncomplete
+	}
+	header.Set(TrailerStatus, status)
+	header.Set(TrailerRows, strconv.Itoa(rows))
+	res.Flush()
+
+	if err != nil {
+		return fmt.Errorf("export interrupted after %d rows: %w", rows, err)
+	}
+	return nil
+}
+
+func writeCSV[T any](res *echo.Response, cursor Cursor[T], columns []Column[T]) (int, error) {
+	w := csv.NewWriter(res)
+
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.Name
+	}
+
+	rows, err := func() (int, error) {
+		if err := w.Write(names); err != nil {
+			return 0, err
+		}
+
+		rows := 0
+		record := make([]string, len(columns))
+		for cursor.Next() {
+			item, err := cursor.Record()
+			if err != nil {
+				return rows, err
+			}
+			for i, column := range columns {
+				record[i] = column.Value(item)
+			}
+			if err := w.Write(record); err != nil {
+				return rows, err
+			}
+
+			rows++
+			if rows%flushEvery == 0 {
+				w.Flush()
+				res.Flush()
+			}
+		}
+		return rows, cursor.Err()
+	}()
+
+	w.Flush()
+	if err != nil {
+		fmt.Fprintf(res, "%s: stopped after %d rows\n", CSVSentinelIncomplete, rows)
+		return rows, err
+	}
+	if err := w.Error(); err != nil {
+		return rows, err
+	}
+	fmt.Fprintf(res, "%s: %d rows\n", CSVSentinelComplete, rows)
+	return rows, nil
+}
+
+// writeJSON writes {"data":[...],"complete":true,"count":N}. An interrupted export
+// still closes the document, with complete set to false.
+func writeJSON[T any](res *echo.Response, cursor Cursor[T]) (int, error) {
+	rows, err := func() (int, error) {
+		if _, err := res.Write([]byte(`{"data":[`)); err != nil {
+			return 0, err
+		}
+
+		rows := 0
+		for cursor.Next() {
+			item, err := cursor.Record()
+			if err != nil {
+				return rows, err
+			}
+			data, err := json.Marshal(item)
+			if err != nil {
+				return rows, err
+			}
+			if rows > 0 {
+				data = append([]byte{','}, data...)
+			}
+			if _, err := res.Write(data); err != nil {
+				return rows, err
+			}
+
+			rows++
+			if rows%flushEvery == 0 {
+				res.Flush()
+			}
+		}
+		return rows, cursor.Err()
+	}()
+
+	if err != nil {
+		fmt.Fprintf(res, `],"complete":false,"count":%d,"error":"export interrupted"}`, rows)
+		return rows, err
+	}
+	fmt.Fprintf(res, `],"complete":true,"count":%d}`, rows)
+	return rows, nil
+}
@@ -0,0 +1,146 @@
+package export_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/helper/export"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	Name string `json:"name"`
+}
+
+// fakeCursor serves items and fails with err when it reaches failAt
+type fakeCursor struct {
+	items  []item
+	failAt int
+	err    error
+	pos    int
+	closed int
+}
+
+func (c *fakeCursor) Next() bool {
+	if c.pos >= len(c.items) {
+		return false
+	}
+	c.pos++
+	return true
+}
+
+func (c *fakeCursor) Record() (item, error) {
+	if c.err != nil && c.pos == c.failAt {
+		return item{}, c.err
+	}
+	return c.items[c.pos-1], nil
+}
+
+func (c *fakeCursor) Err() error {
+	return nil
+}
+
+func (c *fakeCursor) Close() {
+	c.closed++
+}
+
+var columns = []export.Column[item]{
+	{Name: "name", Value: func(i item) string { return i.Name }},
+}
+
+func newCursor(failAt int) *fakeCursor {
+	cursor := &fakeCursor{items: []item{{"first"}, {"second"}, {"third"}, {"fourth"}}}
+	if failAt > 0 {
+		cursor.failAt = failAt
+		cursor.err = errors.New("connection reset by peer")
+	}
+	return cursor
+}
+
+func runExport(t *testing.T, format export.Format, cursor *fakeCursor) (*httptest.ResponseRecorder, error) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest("GET", "/api/v1/todos/export", nil), rec)
+	return rec, export.Write(c.Response(), format, "todos", cursor, columns)
+}
+
+func TestWrite_CSV(t *testing.T) {
+	t.Run("a complete export ends with the complete sentinel", func(t *testing.T) {
+		cursor := newCursor(0)
+
+		rec, err := runExport(t, export.FormatCSV, cursor)
+		require.NoError(t, err)
+
+		assert.Equal(t, "name\nfirst\nsecond\nthird\nfourth\n# export complete: 4 rows\n", rec.Body.String())
+		assert.Equal(t, `attachment; filename="todos.csv"`, rec.Header().Get(echo.HeaderContentDisposition))
+
+		trailer := rec.Result().Trailer
+		assert.Equal(t, export.StatusComplete, trailer.Get(export.TrailerStatus))
+		assert.Equal(t, "4", trailer.Get(export.TrailerRows))
+		assert.Equal(t, 1, cursor.closed)
+	})
+
+	t.Run("a mid-stream error ends with the incomplete sentinel", func(t *testing.T) {
+		cursor := newCursor(3)
+
+		rec, err := runExport(t, export.FormatCSV, cursor)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "after 2 rows")
+
+		// The status is already on the wire, so the body and trailer carry the failure
+		assert.Equal(t, 200, rec.Code)
+		assert.Equal(t, "name\nfirst\nsecond\n# export incomplete: stopped after 2 rows\n", rec.Body.String())
+
+		trailer := rec.Result().Trailer
+		assert.Equal(t, export.StatusIncomplete, trailer.Get(export.TrailerStatus))
+		assert.Equal(t, "2", trailer.Get(export.TrailerRows))
+		assert.Equal(t, 1, cursor.closed, "the cursor is closed on error")
+	})
+}
+
+func TestWrite_JSON(t *testing.T) {
+	type document struct {
+		Data     []item `json:"data"`
+		Complete bool   `json:"complete"`
+		Count    int    `json:"count"`
+		Error    string `json:"error"`
+	}
+
+	t.Run("a complete export is marked complete", func(t *testing.T) {
+		cursor := newCursor(0)
+
+		rec, err := runExport(t, export.FormatJSON, cursor)
+		require.NoError(t, err)
+
+		var doc document
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+		assert.True(t, doc.Complete)
+		assert.Equal(t, 4, doc.Count)
+		assert.Len(t, doc.Data, 4)
+		assert.True(t, strings.HasPrefix(rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON))
+		assert.Equal(t, export.StatusComplete, rec.Result().Trailer.Get(export.TrailerStatus))
+	})
+
+	t.Run("a mid-stream error still yields a document marked incomplete", func(t *testing.T) {
+		cursor := newCursor(2)
+
+		rec, err := runExport(t, export.FormatJSON, cursor)
+		require.Error(t, err)
+
+		var doc document
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc), "the document is closed despite the error")
+		assert.False(t, doc.Complete)
+		assert.Equal(t, 1, doc.Count)
+		assert.Equal(t, []item{{"first"}}, doc.Data)
+		assert.Equal(t, "export interrupted", doc.Error, "internal error details are not leaked")
+
+		assert.Equal(t, export.StatusIncomplete, rec.Result().Trailer.Get(export.TrailerStatus))
+		assert.Equal(t, 1, cursor.closed, "the cursor is closed on error")
+	})
+}
@@ -0,0 +1,166 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	maxIdleConns        = 100
+	maxIdleConnsPerHost = 10
+	maxConnsPerHost     = 50
+	idleConnTimeout     = 90 * time.Second
+
+	// Base delay before the first retry; doubled on every subsequent attempt
+	retryBaseDelay = 100 * time.Millisecond
+	retryMaxDelay  = 2 * time.Second
+)
+
+// New returns an HTTP client for outbound calls to external services. Each attempt is
+// bounded by timeout, and idempotent requests answered with a 5xx (or failing at the
+// transport level) are retried up to retries times with exponential backoff.
+func New(timeout time.Duration, retries int) *http.Client {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   5 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          maxIdleConns,
+		MaxIdleConnsPerHost:   maxIdleConnsPerHost,
+		MaxConnsPerHost:       maxConnsPerHost,
+		IdleConnTimeout:       idleConnTimeout,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		ForceAttemptHTTP2:     true,
+	}
+
+	return &http.Client{
+		Transport: &retryTransport{
+			next:       transport,
+			timeout:    timeout,
+			retries:    retries,
+			baseDelay:  retryBaseDelay,
+			maxBackoff: retryMaxDelay,
+		},
+	}
+}
+
+type retryTransport struct {
+	next       http.RoundTripper
+	timeout    time.Duration
+	retries    int
+	baseDelay  time.Duration
+	maxBackoff time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attempts := 1
+	if isIdempotent(req) {
+		attempts += t.retries
+	}
+
+	var (
+		resp *http.Response
+		err  error
+	)
+
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if err := t.wait(req.Context(), attempt); err != nil {
+				return nil, err
+			}
+
+			if req.Body != nil && req.GetBody != nil {
+				body, bodyErr := req.GetBody()
+				if bodyErr != nil {
+					return nil, bodyErr
+				}
+				req.Body = body
+			}
+		}
+
+		resp, err = t.roundTripOnce(req)
+
+		last := attempt == attempts-1
+		if last || !shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		// Drain and close the discarded response so the connection can be reused
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+	}
+
+	return resp, err
+}
+
+// roundTripOnce performs a single attempt bounded by the per-attempt timeout. The timeout
+// stays active until the caller closes the response body.
+func (t *retryTransport) roundTripOnce(req *http.Request) (*http.Response, error) {
+	if t.timeout <= 0 {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+func (t *retryTransport) wait(ctx context.Context, attempt int) error {
+	delay := t.baseDelay << (attempt - 1)
+	if delay > t.maxBackoff || delay <= 0 {
+		delay = t.maxBackoff
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError && resp.StatusCode != http.StatusNotImplemented
+}
+
+// isIdempotent reports whether the request can be safely replayed
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodTrace:
+	default:
+		return false
+	}
+
+	// A body that cannot be rewound cannot be replayed
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
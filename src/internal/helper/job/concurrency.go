@@ -0,0 +1,47 @@
+package job
+
+import (
+	"context"
+
+	"github.com/hibiken/asynq"
+)
+
+// TaskConcurrencyLimiter caps the number of tasks of a given type that run at once.
+// Tasks over the limit wait for a free slot instead of failing, so they queue up
+// behind the running ones while still holding their worker goroutine.
+type TaskConcurrencyLimiter struct {
+	semaphores map[string]chan struct{}
+}
+
+func NewTaskConcurrencyLimiter(limits map[string]int) *TaskConcurrencyLimiter {
+	semaphores := make(map[string]chan struct{}, len(limits))
+	for taskType, limit := range limits {
+		if limit > 0 {
+			semaphores[taskType] = make(chan struct{}, limit)
+		}
+	}
+
+	return &TaskConcurrencyLimiter{
+		semaphores: semaphores,
+	}
+}
+
+// Middleware wraps an asynq handler so it respects the per-type limits
+func (l *TaskConcurrencyLimiter) Middleware(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, t *asynq.Task) error {
+		sem, ok := l.semaphores[t.Type()]
+		if !ok {
+			return next.ProcessTask(ctx, t)
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			// Returning the error lets asynq retry the task later
+			return ctx.Err()
+		}
+		defer func() { <-sem }()
+
+		return next.ProcessTask(ctx, t)
+	})
+}
@@ -0,0 +1,96 @@
+package job_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/helper/job"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// trackingHandler records the highest number of tasks it saw running at once
+type trackingHandler struct {
+	running   atomic.Int32
+	maxActive atomic.Int32
+	processed atomic.Int32
+}
+
+func (h *trackingHandler) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	active := h.running.Add(1)
+	defer h.running.Add(-1)
+
+	for {
+		current := h.maxActive.Load()
+		if active <= current || h.maxActive.CompareAndSwap(current, active) {
+			break
+		}
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	h.processed.Add(1)
+	return nil
+}
+
+func runConcurrently(t *testing.T, handler asynq.Handler, taskType string, n int) {
+	t.Helper()
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, handler.ProcessTask(context.Background(), asynq.NewTask(taskType, nil)))
+		}()
+	}
+	wg.Wait()
+}
+
+func TestTaskConcurrencyLimiter(t *testing.T) {
+	t.Run("tasks over the per-type limit queue up", func(t *testing.T) {
+		limiter := job.NewTaskConcurrencyLimiter(map[string]int{job.TaskWelcome: 2})
+		h := &trackingHandler{}
+
+		runConcurrently(t, limiter.Middleware(h), job.TaskWelcome, 8)
+
+		assert.Equal(t, int32(2), h.maxActive.Load())
+		assert.Equal(t, int32(8), h.processed.Load())
+	})
+
+	t.Run("task types without a limit run freely", func(t *testing.T) {
+		limiter := job.NewTaskConcurrencyLimiter(map[string]int{job.TaskWelcome: 1})
+		h := &trackingHandler{}
+
+		runConcurrently(t, limiter.Middleware(h), job.TaskReminderEmail, 4)
+
+		assert.Equal(t, int32(4), h.maxActive.Load())
+	})
+
+	t.Run("waiting task gives up when its context ends", func(t *testing.T) {
+		limiter := job.NewTaskConcurrencyLimiter(map[string]int{job.TaskWelcome: 1})
+		release := make(chan struct{})
+		started := make(chan struct{})
+
+		blocking := asynq.HandlerFunc(func(ctx context.Context, t *asynq.Task) error {
+			close(started)
+			<-release
+			return nil
+		})
+		handler := limiter.Middleware(blocking)
+
+		go func() { _ = handler.ProcessTask(context.Background(), asynq.NewTask(job.TaskWelcome, nil)) }()
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		err := handler.ProcessTask(ctx, asynq.NewTask(job.TaskWelcome, nil))
+		close(release)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -1,12 +1,16 @@
 package job
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
 	"github.com/Harmeet10000/Fortress_API/src/internal/model/todo"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
 )
 
 const (
@@ -15,13 +19,30 @@ const (
 	TaskWeeklyReportEmail = "email:weekly_report"
 )
 
+// WelcomeEmailPayloadVersion is the payload schema NewWelcomeEmailTask writes. Bump it
+// when the shape changes and keep decoding the previous versions in
+// DecodeWelcomeEmailPayload, since tasks enqueued before a deploy are still in the queue.
+const WelcomeEmailPayloadVersion = 1
+
+// ErrUnknownPayloadVersion is returned for a payload newer than this worker understands,
+// e.g. enqueued by an already upgraded instance during a rollout
+var ErrUnknownPayloadVersion = errors.New("unknown payload version")
+
 type WelcomeEmailPayload struct {
+	Version   int    `json:"version"`
+	To        string `json:"to"`
+	FirstName string `json:"first_name"`
+}
+
+// legacyWelcomeEmailPayload is the unversioned shape enqueued before payloads carried a version
+type legacyWelcomeEmailPayload struct {
 	To        string `json:"to"`
 	FirstName string `json:"first_name"`
 }
 
 func NewWelcomeEmailTask(to, firstName string) (*asynq.Task, error) {
 	payload, err := json.Marshal(WelcomeEmailPayload{
+		Version:   WelcomeEmailPayloadVersion,
 		To:        to,
 		FirstName: firstName,
 	})
@@ -35,6 +56,72 @@ func NewWelcomeEmailTask(to, firstName string) (*asynq.Task, error) {
 		asynq.Timeout(30*time.Second)), nil
 }
 
+// DecodeWelcomeEmailPayload decodes any welcome email payload version into the current
+// shape. legacy reports an unversioned payload from before versioning was introduced.
+func DecodeWelcomeEmailPayload(data []byte) (p WelcomeEmailPayload, legacy bool, err error) {
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return p, false, err
+	}
+
+	switch header.Version {
+	case 0:
+		var old legacyWelcomeEmailPayload
+		if err := json.Unmarshal(data, &old); err != nil {
+			return p, true, err
+		}
+		return WelcomeEmailPayload{
+			Version:   WelcomeEmailPayloadVersion,
+			To:        old.To,
+			FirstName: old.FirstName,
+		}, true, nil
+	case WelcomeEmailPayloadVersion:
+		err := json.Unmarshal(data, &p)
+		return p, false, err
+	default:
+		return p, false, fmt.Errorf("%w %d for %s", ErrUnknownPayloadVersion, header.Version, TaskWelcome)
+	}
+}
+
+// Window during which a repeated welcome email enqueue for the same user is rejected
+const welcomeEmailUniqueTTL = time.Hour
+
+// WelcomeEmailTaskID derives the task ID from the user so the same welcome email is
+// never enqueued twice, e.g. when a signup request is retried
+func WelcomeEmailTaskID(userID string) string {
+	return "welcome:" + userID
+}
+
+// EnqueueWelcomeEmail enqueues the welcome email for a user at most once. A duplicate
+// enqueue within the uniqueness window is treated as a successful no-op. The address is
+// normalized first and an invalid one is rejected before anything is enqueued.
+func EnqueueWelcomeEmail(ctx context.Context, client TaskEnqueuer, userID, to, firstName string) error {
+	to, err := validation.NormalizeEmail(to)
+	if err != nil {
+		return err
+	}
+
+	task, err := NewWelcomeEmailTask(to, firstName)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.EnqueueContext(ctx, task,
+		asynq.TaskID(WelcomeEmailTaskID(userID)),
+		asynq.Unique(welcomeEmailUniqueTTL))
+	if IsDuplicateTask(err) {
+		return nil
+	}
+	return err
+}
+
+// IsDuplicateTask reports whether an enqueue failed only because the task already exists
+func IsDuplicateTask(err error) bool {
+	return errors.Is(err, asynq.ErrDuplicateTask) || errors.Is(err, asynq.ErrTaskIDConflict)
+}
+
 type ReminderEmailTask struct {
 	UserID    string    `json:"user_id"`
 	TodoID    uuid.UUID `json:"todo_id"`
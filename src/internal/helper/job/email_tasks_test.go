@@ -0,0 +1,131 @@
+package job_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/helper/job"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// uniqueEnqueuer mimics asynq's handling of task IDs by rejecting a repeated ID
+type uniqueEnqueuer struct {
+	tasks map[string]*asynq.Task
+	err   error
+}
+
+func newUniqueEnqueuer() *uniqueEnqueuer {
+	return &uniqueEnqueuer{tasks: make(map[string]*asynq.Task)}
+}
+
+func (u *uniqueEnqueuer) EnqueueContext(ctx context.Context, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	if u.err != nil {
+		return nil, u.err
+	}
+
+	var id string
+	for _, opt := range opts {
+		if opt.Type() == asynq.TaskIDOpt {
+			id = opt.Value().(string)
+		}
+	}
+
+	if _, exists := u.tasks[id]; exists {
+		return nil, asynq.ErrTaskIDConflict
+	}
+	u.tasks[id] = task
+	return &asynq.TaskInfo{ID: id, Type: task.Type()}, nil
+}
+
+func TestEnqueueWelcomeEmail(t *testing.T) {
+	t.Run("enqueuing the same welcome email twice results in one task", func(t *testing.T) {
+		enqueuer := newUniqueEnqueuer()
+
+		require.NoError(t, job.EnqueueWelcomeEmail(context.Background(), enqueuer, "user_1", "ada@example.com", "Ada"))
+		require.NoError(t, job.EnqueueWelcomeEmail(context.Background(), enqueuer, "user_1", "ada@example.com", "Ada"))
+
+		assert.Len(t, enqueuer.tasks, 1)
+		assert.Contains(t, enqueuer.tasks, job.WelcomeEmailTaskID("user_1"))
+	})
+
+	t.Run("different users each get a task", func(t *testing.T) {
+		enqueuer := newUniqueEnqueuer()
+
+		require.NoError(t, job.EnqueueWelcomeEmail(context.Background(), enqueuer, "user_1", "ada@example.com", "Ada"))
+		require.NoError(t, job.EnqueueWelcomeEmail(context.Background(), enqueuer, "user_2", "alan@example.com", "Alan"))
+
+		assert.Len(t, enqueuer.tasks, 2)
+	})
+
+	t.Run("duplicate-task errors are a no-op but other errors surface", func(t *testing.T) {
+		enqueuer := newUniqueEnqueuer()
+
+		enqueuer.err = asynq.ErrDuplicateTask
+		assert.NoError(t, job.EnqueueWelcomeEmail(context.Background(), enqueuer, "user_1", "ada@example.com", "Ada"))
+
+		enqueuer.err = errors.New("redis unavailable")
+		assert.Error(t, job.EnqueueWelcomeEmail(context.Background(), enqueuer, "user_1", "ada@example.com", "Ada"))
+	})
+	t.Run("the address is normalized before enqueuing", func(t *testing.T) {
+		enqueuer := newUniqueEnqueuer()
+
+		require.NoError(t, job.EnqueueWelcomeEmail(context.Background(), enqueuer, "user_1", "  Ada@Example.COM ", "Ada"))
+
+		var payload job.WelcomeEmailPayload
+		require.NoError(t, json.Unmarshal(enqueuer.tasks[job.WelcomeEmailTaskID("user_1")].Payload(), &payload))
+		assert.Equal(t, "ada@example.com", payload.To)
+	})
+
+	t.Run("an invalid address is not enqueued", func(t *testing.T) {
+		enqueuer := newUniqueEnqueuer()
+
+		assert.Error(t, job.EnqueueWelcomeEmail(context.Background(), enqueuer, "user_1", "not-an-email", "Ada"))
+		assert.Empty(t, enqueuer.tasks)
+	})
+}
+
+func TestDecodeWelcomeEmailPayload(t *testing.T) {
+	t.Run("a versioned payload decodes as current", func(t *testing.T) {
+		task, err := job.NewWelcomeEmailTask("ada@example.com", "Ada")
+		require.NoError(t, err)
+
+		payload, legacy, err := job.DecodeWelcomeEmailPayload(task.Payload())
+		require.NoError(t, err)
+
+		assert.False(t, legacy)
+		assert.Equal(t, job.WelcomeEmailPayload{
+			Version:   job.WelcomeEmailPayloadVersion,
+			To:        "ada@example.com",
+			FirstName: "Ada",
+		}, payload)
+	})
+
+	t.Run("a legacy unversioned payload is upgraded", func(t *testing.T) {
+		payload, legacy, err := job.DecodeWelcomeEmailPayload([]byte(`{"to":"alan@example.com","first_name":"Alan"}`))
+		require.NoError(t, err)
+
+		assert.True(t, legacy)
+		assert.Equal(t, job.WelcomeEmailPayload{
+			Version:   job.WelcomeEmailPayloadVersion,
+			To:        "alan@example.com",
+			FirstName: "Alan",
+		}, payload)
+	})
+
+	t.Run("a newer version is rejected without skipping retries", func(t *testing.T) {
+		_, _, err := job.DecodeWelcomeEmailPayload([]byte(`{"version":99,"to":"ada@example.com"}`))
+
+		assert.ErrorIs(t, err, job.ErrUnknownPayloadVersion)
+		assert.NotErrorIs(t, err, asynq.SkipRetry)
+	})
+
+	t.Run("malformed JSON fails", func(t *testing.T) {
+		_, _, err := job.DecodeWelcomeEmailPayload([]byte(`{"to":`))
+
+		assert.Error(t, err)
+	})
+}
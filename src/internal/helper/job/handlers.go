@@ -9,6 +9,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/Harmeet10000/Fortress_API/src/internal/config"
 	"github.com/Harmeet10000/Fortress_API/src/internal/helper/email"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
 )
 
 func (j *JobService) InitHandlers(config *config.Config, logger *zerolog.Logger) {
@@ -16,17 +17,30 @@ func (j *JobService) InitHandlers(config *config.Config, logger *zerolog.Logger)
 }
 
 func (j *JobService) handleWelcomeEmailTask(ctx context.Context, t *asynq.Task) error {
-	var p WelcomeEmailPayload
-	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+	// An unknown version is retried: a newer instance may pick it up during a rollout
+	p, legacy, err := DecodeWelcomeEmailPayload(t.Payload())
+	if err != nil {
 		return fmt.Errorf("failed to unmarshal welcome email payload: %w", err)
 	}
+	if legacy {
+		j.logger.Info().
+			Str("type", "welcome").
+			Msg("Handling legacy unversioned welcome email payload")
+	}
+
+	// Tasks enqueued before addresses were normalized may still carry the raw value
+	to, err := validation.NormalizeEmail(p.To)
+	if err != nil {
+		return fmt.Errorf("welcome email not sent: %v: %w", err, asynq.SkipRetry)
+	}
+	p.To = to
 
 	j.logger.Info().
 		Str("type", "welcome").
 		Str("to", p.To).
 		Msg("Processing welcome email task")
 
-	err := j.emailClient.SendWelcomeEmail(
+	err = j.emailClient.SendWelcomeEmail(
 		p.To,
 		p.FirstName,
 	)
@@ -2,8 +2,10 @@ package job
 
 import (
 	"context"
+	"time"
 
 	"github.com/hibiken/asynq"
+	"github.com/newrelic/go-agent/v3/newrelic"
 	"github.com/rs/zerolog"
 	"github.com/Harmeet10000/Fortress_API/src/internal/config"
 	"github.com/Harmeet10000/Fortress_API/src/internal/helper/email"
@@ -15,6 +17,10 @@ type JobService struct {
 	logger      *zerolog.Logger
 	authService AuthServiceInterface
 	emailClient *email.Client
+	limiter     *TaskConcurrencyLimiter
+	inspector   *asynq.Inspector
+	metrics     *QueueMetricsCollector
+	metricsPoll time.Duration
 }
 
 type AuthServiceInterface interface {
@@ -24,16 +30,23 @@ type AuthServiceInterface interface {
 func NewJobService(logger *zerolog.Logger, cfg *config.Config) *JobService {
 	redisAddr := cfg.Redis.Address
 
+	workerCfg := cfg.Worker
+	if workerCfg == nil {
+		workerCfg = config.DefaultWorkerConfig()
+	}
+
 	client := asynq.NewClient(asynq.RedisClientOpt{
 		Addr:     redisAddr,
 		Password: cfg.Redis.Password,
 		DB:       0,
 	})
 
+	inspector := asynq.NewInspector(asynq.RedisClientOpt{Addr: redisAddr, Password: cfg.Redis.Password, DB: 0})
+
 	server := asynq.NewServer(
 		asynq.RedisClientOpt{Addr: redisAddr, Password: cfg.Redis.Password, DB: 0},
 		asynq.Config{
-			Concurrency: 10,
+			Concurrency: workerCfg.Concurrency,
 			Queues: map[string]int{
 				"critical": 6, // Higher priority queue for important emails
 				"default":  3, // Default priority for most emails
@@ -43,9 +56,12 @@ func NewJobService(logger *zerolog.Logger, cfg *config.Config) *JobService {
 	)
 
 	return &JobService{
-		Client: client,
-		server: server,
-		logger: logger,
+		Client:      client,
+		server:      server,
+		logger:      logger,
+		limiter:     NewTaskConcurrencyLimiter(workerCfg.TaskConcurrency),
+		inspector:   inspector,
+		metricsPoll: workerCfg.MetricsPollInterval,
 	}
 }
 
@@ -56,6 +72,7 @@ func (j *JobService) SetAuthService(authService AuthServiceInterface) {
 func (j *JobService) Start() error {
 	// Register task handlers
 	mux := asynq.NewServeMux()
+	mux.Use(j.limiter.Middleware)
 	mux.HandleFunc(TaskWelcome, j.handleWelcomeEmailTask)
 	mux.HandleFunc(TaskReminderEmail, j.handleReminderEmailTask)
 	mux.HandleFunc(TaskWeeklyReportEmail, j.handleWeeklyReportEmailTask)
@@ -68,8 +85,28 @@ func (j *JobService) Start() error {
 	return nil
 }
 
+// StartQueueMetrics begins polling queue depths and dead-letter counts, reporting them to New Relic
+func (j *JobService) StartQueueMetrics(nrApp *newrelic.Application) {
+	interval := j.metricsPoll
+	if interval <= 0 {
+		interval = config.DefaultWorkerConfig().MetricsPollInterval
+	}
+
+	j.metrics = NewQueueMetricsCollector(j.inspector, nrApp, j.logger, interval)
+	j.metrics.Start()
+}
+
+// StopQueueMetrics stops the poller started by StartQueueMetrics, if any
+func (j *JobService) StopQueueMetrics() {
+	if j.metrics != nil {
+		j.metrics.Stop()
+	}
+}
+
 func (j *JobService) Stop() {
 	j.logger.Info().Msg("Stopping background job server")
+	j.StopQueueMetrics()
 	j.server.Shutdown()
+	j.inspector.Close()
 	j.Client.Close()
 }
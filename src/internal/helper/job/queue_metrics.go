@@ -0,0 +1,140 @@
+package job
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/rs/zerolog"
+)
+
+// QueueInspector is the subset of *asynq.Inspector used to collect queue metrics
+type QueueInspector interface {
+	Queues() ([]string, error)
+	GetQueueInfo(queue string) (*asynq.QueueInfo, error)
+}
+
+// QueueStats holds the latest gauge values for a single queue. Archived tasks are the
+// ones asynq gave up on after exhausting their retries, i.e. the dead-letter queue.
+type QueueStats struct {
+	Size      int
+	Pending   int
+	Active    int
+	Scheduled int
+	Retry     int
+	Archived  int
+}
+
+// QueueMetricsCollector periodically polls asynq for queue depths and reports them as
+// New Relic custom metrics so alerts can fire on a growing dead-letter queue
+type QueueMetricsCollector struct {
+	inspector QueueInspector
+	nrApp     *newrelic.Application
+	logger    *zerolog.Logger
+	interval  time.Duration
+
+	mu    sync.RWMutex
+	stats map[string]QueueStats
+
+	stop chan struct{}
+	once sync.Once
+}
+
+func NewQueueMetricsCollector(inspector QueueInspector, nrApp *newrelic.Application,
+	logger *zerolog.Logger, interval time.Duration,
+) *QueueMetricsCollector {
+	return &QueueMetricsCollector{
+		inspector: inspector,
+		nrApp:     nrApp,
+		logger:    logger,
+		interval:  interval,
+		stats:     make(map[string]QueueStats),
+		stop:      make(chan struct{}),
+	}
+}
+
+// Start polls the queues every interval until Stop is called
+func (c *QueueMetricsCollector) Start() {
+	go func() {
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := c.Collect(); err != nil {
+				c.logger.Warn().Err(err).Msg("failed to collect job queue metrics")
+			}
+
+			select {
+			case <-c.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (c *QueueMetricsCollector) Stop() {
+	c.once.Do(func() { close(c.stop) })
+}
+
+// Collect takes a single snapshot of every queue and records it
+func (c *QueueMetricsCollector) Collect() error {
+	queues, err := c.inspector.Queues()
+	if err != nil {
+		return fmt.Errorf("failed to list queues: %w", err)
+	}
+
+	stats := make(map[string]QueueStats, len(queues))
+	for _, queue := range queues {
+		info, err := c.inspector.GetQueueInfo(queue)
+		if err != nil {
+			return fmt.Errorf("failed to get info for queue %s: %w", queue, err)
+		}
+
+		stats[queue] = QueueStats{
+			Size:      info.Size,
+			Pending:   info.Pending,
+			Active:    info.Active,
+			Scheduled: info.Scheduled,
+			Retry:     info.Retry,
+			Archived:  info.Archived,
+		}
+	}
+
+	c.mu.Lock()
+	c.stats = stats
+	c.mu.Unlock()
+
+	c.record(stats)
+	return nil
+}
+
+// Stats returns a copy of the most recently collected gauges, keyed by queue name
+func (c *QueueMetricsCollector) Stats() map[string]QueueStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	stats := make(map[string]QueueStats, len(c.stats))
+	for queue, s := range c.stats {
+		stats[queue] = s
+	}
+	return stats
+}
+
+func (c *QueueMetricsCollector) record(stats map[string]QueueStats) {
+	if c.nrApp == nil {
+		return
+	}
+
+	for queue, s := range stats {
+		prefix := "Custom/Asynq/Queue/" + queue + "/"
+		c.nrApp.RecordCustomMetric(prefix+"Size", float64(s.Size))
+		c.nrApp.RecordCustomMetric(prefix+"Pending", float64(s.Pending))
+		c.nrApp.RecordCustomMetric(prefix+"Active", float64(s.Active))
+		c.nrApp.RecordCustomMetric(prefix+"Scheduled", float64(s.Scheduled))
+		c.nrApp.RecordCustomMetric(prefix+"Retry", float64(s.Retry))
+		c.nrApp.RecordCustomMetric(prefix+"DeadLetter", float64(s.Archived))
+	}
+}
@@ -0,0 +1,77 @@
+package job_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/helper/job"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubInspector struct {
+	queues map[string]*asynq.QueueInfo
+	err    error
+}
+
+func (s *stubInspector) Queues() ([]string, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	names := make([]string, 0, len(s.queues))
+	for name := range s.queues {
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func (s *stubInspector) GetQueueInfo(queue string) (*asynq.QueueInfo, error) {
+	return s.queues[queue], nil
+}
+
+func TestQueueMetricsCollector_Collect(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("gauges reflect the reported queue sizes", func(t *testing.T) {
+		inspector := &stubInspector{queues: map[string]*asynq.QueueInfo{
+			"default":  {Queue: "default", Size: 12, Pending: 5, Active: 2, Scheduled: 1, Retry: 3, Archived: 1},
+			"critical": {Queue: "critical", Size: 7, Archived: 7},
+		}}
+		collector := job.NewQueueMetricsCollector(inspector, nil, &logger, time.Minute)
+
+		require.NoError(t, collector.Collect())
+
+		stats := collector.Stats()
+		assert.Equal(t, job.QueueStats{Size: 12, Pending: 5, Active: 2, Scheduled: 1, Retry: 3, Archived: 1}, stats["default"])
+		assert.Equal(t, 7, stats["critical"].Archived)
+	})
+
+	t.Run("gauges track a growing dead-letter queue", func(t *testing.T) {
+		inspector := &stubInspector{queues: map[string]*asynq.QueueInfo{
+			"default": {Queue: "default", Archived: 1},
+		}}
+		collector := job.NewQueueMetricsCollector(inspector, nil, &logger, time.Minute)
+
+		require.NoError(t, collector.Collect())
+		inspector.queues["default"] = &asynq.QueueInfo{Queue: "default", Archived: 4}
+		require.NoError(t, collector.Collect())
+
+		assert.Equal(t, 4, collector.Stats()["default"].Archived)
+	})
+
+	t.Run("inspector errors keep the previous snapshot", func(t *testing.T) {
+		inspector := &stubInspector{queues: map[string]*asynq.QueueInfo{
+			"default": {Queue: "default", Retry: 2},
+		}}
+		collector := job.NewQueueMetricsCollector(inspector, nil, &logger, time.Minute)
+		require.NoError(t, collector.Collect())
+
+		inspector.err = errors.New("redis unavailable")
+
+		assert.Error(t, collector.Collect())
+		assert.Equal(t, 2, collector.Stats()["default"].Retry)
+	})
+}
@@ -0,0 +1,49 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// TaskEnqueuer is the subset of *asynq.Client needed to enqueue tasks
+type TaskEnqueuer interface {
+	EnqueueContext(ctx context.Context, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error)
+}
+
+// Schedule describes when a task should be processed. The zero value processes it
+// immediately; At takes precedence over In when both are set.
+type Schedule struct {
+	At time.Time
+	In time.Duration
+}
+
+// EnqueueTask enqueues a task according to the schedule. Extra options are applied
+// after the task's own options, so they can override queue, retries or timeout.
+func EnqueueTask(ctx context.Context, client TaskEnqueuer, task *asynq.Task, schedule Schedule,
+	opts ...asynq.Option,
+) (*asynq.TaskInfo, error) {
+	switch {
+	case !schedule.At.IsZero():
+		opts = append(opts, asynq.ProcessAt(schedule.At))
+	case schedule.In > 0:
+		opts = append(opts, asynq.ProcessIn(schedule.In))
+	}
+
+	return client.EnqueueContext(ctx, task, opts...)
+}
+
+// EnqueueAt schedules a task to be processed at a specific time
+func (j *JobService) EnqueueAt(ctx context.Context, task *asynq.Task, at time.Time,
+	opts ...asynq.Option,
+) (*asynq.TaskInfo, error) {
+	return EnqueueTask(ctx, j.Client, task, Schedule{At: at}, opts...)
+}
+
+// EnqueueIn schedules a task to be processed after a delay, e.g. a follow-up email 24h after signup
+func (j *JobService) EnqueueIn(ctx context.Context, task *asynq.Task, delay time.Duration,
+	opts ...asynq.Option,
+) (*asynq.TaskInfo, error) {
+	return EnqueueTask(ctx, j.Client, task, Schedule{In: delay}, opts...)
+}
@@ -0,0 +1,77 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/helper/job"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingEnqueuer struct {
+	task *asynq.Task
+	opts []asynq.Option
+}
+
+func (r *recordingEnqueuer) EnqueueContext(ctx context.Context, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	r.task = task
+	r.opts = opts
+	return &asynq.TaskInfo{Type: task.Type()}, nil
+}
+
+func (r *recordingEnqueuer) option(optType asynq.OptionType) (asynq.Option, bool) {
+	for _, opt := range r.opts {
+		if opt.Type() == optType {
+			return opt, true
+		}
+	}
+	return nil, false
+}
+
+func TestEnqueueTask(t *testing.T) {
+	task, err := job.NewWelcomeEmailTask("user@example.com", "Ada")
+	require.NoError(t, err)
+
+	t.Run("process at a specific time", func(t *testing.T) {
+		enqueuer := &recordingEnqueuer{}
+		at := time.Date(2030, time.January, 2, 9, 0, 0, 0, time.UTC)
+
+		_, err := job.EnqueueTask(context.Background(), enqueuer, task, job.Schedule{At: at})
+		require.NoError(t, err)
+
+		opt, ok := enqueuer.option(asynq.ProcessAtOpt)
+		require.True(t, ok)
+		assert.Equal(t, at, opt.Value())
+		assert.Equal(t, job.TaskWelcome, enqueuer.task.Type())
+	})
+
+	t.Run("process after a delay", func(t *testing.T) {
+		enqueuer := &recordingEnqueuer{}
+
+		_, err := job.EnqueueTask(context.Background(), enqueuer, task, job.Schedule{In: 24 * time.Hour})
+		require.NoError(t, err)
+
+		opt, ok := enqueuer.option(asynq.ProcessInOpt)
+		require.True(t, ok)
+		assert.Equal(t, 24*time.Hour, opt.Value())
+	})
+
+	t.Run("zero schedule enqueues immediately", func(t *testing.T) {
+		enqueuer := &recordingEnqueuer{}
+
+		_, err := job.EnqueueTask(context.Background(), enqueuer, task, job.Schedule{}, asynq.Queue("critical"))
+		require.NoError(t, err)
+
+		_, hasAt := enqueuer.option(asynq.ProcessAtOpt)
+		_, hasIn := enqueuer.option(asynq.ProcessInOpt)
+		assert.False(t, hasAt)
+		assert.False(t, hasIn)
+
+		queue, ok := enqueuer.option(asynq.QueueOpt)
+		require.True(t, ok)
+		assert.Equal(t, "critical", queue.Value())
+	})
+}
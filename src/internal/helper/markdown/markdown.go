@@ -0,0 +1,28 @@
+package markdown
+
+import (
+	"bytes"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+var (
+	// Raw HTML in the source is dropped by goldmark's default (safe) renderer
+	renderer = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+	// The sanitizer is the real guard: it strips anything that could execute, such as
+	// javascript: links or event handler attributes
+	policy = bluemonday.UGCPolicy()
+)
+
+// ToSafeHTML renders user-supplied markdown to HTML that is safe to embed in a page
+func ToSafeHTML(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := renderer.Convert([]byte(source), &buf); err != nil {
+		return "", err
+	}
+
+	return policy.Sanitize(buf.String()), nil
+}
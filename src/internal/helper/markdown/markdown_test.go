@@ -0,0 +1,40 @@
+package markdown_test
+
+import (
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/helper/markdown"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToSafeHTML(t *testing.T) {
+	t.Run("renders markdown", func(t *testing.T) {
+		html, err := markdown.ToSafeHTML("**Ship it** with `go test` and [docs](https://example.com)\n\n- one\n- two")
+		require.NoError(t, err)
+
+		assert.Contains(t, html, "<strong>Ship it</strong>")
+		assert.Contains(t, html, "<code>go test</code>")
+		assert.Contains(t, html, `<a href="https://example.com" rel="nofollow">docs</a>`)
+		assert.Contains(t, html, "<li>one</li>")
+	})
+
+	t.Run("strips script injection", func(t *testing.T) {
+		for name, source := range map[string]string{
+			"raw script tag":     "hello <script>alert('xss')</script>",
+			"javascript link":    "[click](javascript:alert('xss'))",
+			"event handler":      `<img src="x" onerror="alert('xss')">`,
+			"html inside a list": "- <iframe src=\"https://evil.example\"></iframe>",
+		} {
+			t.Run(name, func(t *testing.T) {
+				html, err := markdown.ToSafeHTML(source)
+				require.NoError(t, err)
+
+				assert.NotContains(t, html, "<script")
+				assert.NotContains(t, html, "javascript:")
+				assert.NotContains(t, html, "onerror")
+				assert.NotContains(t, html, "<iframe")
+			})
+		}
+	})
+}
@@ -21,6 +21,7 @@ type LoggerService struct {
 
 // NewLoggerService creates a new logger service with New Relic integration
 func NewLoggerService(cfg *config.ObservabilityConfig) *LoggerService {
+	cfg = observabilityOrDefault(cfg, "NewLoggerService")
 	service := &LoggerService{}
 
 	if cfg.NewRelic.LicenseKey == "" {
@@ -64,6 +65,8 @@ func (ls *LoggerService) GetApplication() *newrelic.Application {
 
 // NewLoggerWithService creates a logger with full config and logger service
 func NewLoggerWithService(cfg *config.ObservabilityConfig, loggerService *LoggerService) zerolog.Logger {
+	cfg = observabilityOrDefault(cfg, "NewLoggerWithService")
+
 	var logLevel zerolog.Level
 	level := cfg.GetLogLevel()
 
@@ -123,6 +126,19 @@ func NewLoggerWithService(cfg *config.ObservabilityConfig, loggerService *Logger
 	return logger
 }
 
+// observabilityOrDefault falls back to the default observability config when cfg is nil,
+// so a change in construction order degrades to defaults instead of a startup panic
+func observabilityOrDefault(cfg *config.ObservabilityConfig, function string) *config.ObservabilityConfig {
+	if cfg != nil {
+		return cfg
+	}
+
+	logger := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	logger.Warn().Str("function", function).Msg("observability config is nil, falling back to defaults")
+
+	return config.DefaultObservabilityConfig()
+}
+
 // WithTraceContext adds New Relic transaction context to logger
 func WithTraceContext(logger zerolog.Logger, txn *newrelic.Transaction) zerolog.Logger {
 	if txn == nil {
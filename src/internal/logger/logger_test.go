@@ -0,0 +1,36 @@
+package logger_test
+
+import (
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/logger"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLoggerService_NilConfig(t *testing.T) {
+	var service *logger.LoggerService
+	require.NotPanics(t, func() {
+		service = logger.NewLoggerService(nil)
+	})
+
+	require.NotNil(t, service)
+	// Defaults carry no license key, so New Relic stays disabled
+	assert.Nil(t, service.GetApplication())
+	service.Shutdown()
+}
+
+func TestNewLoggerWithService_NilConfig(t *testing.T) {
+	service := logger.NewLoggerService(nil)
+
+	var log zerolog.Logger
+	require.NotPanics(t, func() {
+		log = logger.NewLoggerWithService(nil, service)
+	})
+
+	assert.Equal(t, zerolog.InfoLevel, log.GetLevel())
+	assert.NotPanics(t, func() {
+		log.Info().Msg("logger built from default observability config")
+	})
+}
@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	AccessFormatJSON   = "json"
+	AccessFormatLogfmt = "logfmt"
+	AccessFormatCEF    = "cef"
+)
+
+// CEF header fields identifying the log source to a SIEM
+const (
+	cefVendor        = "Fortress"
+	cefDeviceVersion = "1.0"
+)
+
+// writeAccessLog emits the entry in the given format. The json format goes through the
+// request's structured logger so context fields (trace IDs, user) are kept; logfmt and
+// CEF lines are written verbatim for pipelines that expect exactly that format.
+func writeAccessLog(format string, logger *zerolog.Logger, out io.Writer, entry AccessLogEntry, err error) {
+	switch format {
+	case AccessFormatLogfmt:
+		fmt.Fprintln(out, entry.Logfmt())
+	case AccessFormatCEF:
+		fmt.Fprintln(out, entry.CEF())
+	default:
+		event := logger.WithLevel(accessLogLevel(entry.Status))
+		if entry.Status >= 500 {
+			event = event.Err(err)
+		}
+		event.EmbedObject(entry).Msg("API")
+	}
+}
+
+// AccessLogEntry is one access-log line, serialized according to the configured format
+type AccessLogEntry struct {
+	Time      time.Time
+	Service   string
+	RequestID string
+	Method    string
+	URI       string
+	Host      string
+	IP        string
+	UserAgent string
+	Status    int
+	Latency   time.Duration
+}
+
+// MarshalZerologObject writes the entry as structured fields for the json format
+func (e AccessLogEntry) MarshalZerologObject(event *zerolog.Event) {
+	if e.RequestID != "" {
+		event.Str("request_id", e.RequestID)
+	}
+
+	event.
+		Dur("latency", e.Latency).
+		Int("status", e.Status).
+		Str("method", e.Method).
+		Str("uri", e.URI).
+		Str("host", e.Host).
+		Str("ip", e.IP).
+		Str("user_agent", e.UserAgent)
+}
+
+// Logfmt renders the entry as a logfmt line (key=value pairs, quoted when needed)
+func (e AccessLogEntry) Logfmt() string {
+	pairs := [][2]string{
+		{"time", e.Time.UTC().Format(time.RFC3339)},
+		{"level", accessLogLevel(e.Status).String()},
+		{"request_id", e.RequestID},
+		{"method", e.Method},
+		{"uri", e.URI},
+		{"status", strconv.Itoa(e.Status)},
+		{"latency_ms", strconv.FormatFloat(float64(e.Latency)/float64(time.Millisecond), 'f', -1, 64)},
+		{"host", e.Host},
+		{"ip", e.IP},
+		{"user_agent", e.UserAgent},
+		{"msg", "API"},
+	}
+
+	var b strings.Builder
+	for i, pair := range pairs {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(pair[0])
+		b.WriteByte('=')
+		b.WriteString(logfmtValue(pair[1]))
+	}
+	return b.String()
+}
+
+// CEF renders the entry in ArcSight Common Event Format for SIEM ingestion
+func (e AccessLogEntry) CEF() string {
+	header := strings.Join([]string{
+		"CEF:0",
+		cefHeaderValue(cefVendor),
+		cefHeaderValue(e.Service),
+		cefDeviceVersion,
+		"http_request",
+		"API request",
+		strconv.Itoa(cefSeverity(e.Status)),
+	}, "|")
+
+	extension := [][2]string{
+		{"rt", strconv.FormatInt(e.Time.UnixMilli(), 10)},
+		{"requestMethod", e.Method},
+		{"request", e.URI},
+		{"dhost", e.Host},
+		{"src", e.IP},
+		{"outcome", strconv.Itoa(e.Status)},
+		{"requestClientApplication", e.UserAgent},
+		{"cs1Label", "requestId"},
+		{"cs1", e.RequestID},
+		{"cn1Label", "latencyMs"},
+		{"cn1", strconv.FormatInt(e.Latency.Milliseconds(), 10)},
+	}
+
+	var b strings.Builder
+	b.WriteString(header)
+	b.WriteByte('|')
+	for i, pair := range extension {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(pair[0])
+		b.WriteByte('=')
+		b.WriteString(cefExtensionValue(pair[1]))
+	}
+	return b.String()
+}
+
+func accessLogLevel(status int) zerolog.Level {
+	switch {
+	case status >= 500:
+		return zerolog.ErrorLevel
+	case status >= 400:
+		return zerolog.WarnLevel
+	default:
+		return zerolog.InfoLevel
+	}
+}
+
+// cefSeverity maps the status to CEF's 0-10 scale: low, medium, high
+func cefSeverity(status int) int {
+	switch {
+	case status >= 500:
+		return 8
+	case status >= 400:
+		return 5
+	default:
+		return 3
+	}
+}
+
+func logfmtValue(value string) string {
+	if value == "" {
+		return `""`
+	}
+	if strings.ContainsAny(value, " =\"\\") || strings.IndexFunc(value, func(r rune) bool { return r < 0x20 }) >= 0 {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+func cefHeaderValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ").Replace(value)
+}
+
+func cefExtensionValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`).Replace(value)
+}
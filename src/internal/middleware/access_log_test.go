@@ -0,0 +1,101 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleAccessLogEntry() middleware.AccessLogEntry {
+	return middleware.AccessLogEntry{
+		Time:      time.Date(2024, time.March, 10, 12, 30, 45, 0, time.UTC),
+		Service:   "Fortress_API",
+		RequestID: "req-123",
+		Method:    "GET",
+		URI:       "/api/v1/todos?search=a=b",
+		Host:      "api.example.com",
+		IP:        "203.0.113.7",
+		UserAgent: "curl/8.4.0 (x86_64)",
+		Status:    404,
+		Latency:   12500 * time.Microsecond,
+	}
+}
+
+func TestAccessLogEntry_Formats(t *testing.T) {
+	entry := sampleAccessLogEntry()
+
+	t.Run("json", func(t *testing.T) {
+		var logs bytes.Buffer
+		logger := zerolog.New(&logs)
+		observability := config.DefaultObservabilityConfig()
+		observability.Logging.AccessFormat = middleware.AccessFormatJSON
+		global := middleware.NewGlobalMiddlewares(&app.Server{Config: &config.Config{Observability: observability}})
+
+		e := echo.New()
+		e.HTTPErrorHandler = global.GlobalErrorHandler
+		e.Use(global.RequestLogger())
+		e.GET("/api/v1/todos", func(c echo.Context) error {
+			c.Set(middleware.LoggerKey, &logger)
+			c.Set(middleware.RequestIDKey, entry.RequestID)
+			return c.NoContent(entry.Status)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, entry.URI, nil)
+		req.Host = entry.Host
+		req.RemoteAddr = entry.IP + ":52100"
+		req.Header.Set("User-Agent", entry.UserAgent)
+		e.ServeHTTP(httptest.NewRecorder(), req)
+
+		var logged map[string]interface{}
+		require.NoError(t, json.Unmarshal(logs.Bytes(), &logged))
+		// Latency is measured, so only its presence is checked
+		assert.Contains(t, logged, "latency")
+		delete(logged, "latency")
+		assert.Equal(t, map[string]interface{}{
+			"level":      "warn",
+			"request_id": "req-123",
+			"status":     float64(404),
+			"method":     "GET",
+			"uri":        "/api/v1/todos?search=a=b",
+			"host":       "api.example.com",
+			"ip":         "203.0.113.7",
+			"user_agent": "curl/8.4.0 (x86_64)",
+			"message":    "API",
+		}, logged)
+	})
+
+	t.Run("logfmt", func(t *testing.T) {
+		assert.Equal(t,
+			`time=2024-03-10T12:30:45Z level=warn request_id=req-123 method=GET uri="/api/v1/todos?search=a=b" `+
+				`status=404 latency_ms=12.5 host=api.example.com ip=203.0.113.7 user_agent="curl/8.4.0 (x86_64)" msg=API`,
+			entry.Logfmt())
+	})
+
+	t.Run("cef", func(t *testing.T) {
+		assert.Equal(t,
+			`CEF:0|Fortress|Fortress_API|1.0|http_request|API request|5|`+
+				`rt=1710073845000 requestMethod=GET request=/api/v1/todos?search\=a\=b dhost=api.example.com `+
+				`src=203.0.113.7 outcome=404 requestClientApplication=curl/8.4.0 (x86_64) `+
+				`cs1Label=requestId cs1=req-123 cn1Label=latencyMs cn1=12`,
+			entry.CEF())
+	})
+
+	t.Run("cef escapes header separators", func(t *testing.T) {
+		entry := sampleAccessLogEntry()
+		entry.Service = "Fortress|API"
+		entry.Status = 503
+
+		assert.Contains(t, entry.CEF(), `CEF:0|Fortress|Fortress\|API|1.0|http_request|API request|8|`)
+	})
+}
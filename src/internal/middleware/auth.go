@@ -1,12 +1,14 @@
 package middleware
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"time"
 
 	"github.com/clerk/clerk-sdk-go/v2"
 	clerkhttp "github.com/clerk/clerk-sdk-go/v2/http"
+	"github.com/clerk/clerk-sdk-go/v2/jwks"
 	"github.com/labstack/echo/v4"
 	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
 	"github.com/Harmeet10000/Fortress_API/src/internal/app"
@@ -14,17 +16,37 @@ import (
 
 type AuthMiddleware struct {
 	server *app.Server
+	jwks   *JWKSCache
 }
 
 func NewAuthMiddleware(s *app.Server) *AuthMiddleware {
+	// The backend is looked up per fetch so it picks up the one configured by the auth service
+	fetch := func(ctx context.Context) (*clerk.JSONWebKeySet, error) {
+		client := &jwks.Client{Backend: clerk.GetBackend()}
+		return client.Get(ctx, &jwks.GetParams{})
+	}
+
 	return &AuthMiddleware{
 		server: s,
+		jwks:   NewJWKSCache(fetch, s.Config.Auth.JWKSRefreshInterval, s.Logger),
 	}
 }
 
+// StartJWKS pre-warms the Clerk JWKS cache so the first authenticated request does not
+// pay for the fetch, then keeps it refreshed in the background
+func (auth *AuthMiddleware) StartJWKS(ctx context.Context) {
+	auth.jwks.Warm(ctx)
+	auth.jwks.Start()
+}
+
+func (auth *AuthMiddleware) StopJWKS() {
+	auth.jwks.Stop()
+}
+
 func (auth *AuthMiddleware) RequireAuth(next echo.HandlerFunc) echo.HandlerFunc {
 	return echo.WrapMiddleware(
 		clerkhttp.WithHeaderAuthorization(
+			clerkhttp.JWKSClient(auth.jwks.Client()),
 			clerkhttp.AuthorizationFailureHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				start := time.Now()
 
@@ -72,3 +94,26 @@ func (auth *AuthMiddleware) RequireAuth(next echo.HandlerFunc) echo.HandlerFunc
 		return next(c)
 	})
 }
+
+// RequireRole allows the request only if the authenticated user holds one of the given
+// organization roles (e.g. "org:admin"). It must run after RequireAuth.
+func (auth *AuthMiddleware) RequireRole(roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			role, _ := c.Get(UserRoleKey).(string)
+			for _, allowed := range roles {
+				if role == allowed {
+					return next(c)
+				}
+			}
+
+			auth.server.Logger.Warn().
+				Str("function", "RequireRole").
+				Str("user_id", GetUserID(c)).
+				Str("user_role", role).
+				Str("request_id", GetCorrelationID(c)).
+				Msg("user lacks the required role")
+			return errs.NewForbiddenError("Forbidden", false)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderRequestBudget = "X-Request-Budget-Ms"
+
+	// DefaultMaxRequestBudget caps client budgets when server.max_request_budget is unset
+	DefaultMaxRequestBudget = 30 * time.Second
+
+	invalidRequestBudgetCode = "INVALID_REQUEST_BUDGET"
+)
+
+// RequestBudget sets the request context deadline to the smaller of the server's request
+// timeout and the budget the client sends in X-Request-Budget-Ms. A client that retries
+// can pass what is left of its overall budget so the server gives up when the client
+// would. Budgets above server.max_request_budget are capped to it; malformed or
+// non-positive budgets are answered with 400.
+func (global *GlobalMiddlewares) RequestBudget() echo.MiddlewareFunc {
+	timeout := global.server.Config.Server.RequestTimeout
+	maxBudget := global.server.Config.Server.MaxRequestBudget
+	if maxBudget <= 0 {
+		maxBudget = DefaultMaxRequestBudget
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			deadline := timeout
+
+			if header := strings.TrimSpace(c.Request().Header.Get(HeaderRequestBudget)); header != "" {
+				ms, err := strconv.ParseInt(header, 10, 64)
+				if err != nil || ms <= 0 {
+					code := invalidRequestBudgetCode
+					return errs.NewBadRequestError(HeaderRequestBudget+" must be a positive number of milliseconds", false, &code, nil, nil)
+				}
+
+				budget := maxBudget
+				if ms < maxBudget.Milliseconds() {
+					budget = time.Duration(ms) * time.Millisecond
+				}
+				if deadline <= 0 || budget < deadline {
+					deadline = budget
+				}
+			}
+
+			if deadline <= 0 {
+				return next(c)
+			}
+
+			ctx, cancel := context.WithTimeout(c.Request().Context(), deadline)
+			defer cancel()
+
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
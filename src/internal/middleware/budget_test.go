@@ -0,0 +1,100 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobalMiddlewares_RequestBudget(t *testing.T) {
+	// serve returns how long the handler's context had left, or -1 when it had no deadline
+	serve := func(t *testing.T, server config.ServerConfig, budget string) (*httptest.ResponseRecorder, time.Duration) {
+		t.Helper()
+
+		logger := zerolog.Nop()
+		global := middleware.NewGlobalMiddlewares(&app.Server{Logger: &logger, Config: &config.Config{Server: server}})
+
+		remaining := time.Duration(-1)
+		e := echo.New()
+		e.HTTPErrorHandler = global.GlobalErrorHandler
+		e.Use(global.RequestBudget())
+		e.GET("/api/v1/todos", func(c echo.Context) error {
+			if deadline, ok := c.Request().Context().Deadline(); ok {
+				remaining = time.Until(deadline)
+			}
+			return c.NoContent(http.StatusNoContent)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/todos", nil)
+		if budget != "" {
+			req.Header.Set(middleware.HeaderRequestBudget, budget)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec, remaining
+	}
+
+	withTimeout := config.ServerConfig{RequestTimeout: 10 * time.Second, MaxRequestBudget: 20 * time.Second}
+
+	t.Run("server timeout applies without a budget", func(t *testing.T) {
+		rec, remaining := serve(t, withTimeout, "")
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.InDelta(t, 10*time.Second, remaining, float64(time.Second))
+	})
+
+	t.Run("no deadline without a server timeout or budget", func(t *testing.T) {
+		_, remaining := serve(t, config.ServerConfig{}, "")
+
+		assert.Equal(t, time.Duration(-1), remaining)
+	})
+
+	t.Run("small budget shortens the deadline", func(t *testing.T) {
+		rec, remaining := serve(t, withTimeout, "250")
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		require.Positive(t, remaining)
+		assert.LessOrEqual(t, remaining, 250*time.Millisecond)
+	})
+
+	t.Run("budget above the server timeout keeps the server timeout", func(t *testing.T) {
+		_, remaining := serve(t, withTimeout, "15000")
+
+		assert.InDelta(t, 10*time.Second, remaining, float64(time.Second))
+	})
+
+	t.Run("oversized budget is capped", func(t *testing.T) {
+		_, remaining := serve(t, config.ServerConfig{MaxRequestBudget: 2 * time.Second}, "3600000")
+
+		assert.InDelta(t, 2*time.Second, remaining, float64(time.Second))
+	})
+
+	t.Run("oversized budget is capped to the default maximum", func(t *testing.T) {
+		_, remaining := serve(t, config.ServerConfig{}, "99999999999999")
+
+		assert.InDelta(t, middleware.DefaultMaxRequestBudget, remaining, float64(time.Second))
+	})
+
+	for name, budget := range map[string]string{
+		"non-numeric": "soon",
+		"zero":        "0",
+		"negative":    "-5",
+		"fractional":  "1.5",
+	} {
+		t.Run(name+" budget is rejected", func(t *testing.T) {
+			rec, _ := serve(t, withTimeout, budget)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Contains(t, rec.Body.String(), "INVALID_REQUEST_BUDGET")
+		})
+	}
+}
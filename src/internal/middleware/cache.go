@@ -0,0 +1,284 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// CacheKeyPrefix namespaces every response cache entry in Redis
+	CacheKeyPrefix    = "http_cache"
+	CacheStatusHeader = "X-Cache"
+
+	// Upper bound for any single cache round trip so a slow Redis never stalls a request
+	cacheOperationTimeout = 200 * time.Millisecond
+
+	// Consecutive Redis failures that open the breaker, and how long it stays open
+	cacheBreakerThreshold = 3
+	cacheBreakerCooldown  = 10 * time.Second
+)
+
+type CacheMiddleware struct {
+	server  *app.Server
+	breaker *cacheBreaker
+}
+
+func NewCacheMiddleware(s *app.Server) *CacheMiddleware {
+	return &CacheMiddleware{
+		server:  s,
+		breaker: &cacheBreaker{threshold: cacheBreakerThreshold, cooldown: cacheBreakerCooldown},
+	}
+}
+
+// cacheBreaker stops the middleware from talking to Redis for a short cooldown after
+// repeated failures, so an outage degrades to uncached responses instead of slow ones
+type cacheBreaker struct {
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	threshold int
+	cooldown  time.Duration
+}
+
+func (b *cacheBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return !time.Now().Before(b.openUntil)
+}
+
+func (b *cacheBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+}
+
+// failure records a Redis error and reports whether it tripped the breaker
+func (b *cacheBreaker) failure() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.failures < b.threshold {
+		return false
+	}
+
+	b.failures = 0
+	b.openUntil = time.Now().Add(b.cooldown)
+	return true
+}
+
+// cachedResponse is the serialized form of a full response stored in Redis
+type cachedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+}
+
+// CacheGET caches successful GET responses (status, headers and body) in Redis for ttl.
+// Entries are keyed on method, path, query and the authenticated user so users never
+// see each other's data. Register it after RequireAuth so the user ID is available.
+func (cm *CacheMiddleware) CacheGET(ttl time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.Request().Method != http.MethodGet || cm.server.Redis == nil {
+				return next(c)
+			}
+
+			// Redis is known to be unhealthy, serve straight from the handler
+			if !cm.breaker.allow() {
+				c.Response().Header().Set(CacheStatusHeader, "BYPASS")
+				return next(c)
+			}
+
+			logger := GetLogger(c)
+			key := cacheKey(c)
+
+			ctx, cancel := cm.operationContext(c)
+			raw, err := cm.server.Redis.Get(ctx, key).Bytes()
+			cancel()
+
+			switch {
+			case err == nil:
+				cm.breaker.success()
+				var cached cachedResponse
+				if err := json.Unmarshal(raw, &cached); err == nil {
+					return cm.writeCached(c, &cached)
+				}
+				logger.Warn().Str("cache_key", key).Msg("discarding unreadable cache entry")
+			case errors.Is(err, redis.Nil):
+				cm.breaker.success()
+			default:
+				cm.recordFailure(c, err, "cache lookup failed, serving from handler")
+				c.Response().Header().Set(CacheStatusHeader, "BYPASS")
+				return next(c)
+			}
+
+			// Capture the response while it is written so it can be stored afterwards
+			recorder := &cacheRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+			c.Response().Header().Set(CacheStatusHeader, "MISS")
+
+			if err := next(c); err != nil {
+				return err
+			}
+
+			if c.Response().Status != http.StatusOK {
+				return nil
+			}
+
+			entry, err := json.Marshal(cachedResponse{
+				Status: c.Response().Status,
+				Header: cacheableHeader(c.Response().Header(), requestIDHeaderName(cm.server.Config)),
+				Body:   recorder.body.Bytes(),
+			})
+			if err != nil {
+				logger.Warn().Err(err).Str("cache_key", key).Msg("failed to serialize response for cache")
+				return nil
+			}
+
+			ctx, cancel = cm.operationContext(c)
+			defer cancel()
+
+			if err := cm.server.Redis.Set(ctx, key, entry, ttl).Err(); err != nil {
+				cm.recordFailure(c, err, "failed to store response in cache")
+			}
+
+			return nil
+		}
+	}
+}
+
+// InvalidateCache busts the current user's cached GET responses under the given path
+// prefixes after a successful mutation (any non-GET/HEAD request that did not fail).
+func (cm *CacheMiddleware) InvalidateCache(pathPrefixes ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			method := c.Request().Method
+			if method == http.MethodGet || method == http.MethodHead || cm.server.Redis == nil {
+				return next(c)
+			}
+
+			if err := next(c); err != nil {
+				return err
+			}
+
+			if c.Response().Status >= http.StatusBadRequest {
+				return nil
+			}
+
+			// Invalidation is attempted even while the breaker is open: skipping it could
+			// leave stale entries behind once Redis recovers
+			userKey := cacheUserKey(c)
+
+			for _, prefix := range pathPrefixes {
+				pattern := EscapeGlob(userKey) + ":" + http.MethodGet + ":" + EscapeGlob(prefix) + "*"
+				if err := cm.deleteMatching(c, pattern); err != nil {
+					cm.recordFailure(c, err, "failed to invalidate cached responses")
+				}
+			}
+
+			return nil
+		}
+	}
+}
+
+func (cm *CacheMiddleware) deleteMatching(c echo.Context, pattern string) error {
+	ctx, cancel := cm.operationContext(c)
+	defer cancel()
+
+	var cursor uint64
+	for {
+		keys, next, err := cm.server.Redis.Scan(ctx, cursor, pattern, 100).Result()
+		if err != nil {
+			return err
+		}
+
+		if len(keys) > 0 {
+			if err := cm.server.Redis.Del(ctx, keys...).Err(); err != nil {
+				return err
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
+
+func (cm *CacheMiddleware) recordFailure(c echo.Context, err error, msg string) {
+	logger := GetLogger(c)
+	logger.Warn().Err(err).Str("path", c.Request().URL.Path).Msg(msg)
+
+	if cm.breaker.failure() {
+		logger.Warn().
+			Dur("cooldown", cm.breaker.cooldown).
+			Msg("cache circuit breaker opened, bypassing redis")
+	}
+}
+
+func (cm *CacheMiddleware) operationContext(c echo.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(c.Request().Context(), cacheOperationTimeout)
+}
+
+func (cm *CacheMiddleware) writeCached(c echo.Context, cached *cachedResponse) error {
+	header := c.Response().Header()
+	for name, values := range cached.Header {
+		header[name] = values
+	}
+	header.Set(CacheStatusHeader, "HIT")
+
+	c.Response().WriteHeader(cached.Status)
+	_, err := c.Response().Write(cached.Body)
+	return err
+}
+
+func cacheUserKey(c echo.Context) string {
+	userID := GetUserID(c)
+	if userID == "" {
+		userID = "anonymous"
+	}
+	return CacheKeyPrefix + ":" + userID
+}
+
+func cacheKey(c echo.Context) string {
+	req := c.Request()
+	// Encode sorts the parameters so equivalent queries share an entry
+	return cacheUserKey(c) + ":" + req.Method + ":" + req.URL.Path + "?" + req.URL.Query().Encode()
+}
+
+// cacheableHeader drops headers that are specific to a single response
+func cacheableHeader(h http.Header, requestIDHeader string) http.Header {
+	cloned := h.Clone()
+	cloned.Del(CacheStatusHeader)
+	cloned.Del(requestIDHeader)
+	cloned.Del("Set-Cookie")
+	return cloned
+}
+
+// EscapeGlob escapes Redis glob metacharacters so a prefix is matched literally
+func EscapeGlob(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+	return replacer.Replace(s)
+}
+
+// cacheRecorder tees the response body into a buffer while writing it to the client
+type cacheRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *cacheRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,159 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/Harmeet10000/Fortress_API/tests/redisfake"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type cacheTestServer struct {
+	echo  *echo.Echo
+	calls int
+}
+
+func newCacheTestServer(s *app.Server, ttl time.Duration) *cacheTestServer {
+	ts := &cacheTestServer{echo: echo.New()}
+	cache := middleware.NewCacheMiddleware(s)
+
+	withUser := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(middleware.UserIDKey, c.Request().Header.Get("X-Test-User"))
+			return next(c)
+		}
+	}
+
+	categories := ts.echo.Group("/api/v1/categories", withUser, cache.InvalidateCache("/api/v1/categories"))
+	categories.GET("", func(c echo.Context) error {
+		ts.calls++
+		c.Response().Header().Set("X-Handler-Call", strconv.Itoa(ts.calls))
+		return c.JSON(http.StatusOK, map[string]int{"call": ts.calls})
+	}, cache.CacheGET(ttl))
+	categories.POST("", func(c echo.Context) error {
+		return c.NoContent(http.StatusCreated)
+	})
+
+	return ts
+}
+
+func (ts *cacheTestServer) do(method, target, user string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	req.Header.Set("X-Test-User", user)
+	rec := httptest.NewRecorder()
+	ts.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCacheGET(t *testing.T) {
+	t.Run("serves a cached response within the TTL", func(t *testing.T) {
+		client, _ := redisfake.NewClient()
+		ts := newCacheTestServer(&app.Server{Redis: client}, time.Minute)
+
+		first := ts.do(http.MethodGet, "/api/v1/categories?page=1", "user_1")
+		second := ts.do(http.MethodGet, "/api/v1/categories?page=1", "user_1")
+
+		assert.Equal(t, 1, ts.calls)
+		assert.Equal(t, "MISS", first.Header().Get(middleware.CacheStatusHeader))
+		assert.Equal(t, "HIT", second.Header().Get(middleware.CacheStatusHeader))
+		assert.Equal(t, http.StatusOK, second.Code)
+		assert.Equal(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, "1", second.Header().Get("X-Handler-Call"))
+		assert.Equal(t, echo.MIMEApplicationJSON, second.Header().Get(echo.HeaderContentType))
+	})
+
+	t.Run("misses after the TTL expires", func(t *testing.T) {
+		client, _ := redisfake.NewClient()
+		ts := newCacheTestServer(&app.Server{Redis: client}, 50*time.Millisecond)
+
+		ts.do(http.MethodGet, "/api/v1/categories", "user_1")
+		time.Sleep(100 * time.Millisecond)
+		rec := ts.do(http.MethodGet, "/api/v1/categories", "user_1")
+
+		assert.Equal(t, 2, ts.calls)
+		assert.Equal(t, "MISS", rec.Header().Get(middleware.CacheStatusHeader))
+		assert.JSONEq(t, `{"call":2}`, rec.Body.String())
+	})
+
+	t.Run("varies by user and query", func(t *testing.T) {
+		client, _ := redisfake.NewClient()
+		ts := newCacheTestServer(&app.Server{Redis: client}, time.Minute)
+
+		ts.do(http.MethodGet, "/api/v1/categories", "user_1")
+		other := ts.do(http.MethodGet, "/api/v1/categories", "user_2")
+		query := ts.do(http.MethodGet, "/api/v1/categories?page=2", "user_1")
+
+		assert.Equal(t, 3, ts.calls)
+		assert.Equal(t, "MISS", other.Header().Get(middleware.CacheStatusHeader))
+		assert.Equal(t, "MISS", query.Header().Get(middleware.CacheStatusHeader))
+	})
+
+	t.Run("mutation busts the user's cached responses", func(t *testing.T) {
+		client, _ := redisfake.NewClient()
+		ts := newCacheTestServer(&app.Server{Redis: client}, time.Minute)
+
+		ts.do(http.MethodGet, "/api/v1/categories", "user_1")
+		ts.do(http.MethodGet, "/api/v1/categories", "user_2")
+		assert.Equal(t, http.StatusCreated, ts.do(http.MethodPost, "/api/v1/categories", "user_1").Code)
+
+		refreshed := ts.do(http.MethodGet, "/api/v1/categories", "user_1")
+		untouched := ts.do(http.MethodGet, "/api/v1/categories", "user_2")
+
+		assert.Equal(t, 3, ts.calls)
+		assert.Equal(t, "MISS", refreshed.Header().Get(middleware.CacheStatusHeader))
+		assert.Equal(t, "HIT", untouched.Header().Get(middleware.CacheStatusHeader))
+	})
+}
+
+func TestCacheGET_RedisUnavailable(t *testing.T) {
+	t.Run("requests fall back to the handler", func(t *testing.T) {
+		client, fake := redisfake.NewClient()
+		fake.SetErr(errors.New("connection refused"))
+		ts := newCacheTestServer(&app.Server{Redis: client}, time.Minute)
+
+		first := ts.do(http.MethodGet, "/api/v1/categories", "user_1")
+		second := ts.do(http.MethodGet, "/api/v1/categories", "user_1")
+
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, http.StatusOK, second.Code)
+		assert.JSONEq(t, `{"call":2}`, second.Body.String())
+		assert.Equal(t, "BYPASS", second.Header().Get(middleware.CacheStatusHeader))
+		assert.Equal(t, 2, ts.calls)
+	})
+
+	t.Run("mutations still succeed when invalidation fails", func(t *testing.T) {
+		client, fake := redisfake.NewClient()
+		fake.SetErr(errors.New("connection refused"))
+		ts := newCacheTestServer(&app.Server{Redis: client}, time.Minute)
+
+		rec := ts.do(http.MethodPost, "/api/v1/categories", "user_1")
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("repeated failures trip the breaker and stop hitting redis", func(t *testing.T) {
+		client, fake := redisfake.NewClient()
+		fake.SetErr(errors.New("i/o timeout"))
+		ts := newCacheTestServer(&app.Server{Redis: client}, time.Minute)
+
+		for i := 0; i < 3; i++ {
+			ts.do(http.MethodGet, "/api/v1/categories", "user_1")
+		}
+		callsWhenTripped := fake.Calls()
+
+		for i := 0; i < 5; i++ {
+			rec := ts.do(http.MethodGet, "/api/v1/categories", "user_1")
+			assert.Equal(t, http.StatusOK, rec.Code)
+		}
+
+		assert.Equal(t, callsWhenTripped, fake.Calls())
+		assert.Equal(t, 8, ts.calls)
+	})
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+)
+
+const mimeEventStream = "text/event-stream"
+
+// Compress gzips responses for clients that accept it. Server-Sent Events are left
+// uncompressed so each event reaches the client as soon as it is flushed: requests that
+// ask for an event stream or hit a configured streaming path are skipped up front, and
+// a response that turns out to be text/event-stream bypasses the compressor when its
+// headers are written.
+func (global *GlobalMiddlewares) Compress() echo.MiddlewareFunc {
+	cfg := global.server.Config.Compression
+	if cfg == nil {
+		cfg = config.DefaultCompressionConfig()
+	}
+
+	streamingPaths := splitPathPrefixes(cfg.StreamingPaths)
+
+	gzip := echoMiddleware.GzipWithConfig(echoMiddleware.GzipConfig{
+		Level:     cfg.Level,
+		MinLength: cfg.MinLength,
+		Skipper: func(c echo.Context) bool {
+			return acceptsEventStream(c.Request()) || hasPathPrefix(c.Request().URL.Path, streamingPaths)
+		},
+	})
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			uncompressed := c.Response().Writer
+
+			return gzip(func(c echo.Context) error {
+				res := c.Response()
+				if res.Writer != uncompressed {
+					res.Writer = &eventStreamBypassWriter{ResponseWriter: res.Writer, uncompressed: uncompressed}
+				}
+				return next(c)
+			})(c)
+		}
+	}
+}
+
+// eventStreamBypassWriter sits between the handler and the gzip writer and switches to
+// the uncompressed writer when the response is an event stream
+type eventStreamBypassWriter struct {
+	http.ResponseWriter
+	uncompressed http.ResponseWriter
+	wroteHeader  bool
+}
+
+func (w *eventStreamBypassWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if isEventStream(w.Header().Get(echo.HeaderContentType)) {
+			w.ResponseWriter = w.uncompressed
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *eventStreamBypassWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *eventStreamBypassWriter) Flush() {
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+func (w *eventStreamBypassWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func isEventStream(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == mimeEventStream
+}
+
+func acceptsEventStream(req *http.Request) bool {
+	for _, mediaRange := range strings.Split(req.Header.Get(echo.HeaderAccept), ",") {
+		if isEventStream(strings.TrimSpace(mediaRange)) {
+			return true
+		}
+	}
+	return false
+}
+
+func splitPathPrefixes(value string) []string {
+	var prefixes []string
+	for _, prefix := range strings.Split(value, ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
+
+func hasPathPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if path == prefix || strings.HasPrefix(path, strings.TrimSuffix(prefix, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package middleware_test
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCompressServer(t *testing.T, release <-chan struct{}) *httptest.Server {
+	t.Helper()
+
+	logger := zerolog.Nop()
+	s := &app.Server{
+		Logger: &logger,
+		Config: &config.Config{
+			Compression: &config.CompressionConfig{Level: 5, StreamingPaths: "/api/v1/live"},
+		},
+	}
+	global := middleware.NewGlobalMiddlewares(s)
+
+	e := echo.New()
+	e.Use(global.Compress())
+
+	sse := func(c echo.Context) error {
+		res := c.Response()
+		res.Header().Set(echo.HeaderContentType, "text/event-stream")
+		res.Header().Set(echo.HeaderCacheControl, "no-cache")
+		res.WriteHeader(http.StatusOK)
+
+		for i := 1; i <= 2; i++ {
+			if _, err := fmt.Fprintf(res, "data: event %d\n\n", i); err != nil {
+				return err
+			}
+			res.Flush()
+
+			// Hold the stream open until the client has seen the event, so the test
+			// fails instead of passing on a buffered response
+			if i == 1 {
+				select {
+				case <-release:
+				case <-time.After(2 * time.Second):
+					return nil
+				}
+			}
+		}
+		return nil
+	}
+	e.GET("/events", sse)
+	e.GET("/api/v1/live/feed", func(c echo.Context) error {
+		return c.String(http.StatusOK, strings.Repeat("live ", 500))
+	})
+	e.GET("/report", func(c echo.Context) error {
+		return c.String(http.StatusOK, strings.Repeat("report ", 500))
+	})
+
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func getWithGzip(t *testing.T, url string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	// Setting the header explicitly stops the transport from decompressing for us
+	req.Header.Set(echo.HeaderAcceptEncoding, "gzip")
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = res.Body.Close() })
+	return res
+}
+
+func TestCompress(t *testing.T) {
+	t.Run("regular responses are gzipped", func(t *testing.T) {
+		server := newCompressServer(t, nil)
+
+		res := getWithGzip(t, server.URL+"/report")
+
+		assert.Equal(t, "gzip", res.Header.Get(echo.HeaderContentEncoding))
+		gz, err := gzip.NewReader(res.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(gz)
+		require.NoError(t, err)
+		assert.Equal(t, strings.Repeat("report ", 500), string(body))
+	})
+
+	t.Run("event streams are not gzipped and flush per event", func(t *testing.T) {
+		release := make(chan struct{})
+		server := newCompressServer(t, release)
+
+		res := getWithGzip(t, server.URL+"/events")
+
+		assert.Empty(t, res.Header.Get(echo.HeaderContentEncoding))
+		assert.Equal(t, "text/event-stream", res.Header.Get(echo.HeaderContentType))
+
+		reader := bufio.NewReader(res.Body)
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, "data: event 1\n", line, "the first event arrives while the stream is still open")
+
+		close(release)
+
+		rest, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, "\ndata: event 2\n\n", string(rest))
+	})
+
+	t.Run("configured streaming paths are not gzipped", func(t *testing.T) {
+		server := newCompressServer(t, nil)
+
+		res := getWithGzip(t, server.URL+"/api/v1/live/feed")
+
+		assert.Empty(t, res.Header.Get(echo.HeaderContentEncoding))
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, strings.Repeat("live ", 500), string(body))
+	})
+}
@@ -34,7 +34,7 @@ func (ce *ContextEnhancer) EnhanceContext() echo.MiddlewareFunc {
 			contextLogger := ce.server.Logger.With().
 				Str("request_id", requestID).
 				Str("method", c.Request().Method).
-				Str("path", c.Path()).
+				Str("path", GetRouteTemplate(c)).
 				Str("ip", c.RealIP()).
 				Logger()
 
@@ -87,6 +87,15 @@ func GetUserID(c echo.Context) string {
 	return ""
 }
 
+// GetRouteTemplate returns the matched route template (e.g. "/api/v1/todos/:id") rather
+// than the raw path, so it is safe to use as a low-cardinality label
+func GetRouteTemplate(c echo.Context) string {
+	if route := c.Path(); route != "" {
+		return route
+	}
+	return "unmatched"
+}
+
 func GetLogger(c echo.Context) *zerolog.Logger {
 	if logger, ok := c.Get(LoggerKey).(*zerolog.Logger); ok {
 		return logger
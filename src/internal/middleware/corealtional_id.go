@@ -1,6 +1,10 @@
 package middleware
 
 import (
+	"net/http"
+	"strings"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
@@ -10,22 +14,72 @@ const (
 	RequestIDKey    = "request_id"
 )
 
+// CorrelationIDConfig controls which headers carry the request ID
+type CorrelationIDConfig struct {
+	// Header is the primary request ID header. It is read first and always used to
+	// echo the ID back on the response. Defaults to X-Request-ID.
+	Header string
+	// CandidateHeaders are read in order when the primary header is absent, so IDs
+	// from ecosystems using e.g. X-Correlation-ID or Request-Id are preserved
+	CandidateHeaders []string
+}
+
 func CorrelationID() echo.MiddlewareFunc {
+	return CorrelationIDWithConfig(CorrelationIDConfig{})
+}
+
+// CorrelationID uses the request ID headers from the server config
+func (global *GlobalMiddlewares) CorrelationID() echo.MiddlewareFunc {
+	server := global.server.Config.Server
+
+	var candidates []string
+	for _, name := range strings.Split(server.RequestIDCandidateHeaders, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			candidates = append(candidates, name)
+		}
+	}
+
+	return CorrelationIDWithConfig(CorrelationIDConfig{
+		Header:           server.RequestIDHeader,
+		CandidateHeaders: candidates,
+	})
+}
+
+func CorrelationIDWithConfig(cfg CorrelationIDConfig) echo.MiddlewareFunc {
+	header := http.CanonicalHeaderKey(cfg.Header)
+	if header == "" {
+		header = RequestIDHeader
+	}
+	headers := append([]string{header}, cfg.CandidateHeaders...)
+
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			correlationID := c.Request().Header.Get(RequestIDHeader)
+			var correlationID string
+			for _, name := range headers {
+				if correlationID = strings.TrimSpace(c.Request().Header.Get(name)); correlationID != "" {
+					break
+				}
+			}
 			if correlationID == "" {
 				correlationID = uuid.New().String() // 4c90fc3f-39cc-4b04-af21-c83ee64aa67e
 			}
 
 			c.Set(RequestIDKey, correlationID)
-			c.Response().Header().Set(RequestIDHeader, correlationID)
+			c.Response().Header().Set(header, correlationID)
 
 			return next(c)
 		}
 	}
 }
 
+// requestIDHeaderName returns the header the request ID is echoed back on
+func requestIDHeaderName(cfg *config.Config) string {
+	if cfg != nil && cfg.Server.RequestIDHeader != "" {
+		return http.CanonicalHeaderKey(cfg.Server.RequestIDHeader)
+	}
+	return RequestIDHeader
+}
+
 func GetCorrelationID(c echo.Context) string {
 	if correlationID, ok := c.Get(RequestIDKey).(string); ok {
 		return correlationID
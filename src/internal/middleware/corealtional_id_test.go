@@ -0,0 +1,69 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveCorrelationID(requestHeaders map[string]string) (*httptest.ResponseRecorder, string) {
+	s := &app.Server{
+		Config: &config.Config{
+			Server: config.ServerConfig{
+				RequestIDHeader:           "Request-Id",
+				RequestIDCandidateHeaders: "X-Correlation-ID, X-Request-ID",
+			},
+		},
+	}
+	global := middleware.NewGlobalMiddlewares(s)
+
+	var seen string
+	e := echo.New()
+	e.Use(global.CorrelationID())
+	e.GET("/api/v1/todos", func(c echo.Context) error {
+		seen = middleware.GetCorrelationID(c)
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/todos", nil)
+	for name, value := range requestHeaders {
+		req.Header.Set(name, value)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec, seen
+}
+
+func TestGlobalMiddlewares_CorrelationID(t *testing.T) {
+	t.Run("picks up a candidate header", func(t *testing.T) {
+		rec, seen := serveCorrelationID(map[string]string{"X-Correlation-ID": "corr-123"})
+
+		assert.Equal(t, "corr-123", seen)
+		assert.Equal(t, "corr-123", rec.Header().Get("Request-Id"))
+		assert.Empty(t, rec.Header().Get("X-Correlation-ID"))
+	})
+
+	t.Run("primary header wins over candidates", func(t *testing.T) {
+		rec, seen := serveCorrelationID(map[string]string{
+			"Request-Id":   "primary-1",
+			"X-Request-ID": "candidate-2",
+		})
+
+		assert.Equal(t, "primary-1", seen)
+		assert.Equal(t, "primary-1", rec.Header().Get("Request-Id"))
+	})
+
+	t.Run("generates an ID on the configured header", func(t *testing.T) {
+		rec, seen := serveCorrelationID(nil)
+
+		assert.NotEmpty(t, seen)
+		assert.Equal(t, seen, rec.Header().Get("Request-Id"))
+		assert.Empty(t, rec.Header().Get(middleware.RequestIDHeader))
+	})
+}
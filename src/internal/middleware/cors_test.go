@@ -0,0 +1,65 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveCORS(allowedOrigins string, allowAnyOrigin bool, origin string) *httptest.ResponseRecorder {
+	logger := zerolog.Nop()
+	s := &app.Server{
+		Logger: &logger,
+		Config: &config.Config{
+			Server: config.ServerConfig{
+				CORSAllowedOrigins: allowedOrigins,
+				CORSAllowAnyOrigin: allowAnyOrigin,
+			},
+		},
+	}
+	global := middleware.NewGlobalMiddlewares(s)
+
+	e := echo.New()
+	e.Use(global.CORS())
+	e.GET("/api/v1/todos", func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/todos", nil)
+	req.Header.Set(echo.HeaderOrigin, origin)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestGlobalMiddlewares_CORS(t *testing.T) {
+	t.Run("allow any origin reflects the origin with credentials", func(t *testing.T) {
+		for _, origin := range []string{"https://app.example.com", "https://elsewhere.test"} {
+			rec := serveCORS("*", true, origin)
+
+			assert.Equal(t, origin, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+			assert.Equal(t, "true", rec.Header().Get(echo.HeaderAccessControlAllowCredentials))
+			assert.Contains(t, rec.Header().Values(echo.HeaderVary), echo.HeaderOrigin)
+		}
+	})
+
+	t.Run("wildcard is literal without the flag", func(t *testing.T) {
+		rec := serveCORS("*", false, "https://elsewhere.test")
+
+		assert.Equal(t, "*", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+		assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowCredentials))
+	})
+
+	t.Run("flag has no effect on an explicit allowlist", func(t *testing.T) {
+		rec := serveCORS("https://app.example.com", true, "https://elsewhere.test")
+
+		assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	})
+}
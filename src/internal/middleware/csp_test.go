@@ -0,0 +1,52 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSPBuilder(t *testing.T) {
+	t.Run("renders configured directives in order", func(t *testing.T) {
+		csp := middleware.NewCSPBuilderFromConfig(config.CSPConfig{
+			DefaultSrc: "'self'",
+			ScriptSrc:  "'self' https://www.googletagmanager.com",
+			StyleSrc:   "'self'  https://fonts.googleapis.com",
+			FontSrc:    "https://fonts.gstatic.com",
+			ConnectSrc: "'self' https://api.example.com",
+			ReportURI:  "https://example.com/csp-reports",
+		})
+
+		assert.Equal(t,
+			"default-src 'self'; "+
+				"script-src 'self' https://www.googletagmanager.com; "+
+				"style-src 'self' https://fonts.googleapis.com; "+
+				"font-src https://fonts.gstatic.com; "+
+				"connect-src 'self' https://api.example.com; "+
+				"report-uri https://example.com/csp-reports",
+			csp.String())
+		assert.Equal(t, echo.HeaderContentSecurityPolicy, csp.HeaderName())
+	})
+
+	t.Run("report-only uses the report-only header name", func(t *testing.T) {
+		csp := middleware.NewCSPBuilderFromConfig(config.CSPConfig{DefaultSrc: "'self'", ReportOnly: true})
+
+		assert.Equal(t, echo.HeaderContentSecurityPolicyReportOnly, csp.HeaderName())
+	})
+
+	t.Run("merges repeated directives without duplicates", func(t *testing.T) {
+		csp := middleware.NewCSPBuilder().
+			Directive("script-src", "'self'").
+			Directive("img-src", "data:").
+			Directive("script-src", "'self'", "cdn.example.com")
+
+		assert.Equal(t, "script-src 'self' cdn.example.com; img-src data:", csp.String())
+	})
+
+	t.Run("empty config renders no policy", func(t *testing.T) {
+		assert.Empty(t, middleware.NewCSPBuilderFromConfig(config.CSPConfig{}).String())
+	})
+}
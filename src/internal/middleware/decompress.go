@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// DefaultMaxDecompressionRatio is used when security.max_decompression_ratio is unset
+	DefaultMaxDecompressionRatio = 100
+
+	// Decompressed bytes read before the ratio is enforced, so small bodies that compress
+	// unusually well (e.g. a short run of whitespace) are not rejected
+	decompressionRatioGrace = 64 * 1024
+)
+
+// ErrDecompressionRatioExceeded is returned by the request body once it expands beyond
+// the configured ratio
+var ErrDecompressionRatioExceeded = errors.New("decompression ratio exceeded")
+
+// Decompress transparently inflates gzip-encoded request bodies. The stream is aborted
+// with 400 as soon as decompressed/compressed exceeds the configured ratio, so a tiny
+// upload cannot expand into gigabytes in memory (a decompression bomb).
+func (global *GlobalMiddlewares) Decompress() echo.MiddlewareFunc {
+	maxRatio := DefaultMaxDecompressionRatio
+	if security := global.server.Config.Security; security != nil && security.MaxDecompressionRatio > 0 {
+		maxRatio = security.MaxDecompressionRatio
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if !strings.EqualFold(strings.TrimSpace(req.Header.Get(echo.HeaderContentEncoding)), "gzip") || req.Body == nil {
+				return next(c)
+			}
+
+			compressed := &countingReader{r: req.Body}
+			gz, err := gzip.NewReader(compressed)
+			if err != nil {
+				return errs.NewBadRequestError("Request body is not valid gzip", false, nil, nil, nil)
+			}
+
+			body := &ratioLimitedReader{r: gz, compressed: compressed, maxRatio: int64(maxRatio)}
+			original := req.Body
+			defer original.Close()
+
+			req.Body = io.NopCloser(body)
+			req.Header.Del(echo.HeaderContentEncoding)
+			req.Header.Del(echo.HeaderContentLength)
+			req.ContentLength = -1
+
+			err = next(c)
+			if body.exceeded {
+				global.server.Logger.Warn().
+					Str("method", req.Method).
+					Str("path", req.URL.Path).
+					Str("ip", c.RealIP()).
+					Int64("compressed_bytes", compressed.n).
+					Int64("decompressed_bytes", body.n).
+					Int("max_ratio", maxRatio).
+					Msg("request body exceeded decompression ratio")
+				return errs.NewBadRequestError("Request body expands beyond the allowed compression ratio", false, nil, nil, nil)
+			}
+			return err
+		}
+	}
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+// ratioLimitedReader fails once the decompressed output outgrows the compressed input
+// by more than maxRatio. The gzip reader buffers ahead, so the compressed count is an
+// upper bound and the measured ratio errs on the permissive side.
+type ratioLimitedReader struct {
+	r          io.Reader
+	compressed *countingReader
+	maxRatio   int64
+	n          int64
+	exceeded   bool
+}
+
+func (rr *ratioLimitedReader) Read(p []byte) (int, error) {
+	if rr.exceeded {
+		return 0, ErrDecompressionRatioExceeded
+	}
+
+	n, err := rr.r.Read(p)
+	rr.n += int64(n)
+
+	if rr.n > decompressionRatioGrace && rr.n > rr.compressed.n*rr.maxRatio {
+		rr.exceeded = true
+		return n, ErrDecompressionRatioExceeded
+	}
+	return n, err
+}
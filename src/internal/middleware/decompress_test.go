@@ -0,0 +1,100 @@
+package middleware_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gzipBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func serveDecompress(t *testing.T, body []byte, encoding string) (*httptest.ResponseRecorder, []byte) {
+	t.Helper()
+
+	logger := zerolog.Nop()
+	s := &app.Server{
+		Logger: &logger,
+		Config: &config.Config{
+			Security: &config.SecurityConfig{MaxDecompressionRatio: 50},
+		},
+	}
+	global := middleware.NewGlobalMiddlewares(s)
+
+	var received []byte
+	e := echo.New()
+	e.HTTPErrorHandler = global.GlobalErrorHandler
+	e.Use(global.Decompress())
+	e.POST("/api/v1/todos/bulk", func(c echo.Context) error {
+		data, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		received = data
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/todos/bulk", bytes.NewReader(body))
+	if encoding != "" {
+		req.Header.Set(echo.HeaderContentEncoding, encoding)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec, received
+}
+
+func TestGlobalMiddlewares_Decompress(t *testing.T) {
+	t.Run("inflates gzip bodies", func(t *testing.T) {
+		payload := []byte(`[{"title":"write tests"},{"title":"ship it"}]`)
+
+		rec, received := serveDecompress(t, gzipBytes(t, payload), "gzip")
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, payload, received)
+	})
+
+	t.Run("rejects a high-ratio payload", func(t *testing.T) {
+		// 8 MiB of a single byte compresses to a few KiB, far beyond a 50:1 ratio
+		bomb := gzipBytes(t, bytes.Repeat([]byte{'a'}, 8<<20))
+
+		rec, received := serveDecompress(t, bomb, "gzip")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Nil(t, received)
+	})
+
+	t.Run("rejects an invalid gzip stream", func(t *testing.T) {
+		rec, _ := serveDecompress(t, []byte("not gzip"), "gzip")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("passes through uncompressed bodies", func(t *testing.T) {
+		payload := strings.Repeat("a", 256<<10)
+
+		rec, received := serveDecompress(t, []byte(payload), "")
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, payload, string(received))
+	})
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderDeprecation = "Deprecation"
+	HeaderSunset      = "Sunset"
+)
+
+// deprecatedRoute is one parsed deprecation.routes entry. An empty method matches every
+// method and a zero sunset sends no Sunset header.
+type deprecatedRoute struct {
+	method string
+	route  string
+	sunset time.Time
+}
+
+// Deprecation marks requests to the routes listed in deprecation.routes with
+// "Deprecation: true" and, when a date is configured, a Sunset header. Every such
+// request is logged, and counted in New Relic when it is enabled, so the clients still
+// calling a route can be found before it goes away.
+func (global *GlobalMiddlewares) Deprecation() echo.MiddlewareFunc {
+	var routes []deprecatedRoute
+	if deprecation := global.server.Config.Deprecation; deprecation != nil {
+		routes = global.parseDeprecatedRoutes(deprecation.Routes)
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if len(routes) == 0 {
+			return next
+		}
+
+		return func(c echo.Context) error {
+			route, ok := matchDeprecatedRoute(routes, c.Request().Method, c.Path())
+			if !ok {
+				return next(c)
+			}
+
+			header := c.Response().Header()
+			header.Set(HeaderDeprecation, "true")
+
+			event := global.server.Logger.Warn().
+				Str("method", c.Request().Method).
+				Str("route", route.route).
+				Str("user_agent", c.Request().UserAgent()).
+				Str("ip", c.RealIP())
+			if !route.sunset.IsZero() {
+				header.Set(HeaderSunset, route.sunset.Format(http.TimeFormat))
+				event = event.Time("sunset", route.sunset)
+			}
+			event.Msg("deprecated route used")
+
+			if global.server.LoggerService != nil {
+				if nrApp := global.server.LoggerService.GetApplication(); nrApp != nil {
+					nrApp.RecordCustomMetric("Custom/Deprecated/"+c.Request().Method+route.route, 1)
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func (global *GlobalMiddlewares) parseDeprecatedRoutes(value string) []deprecatedRoute {
+	var routes []deprecatedRoute
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		var route deprecatedRoute
+		target, date, hasDate := strings.Cut(entry, "=")
+		if hasDate {
+			sunset, err := time.Parse(time.DateOnly, strings.TrimSpace(date))
+			if err != nil {
+				global.server.Logger.Warn().Err(err).Str("entry", entry).Msg("ignoring deprecated route with an invalid sunset date")
+				continue
+			}
+			route.sunset = sunset
+		}
+
+		fields := strings.Fields(target)
+		switch len(fields) {
+		case 1:
+			route.route = fields[0]
+		case 2:
+			route.method, route.route = strings.ToUpper(fields[0]), fields[1]
+		default:
+			global.server.Logger.Warn().Str("entry", entry).Msg("ignoring invalid deprecated route")
+			continue
+		}
+
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+func matchDeprecatedRoute(routes []deprecatedRoute, method, path string) (deprecatedRoute, bool) {
+	for _, route := range routes {
+		if route.route == path && (route.method == "" || route.method == method) {
+			return route, true
+		}
+	}
+	return deprecatedRoute{}, false
+}
@@ -0,0 +1,98 @@
+package middleware_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlobalMiddlewares_Deprecation(t *testing.T) {
+	newEcho := func(routes string, logs *bytes.Buffer) *echo.Echo {
+		logger := zerolog.New(logs)
+		global := middleware.NewGlobalMiddlewares(&app.Server{
+			Logger: &logger,
+			Config: &config.Config{Deprecation: &config.DeprecationConfig{Routes: routes}},
+		})
+
+		e := echo.New()
+		e.HTTPErrorHandler = global.GlobalErrorHandler
+		e.Use(global.Deprecation())
+		ok := func(c echo.Context) error { return c.NoContent(http.StatusNoContent) }
+		e.GET("/api/v1/todos", ok)
+		e.GET("/api/v1/todos/export", ok)
+		e.POST("/api/v1/todos/export", ok)
+		e.GET("/api/v1/todos/:id/legacy", ok)
+		return e
+	}
+
+	serve := func(e *echo.Echo, method, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+		return rec
+	}
+
+	t.Run("deprecated route sends the headers and logs its use", func(t *testing.T) {
+		var logs bytes.Buffer
+		e := newEcho("GET /api/v1/todos/export=2027-01-31", &logs)
+
+		rec := serve(e, http.MethodGet, "/api/v1/todos/export")
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "true", rec.Header().Get(middleware.HeaderDeprecation))
+		assert.Equal(t, "Sun, 31 Jan 2027 00:00:00 GMT", rec.Header().Get(middleware.HeaderSunset))
+		assert.Contains(t, logs.String(), "deprecated route used")
+		assert.Contains(t, logs.String(), `"route":"/api/v1/todos/export"`)
+	})
+
+	t.Run("normal route is left alone", func(t *testing.T) {
+		var logs bytes.Buffer
+		e := newEcho("GET /api/v1/todos/export=2027-01-31", &logs)
+
+		rec := serve(e, http.MethodGet, "/api/v1/todos")
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Empty(t, rec.Header().Get(middleware.HeaderDeprecation))
+		assert.Empty(t, rec.Header().Get(middleware.HeaderSunset))
+		assert.Empty(t, logs.String())
+	})
+
+	t.Run("only the configured method is deprecated", func(t *testing.T) {
+		var logs bytes.Buffer
+		e := newEcho("GET /api/v1/todos/export=2027-01-31", &logs)
+
+		rec := serve(e, http.MethodPost, "/api/v1/todos/export")
+
+		assert.Empty(t, rec.Header().Get(middleware.HeaderDeprecation))
+	})
+
+	t.Run("routes match by template and without a method or date", func(t *testing.T) {
+		var logs bytes.Buffer
+		e := newEcho("/api/v1/todos/:id/legacy", &logs)
+
+		rec := serve(e, http.MethodGet, "/api/v1/todos/0b1c/legacy")
+
+		assert.Equal(t, "true", rec.Header().Get(middleware.HeaderDeprecation))
+		assert.Empty(t, rec.Header().Get(middleware.HeaderSunset))
+	})
+
+	t.Run("invalid entries are skipped with a warning", func(t *testing.T) {
+		var logs bytes.Buffer
+		e := newEcho("GET /api/v1/todos/export=next-year, GET /api/v1/todos=2027-01-31", &logs)
+
+		assert.Contains(t, logs.String(), "invalid sunset date")
+
+		rec := serve(e, http.MethodGet, "/api/v1/todos/export")
+		assert.Empty(t, rec.Header().Get(middleware.HeaderDeprecation))
+
+		rec = serve(e, http.MethodGet, "/api/v1/todos")
+		assert.Equal(t, "true", rec.Header().Get(middleware.HeaderDeprecation))
+	})
+}
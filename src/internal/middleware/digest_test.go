@@ -0,0 +1,145 @@
+package middleware_test
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const digestPayload = `{"title":"reconcile ledger"}`
+
+func contentMD5(body string) string {
+	sum := md5.Sum([]byte(body))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func sha256Digest(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return "SHA-256=" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func serveDigest(t *testing.T, path, body string, headers map[string]string) (*httptest.ResponseRecorder, *string) {
+	t.Helper()
+
+	logger := zerolog.Nop()
+	s := &app.Server{Logger: &logger, Config: &config.Config{}}
+	global := middleware.NewGlobalMiddlewares(s)
+
+	var received *string
+	e := echo.New()
+	e.HTTPErrorHandler = global.GlobalErrorHandler
+	handler := func(c echo.Context) error {
+		data, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return err
+		}
+		body := string(data)
+		received = &body
+		return c.NoContent(http.StatusNoContent)
+	}
+	e.POST("/api/v1/integrations/import", handler, global.VerifyBodyDigest(false))
+	e.POST("/api/v1/integrations/strict", handler, global.VerifyBodyDigest(true))
+	e.POST("/api/v1/todos", handler)
+
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec, received
+}
+
+func TestGlobalMiddlewares_VerifyBodyDigest(t *testing.T) {
+	const importPath = "/api/v1/integrations/import"
+
+	t.Run("matching digests pass the body through intact", func(t *testing.T) {
+		for name, headers := range map[string]map[string]string{
+			"Content-MD5": {middleware.HeaderContentMD5: contentMD5(digestPayload)},
+			"Digest":      {middleware.HeaderDigest: "unixsum=42, " + sha256Digest(digestPayload)},
+		} {
+			t.Run(name, func(t *testing.T) {
+				rec, received := serveDigest(t, importPath, digestPayload, headers)
+
+				assert.Equal(t, http.StatusNoContent, rec.Code)
+				require.NotNil(t, received)
+				assert.Equal(t, digestPayload, *received)
+			})
+		}
+	})
+
+	t.Run("a corrupted body is rejected before the handler runs", func(t *testing.T) {
+		corrupted := strings.Replace(digestPayload, "ledger", "1edger", 1)
+
+		for name, headers := range map[string]map[string]string{
+			"Content-MD5": {middleware.HeaderContentMD5: contentMD5(digestPayload)},
+			"Digest":      {middleware.HeaderDigest: sha256Digest(digestPayload)},
+		} {
+			t.Run(name, func(t *testing.T) {
+				rec, received := serveDigest(t, importPath, corrupted, headers)
+
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+				assert.Nil(t, received)
+
+				var envelope struct {
+					Error errs.HTTPError `json:"error"`
+				}
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &envelope))
+				assert.Equal(t, "BODY_DIGEST_MISMATCH", envelope.Error.Code)
+			})
+		}
+	})
+
+	t.Run("malformed or unsupported digests are rejected", func(t *testing.T) {
+		rec, _ := serveDigest(t, importPath, digestPayload, map[string]string{middleware.HeaderContentMD5: "not base64!"})
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		rec, _ = serveDigest(t, importPath, digestPayload, map[string]string{middleware.HeaderDigest: "crc32c=AAAAAA=="})
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("the header is optional unless the route requires it", func(t *testing.T) {
+		rec, received := serveDigest(t, importPath, digestPayload, nil)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.NotNil(t, received)
+
+		rec, received = serveDigest(t, "/api/v1/integrations/strict", digestPayload, nil)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Nil(t, received)
+	})
+
+	t.Run("routes that did not opt in ignore the headers", func(t *testing.T) {
+		rec, received := serveDigest(t, "/api/v1/todos", "tampered", map[string]string{
+			middleware.HeaderContentMD5: contentMD5(digestPayload),
+		})
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.NotNil(t, received)
+	})
+
+	t.Run("bodies over the buffering cap are rejected unread", func(t *testing.T) {
+		oversized := strings.Repeat("a", middleware.MaxDigestBodyBytes+1)
+
+		rec, received := serveDigest(t, importPath, oversized, map[string]string{
+			middleware.HeaderContentMD5: contentMD5(oversized),
+		})
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		assert.Nil(t, received)
+	})
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/labstack/echo/v4"
+)
+
+const ambiguousFramingCode = "AMBIGUOUS_REQUEST_FRAMING"
+
+// RejectAmbiguousFraming answers 400 for requests whose body length is ambiguous:
+// Content-Length together with Transfer-Encoding, or more than one Content-Length.
+// Front proxies and the backend can disagree on where such a body ends, which is how
+// requests are smuggled. net/http already normalizes most of these when it parses the
+// request itself; this guards requests relayed through anything that does not.
+func (global *GlobalMiddlewares) RejectAmbiguousFraming() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if reason := ambiguousFraming(req.Header.Values(echo.HeaderContentLength),
+				req.Header.Values("Transfer-Encoding"), req.TransferEncoding); reason != "" {
+				global.server.Logger.Warn().
+					Str("method", req.Method).
+					Str("path", req.URL.Path).
+					Str("ip", c.RealIP()).
+					Str("reason", reason).
+					Msg("rejected request with ambiguous framing")
+
+				code := ambiguousFramingCode
+				return errs.NewBadRequestError("Request has conflicting Content-Length and Transfer-Encoding headers", false, &code, nil, nil)
+			}
+			return next(c)
+		}
+	}
+}
+
+// ambiguousFraming returns why the framing headers conflict, or "" when they do not.
+// transferEncoding is what net/http parsed out of the header, if it did.
+func ambiguousFraming(contentLengths, transferEncodingHeader, transferEncoding []string) string {
+	lengths := 0
+	for _, value := range contentLengths {
+		lengths += len(strings.Split(value, ","))
+	}
+
+	switch {
+	case lengths > 1:
+		return "duplicate Content-Length"
+	case lengths == 1 && (len(transferEncodingHeader) > 0 || len(transferEncoding) > 0):
+		return "Content-Length with Transfer-Encoding"
+	}
+	return ""
+}
@@ -0,0 +1,82 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlobalMiddlewares_RejectAmbiguousFraming(t *testing.T) {
+	serve := func(t *testing.T, prepare func(req *http.Request)) (*httptest.ResponseRecorder, bool) {
+		t.Helper()
+
+		logger := zerolog.Nop()
+		global := middleware.NewGlobalMiddlewares(&app.Server{Logger: &logger, Config: &config.Config{}})
+
+		reached := false
+		e := echo.New()
+		e.HTTPErrorHandler = global.GlobalErrorHandler
+		e.Use(global.RejectAmbiguousFraming())
+		e.POST("/api/v1/todos", func(c echo.Context) error {
+			reached = true
+			return c.NoContent(http.StatusNoContent)
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/todos", strings.NewReader(`{"title":"a"}`))
+		prepare(req)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec, reached
+	}
+
+	for name, prepare := range map[string]func(req *http.Request){
+		"Content-Length with a chunked Transfer-Encoding header": func(req *http.Request) {
+			req.Header.Set("Content-Length", "13")
+			req.Header.Set("Transfer-Encoding", "chunked")
+		},
+		"Content-Length with a parsed chunked encoding": func(req *http.Request) {
+			req.Header.Set("Content-Length", "13")
+			req.TransferEncoding = []string{"chunked"}
+		},
+		"duplicate Content-Length headers": func(req *http.Request) {
+			req.Header.Add("Content-Length", "13")
+			req.Header.Add("Content-Length", "0")
+		},
+		"a comma-separated Content-Length": func(req *http.Request) {
+			req.Header.Set("Content-Length", "13, 13")
+		},
+	} {
+		t.Run("rejects "+name, func(t *testing.T) {
+			rec, reached := serve(t, prepare)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Contains(t, rec.Body.String(), "AMBIGUOUS_REQUEST_FRAMING")
+			assert.False(t, reached)
+		})
+	}
+
+	for name, prepare := range map[string]func(req *http.Request){
+		"a single Content-Length": func(req *http.Request) {
+			req.Header.Set("Content-Length", "13")
+		},
+		"chunked encoding alone": func(req *http.Request) {
+			req.TransferEncoding = []string{"chunked"}
+			req.ContentLength = -1
+		},
+	} {
+		t.Run("accepts "+name, func(t *testing.T) {
+			rec, reached := serve(t, prepare)
+
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+			assert.True(t, reached)
+		})
+	}
+}
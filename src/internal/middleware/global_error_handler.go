@@ -1,16 +1,23 @@
 package middleware
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
 	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	utils "github.com/Harmeet10000/Fortress_API/src/internal/helper"
+	"github.com/Harmeet10000/Fortress_API/src/internal/helper/breaker"
 	"github.com/Harmeet10000/Fortress_API/src/internal/sqlerr"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/pkg/errors"
-	"github.com/rs/zerolog"
 )
 
 type GlobalMiddlewares struct {
@@ -29,12 +36,34 @@ func (global *GlobalMiddlewares) CORS() echo.MiddlewareFunc {
 		origins[i] = strings.TrimSpace(origins[i])
 	}
 
+	// A literal "*" cannot be combined with credentials, so when any origin is explicitly
+	// allowed the request Origin is reflected instead, which browsers accept with credentials
+	if global.server.Config.Server.CORSAllowAnyOrigin && slices.Contains(origins, "*") {
+		global.server.Logger.Warn().Msg("CORS reflects any request origin and allows credentials")
+
+		return middleware.CORSWithConfig(middleware.CORSConfig{
+			AllowOriginFunc: func(origin string) (bool, error) {
+				return true, nil
+			},
+			AllowCredentials: true,
+		})
+	}
+
 	return middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins: origins,
 	})
 }
 
 func (global *GlobalMiddlewares) RequestLogger() echo.MiddlewareFunc {
+	format := AccessFormatJSON
+	service := "Fortress_API"
+	if observability := global.server.Config.Observability; observability != nil {
+		if observability.Logging.AccessFormat != "" {
+			format = observability.Logging.AccessFormat
+		}
+		service = observability.ServiceName
+	}
+
 	return middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
 		LogURI:     true,
 		LogStatus:  true,
@@ -44,48 +73,22 @@ func (global *GlobalMiddlewares) RequestLogger() echo.MiddlewareFunc {
 		LogMethod:  true,
 		LogURIPath: true,
 		LogValuesFunc: func(c echo.Context, v middleware.RequestLoggerValues) error {
-			statusCode := v.Status
-
 			// note that the status code is not set yet as it gets picked up by the global err handler
 			// see here: https://github.com/labstack/echo/issues/2310#issuecomment-1288196898
-			if v.Error != nil {
-				var httpErr *errs.HTTPError
-				var echoErr *echo.HTTPError
-				if errors.As(v.Error, &httpErr) {
-					statusCode = httpErr.Status
-				} else if errors.As(v.Error, &echoErr) {
-					statusCode = echoErr.Code
-				}
-			}
-
-			// Get enhanced logger from context
-			logger := GetLogger(c)
-
-			var e *zerolog.Event
-
-			switch {
-			case statusCode >= 500:
-				e = logger.Error().Err(v.Error)
-			case statusCode >= 400:
-				e = logger.Warn()
-			default:
-				e = logger.Info()
-			}
-
-			// Add request ID if available
-			if requestID := GetCorrelationID(c); requestID != "" {
-				e = e.Str("request_id", requestID)
-			}
+			statusCode := responseStatus(c, v.Error)
 
-			e.
-				Dur("latency", v.Latency).
-				Int("status", statusCode).
-				Str("method", v.Method).
-				Str("uri", v.URI).
-				Str("host", v.Host).
-				Str("ip", c.RealIP()).
-				Str("user_agent", c.Request().UserAgent()).
-				Msg("API")
+			writeAccessLog(format, GetLogger(c), os.Stdout, AccessLogEntry{
+				Time:      v.StartTime,
+				Service:   service,
+				RequestID: GetCorrelationID(c),
+				Method:    v.Method,
+				URI:       v.URI,
+				Host:      v.Host,
+				IP:        c.RealIP(),
+				UserAgent: c.Request().UserAgent(),
+				Status:    statusCode,
+				Latency:   v.Latency,
+			}, v.Error)
 
 			return nil
 		},
@@ -97,13 +100,63 @@ func (global *GlobalMiddlewares) Recover() echo.MiddlewareFunc {
 }
 
 func (global *GlobalMiddlewares) Secure() echo.MiddlewareFunc {
-	return middleware.Secure()
+	secureConfig := middleware.DefaultSecureConfig
+	var permissionsPolicy string
+
+	if security := global.server.Config.Security; security != nil {
+		csp := NewCSPBuilderFromConfig(security.CSP)
+		secureConfig.ContentSecurityPolicy = csp.String()
+		secureConfig.CSPReportOnly = security.CSP.ReportOnly
+
+		secureConfig.HSTSMaxAge = security.HSTS.MaxAge
+		secureConfig.HSTSExcludeSubdomains = !security.HSTS.IncludeSubDomains
+		secureConfig.HSTSPreloadEnabled = security.HSTS.Preload
+
+		permissionsPolicy = security.PermissionsPolicy
+	}
+
+	secure := middleware.SecureWithConfig(secureConfig)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		handler := secure(next)
+		return func(c echo.Context) error {
+			if permissionsPolicy != "" {
+				c.Response().Header().Set("Permissions-Policy", permissionsPolicy)
+			}
+			return handler(c)
+		}
+	}
 }
 
 func (global *GlobalMiddlewares) GlobalErrorHandler(err error, c echo.Context) {
 	// First try to handle database errors and convert them to appropriate HTTP errors
 	originalErr := err
 
+	// A client that went away or a request that ran out of time is not a server fault:
+	// answer with a dedicated status and keep it out of error-level logs and metrics
+	if status, code, message, ok := contextErrorStatus(err); ok {
+		GetLogger(c).Warn().
+			Err(originalErr).
+			Int("status", status).
+			Str("error_code", code).
+			Msg("request context ended before the response was written")
+
+		if !c.Response().Committed {
+			_ = writeError(c, &errs.HTTPError{
+				Code:    code,
+				Message: message,
+				Status:  status,
+			})
+		}
+		return
+	}
+
+	// A dependency behind an open circuit breaker degrades to 503 with Retry-After
+	var openErr *breaker.OpenError
+	if errors.As(err, &openErr) {
+		err = openErr.HTTPError()
+	}
+
 	// Try to handle known database errors
 	// Only do this for errors that haven't already been converted to HTTPError
 	var httpErr *errs.HTTPError
@@ -127,6 +180,7 @@ func (global *GlobalMiddlewares) GlobalErrorHandler(err error, c echo.Context) {
 	var message string
 	var fieldErrors []errs.FieldError
 	var action *errs.Action
+	var retryAfter int
 
 	switch {
 	case errors.As(err, &httpErr):
@@ -135,6 +189,7 @@ func (global *GlobalMiddlewares) GlobalErrorHandler(err error, c echo.Context) {
 		message = httpErr.Message
 		fieldErrors = httpErr.Errors
 		action = httpErr.Action
+		retryAfter = httpErr.RetryAfter
 
 	case errors.As(err, &echoErr):
 		status = echoErr.Code
@@ -162,14 +217,112 @@ func (global *GlobalMiddlewares) GlobalErrorHandler(err error, c echo.Context) {
 		Str("error_code", code).
 		Msg(message)
 
+	var debug string
+	if status >= http.StatusInternalServerError {
+		message, fieldErrors, debug = global.serverErrorDetails(status, message, fieldErrors, originalErr)
+	}
+
 	if !c.Response().Committed {
-		_ = c.JSON(status, errs.HTTPError{
-			Code:     code,
-			Message:  message,
-			Status:   status,
-			Override: httpErr != nil && httpErr.Override,
-			Errors:   fieldErrors,
-			Action:   action,
+		_ = writeError(c, &errs.HTTPError{
+			Code:       code,
+			Message:    message,
+			Status:     status,
+			Override:   httpErr != nil && httpErr.Override,
+			Errors:     fieldErrors,
+			Action:     action,
+			RetryAfter: retryAfter,
+			Debug:      debug,
 		})
 	}
 }
+
+// serverErrorDetails applies the configured error details mode to a 5xx response. Server
+// errors can describe internals such as queries or upstream responses, so production
+// only sends the status text while development adds the underlying error.
+func (global *GlobalMiddlewares) serverErrorDetails(
+	status int, message string, fieldErrors []errs.FieldError, err error,
+) (string, []errs.FieldError, string) {
+	mode := config.ErrorDetailsMessage
+	if global.server.Config != nil {
+		mode = global.server.Config.ErrorDetailsMode()
+	}
+
+	switch mode {
+	case config.ErrorDetailsFull:
+		return message, fieldErrors, err.Error()
+	case config.ErrorDetailsGeneric:
+		return http.StatusText(status), nil, ""
+	default:
+		return message, fieldErrors, ""
+	}
+}
+
+// contextErrorStatus maps context cancellation anywhere in the error chain to 499 when
+// the client closed the request and to 503 when the request deadline was exceeded
+func contextErrorStatus(err error) (int, string, string, bool) {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return errs.StatusClientClosedRequest, "CLIENT_CLOSED_REQUEST", "Client Closed Request", true
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusServiceUnavailable, errs.MakeUpperCaseWithUnderscores(
+			http.StatusText(http.StatusServiceUnavailable)), "Request timed out", true
+	default:
+		return 0, "", "", false
+	}
+}
+
+// writeError renders the error as RFC 7807 problem details for clients that ask for
+// application/problem+json, and as the standard APIResponse envelope otherwise
+func writeError(c echo.Context, httpErr *errs.HTTPError) error {
+	req := c.Request()
+
+	if httpErr.RetryAfter > 0 {
+		c.Response().Header().Set("Retry-After", strconv.Itoa(httpErr.RetryAfter))
+	}
+
+	if prefersProblemJSON(req.Header.Get(echo.HeaderAccept)) {
+		body, err := json.Marshal(httpErr.Problem(req.URL.Path))
+		if err != nil {
+			return err
+		}
+		return c.Blob(httpErr.Status, errs.MIMEApplicationProblemJSON, body)
+	}
+
+	resp := utils.NewError[any](httpErr.Status, httpErr.Message, httpErr)
+	return c.JSON(httpErr.Status, resp.WithRequestInfo(req, GetCorrelationID(c)))
+}
+
+// prefersProblemJSON reports whether the Accept header ranks application/problem+json
+// at least as high as plain application/json. Wildcards never select it, so clients
+// that don't ask for it keep receiving the envelope.
+func prefersProblemJSON(accept string) bool {
+	problemQ, jsonQ := 0.0, 0.0
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, q := parseMediaRange(mediaRange)
+		switch mediaType {
+		case errs.MIMEApplicationProblemJSON:
+			problemQ = max(problemQ, q)
+		case echo.MIMEApplicationJSON:
+			jsonQ = max(jsonQ, q)
+		}
+	}
+	return problemQ > 0 && problemQ >= jsonQ
+}
+
+// parseMediaRange splits one Accept entry into its media type and quality (default 1)
+func parseMediaRange(mediaRange string) (string, float64) {
+	params := strings.Split(mediaRange, ";")
+	mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+
+	q := 1.0
+	for _, param := range params[1:] {
+		name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok || strings.TrimSpace(name) != "q" {
+			continue
+		}
+		if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+			q = parsed
+		}
+	}
+	return mediaType, q
+}
@@ -0,0 +1,331 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/Harmeet10000/Fortress_API/src/internal/helper/breaker"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type createWidgetPayload struct {
+	Name string `json:"name" validate:"required"`
+}
+
+func (p *createWidgetPayload) Validate() error {
+	return nil
+}
+
+func serveValidationError(t *testing.T, accept string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	global := middleware.NewGlobalMiddlewares(&app.Server{})
+	validator := validation.NewValidator()
+
+	e := echo.New()
+	e.HTTPErrorHandler = global.GlobalErrorHandler
+	e.POST("/api/v1/widgets", func(c echo.Context) error {
+		return validator.BindAndValidate(c, &createWidgetPayload{})
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/widgets", strings.NewReader(`{}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if accept != "" {
+		req.Header.Set(echo.HeaderAccept, accept)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestGlobalMiddlewares_GlobalErrorHandler(t *testing.T) {
+	t.Run("problem+json when requested", func(t *testing.T) {
+		rec := serveValidationError(t, "application/problem+json, application/json;q=0.5")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, errs.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+
+		var problem errs.ProblemDetails
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+		assert.Equal(t, "about:blank", problem.Type)
+		assert.Equal(t, "Bad Request", problem.Title)
+		assert.Equal(t, http.StatusBadRequest, problem.Status)
+		assert.Equal(t, "/api/v1/widgets", problem.Instance)
+		assert.Equal(t, "BAD_REQUEST", problem.Code)
+		require.Len(t, problem.Errors, 1)
+		assert.Equal(t, "name", problem.Errors[0].Field)
+	})
+
+	for name, accept := range map[string]string{
+		"envelope by default":            "",
+		"envelope for application/json":  "application/json",
+		"envelope when json ranks first": "application/json, application/problem+json;q=0.8",
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec := serveValidationError(t, accept)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Equal(t, echo.MIMEApplicationJSON, rec.Header().Get(echo.HeaderContentType))
+
+			var envelope struct {
+				Success    bool           `json:"success"`
+				StatusCode int            `json:"statusCode"`
+				Message    string         `json:"message"`
+				Error      errs.HTTPError `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &envelope))
+			assert.False(t, envelope.Success)
+			assert.Equal(t, http.StatusBadRequest, envelope.StatusCode)
+			assert.Equal(t, "BAD_REQUEST", envelope.Error.Code)
+			require.Len(t, envelope.Error.Errors, 1)
+			assert.Equal(t, "name", envelope.Error.Errors[0].Field)
+		})
+	}
+}
+
+func TestGlobalMiddlewares_GlobalErrorHandler_ContextErrors(t *testing.T) {
+	serve := func(t *testing.T, ctx context.Context, handlerErr error) (*httptest.ResponseRecorder, string) {
+		t.Helper()
+
+		var logs bytes.Buffer
+		logger := zerolog.New(&logs)
+		global := middleware.NewGlobalMiddlewares(&app.Server{})
+
+		e := echo.New()
+		e.HTTPErrorHandler = global.GlobalErrorHandler
+		e.GET("/api/v1/todos", func(c echo.Context) error {
+			c.Set(middleware.LoggerKey, &logger)
+			return handlerErr
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/todos", nil).WithContext(ctx)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec, logs.String()
+	}
+
+	t.Run("client disconnect returns 499", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		rec, logs := serve(t, ctx, fmt.Errorf("failed to execute get todos query: %w", ctx.Err()))
+
+		assert.Equal(t, errs.StatusClientClosedRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":"CLIENT_CLOSED_REQUEST"`)
+		assert.Contains(t, logs, `"level":"warn"`)
+		assert.NotContains(t, logs, `"level":"error"`)
+	})
+
+	t.Run("deadline exceeded returns 503", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), -time.Second)
+		defer cancel()
+
+		rec, logs := serve(t, ctx, fmt.Errorf("failed to execute get todos query: %w", ctx.Err()))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":"SERVICE_UNAVAILABLE"`)
+		assert.NotContains(t, logs, `"level":"error"`)
+	})
+
+	t.Run("other errors are still internal", func(t *testing.T) {
+		rec, logs := serve(t, context.Background(), errors.New("boom"))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, logs, `"level":"error"`)
+	})
+}
+
+func TestGlobalMiddlewares_RequestLogger_ContextErrors(t *testing.T) {
+	logStatus := func(t *testing.T, handlerErr error) int {
+		t.Helper()
+
+		var logs bytes.Buffer
+		logger := zerolog.New(&logs)
+		global := middleware.NewGlobalMiddlewares(&app.Server{Config: &config.Config{}})
+
+		e := echo.New()
+		e.HTTPErrorHandler = global.GlobalErrorHandler
+		e.Use(global.RequestLogger())
+		e.GET("/api/v1/todos", func(c echo.Context) error {
+			c.Set(middleware.LoggerKey, &logger)
+			return handlerErr
+		})
+
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/todos", nil))
+
+		// The error handler logs too; the access log line is the one with message "API"
+		for _, line := range bytes.Split(bytes.TrimSpace(logs.Bytes()), []byte("\n")) {
+			var entry struct {
+				Message string `json:"message"`
+				Status  int    `json:"status"`
+			}
+			require.NoError(t, json.Unmarshal(line, &entry))
+			if entry.Message == "API" {
+				return entry.Status
+			}
+		}
+		t.Fatal("no access log line written")
+		return 0
+	}
+
+	assert.Equal(t, errs.StatusClientClosedRequest, logStatus(t, fmt.Errorf("query: %w", context.Canceled)))
+	assert.Equal(t, http.StatusServiceUnavailable, logStatus(t, fmt.Errorf("query: %w", context.DeadlineExceeded)))
+	assert.Equal(t, http.StatusInternalServerError, logStatus(t, errors.New("boom")))
+}
+
+func TestGlobalMiddlewares_GlobalErrorHandler_OpenBreaker(t *testing.T) {
+	serve := func(t *testing.T, accept string) *httptest.ResponseRecorder {
+		t.Helper()
+
+		logger := zerolog.Nop()
+		global := middleware.NewGlobalMiddlewares(&app.Server{Logger: &logger})
+
+		e := echo.New()
+		e.HTTPErrorHandler = global.GlobalErrorHandler
+		e.GET("/api/v1/me/email", func(c echo.Context) error {
+			err := &breaker.OpenError{Name: "Clerk", RetryAfter: 12300 * time.Millisecond}
+			return fmt.Errorf("failed to get user from Clerk: %w", err)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/me/email", nil)
+		if accept != "" {
+			req.Header.Set(echo.HeaderAccept, accept)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("envelope", func(t *testing.T) {
+		rec := serve(t, "")
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, "13", rec.Header().Get("Retry-After"))
+
+		var envelope struct {
+			Error errs.HTTPError `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &envelope))
+		assert.Equal(t, "DEPENDENCY_UNAVAILABLE", envelope.Error.Code)
+		assert.Equal(t, 13, envelope.Error.RetryAfter)
+		assert.Contains(t, envelope.Error.Message, "Clerk")
+	})
+
+	t.Run("problem+json", func(t *testing.T) {
+		rec := serve(t, errs.MIMEApplicationProblemJSON)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, "13", rec.Header().Get("Retry-After"))
+
+		var problem errs.ProblemDetails
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+		assert.Equal(t, 13, problem.RetryAfter)
+	})
+}
+
+func TestGlobalMiddlewares_GlobalErrorHandler_ServerErrorDetails(t *testing.T) {
+	serve := func(t *testing.T, cfg *config.Config, accept string) *httptest.ResponseRecorder {
+		t.Helper()
+
+		logger := zerolog.Nop()
+		global := middleware.NewGlobalMiddlewares(&app.Server{Logger: &logger, Config: cfg})
+
+		e := echo.New()
+		e.HTTPErrorHandler = global.GlobalErrorHandler
+		e.GET("/api/v1/todos", func(c echo.Context) error {
+			return echo.NewHTTPError(http.StatusBadGateway, "upstream 10.0.3.7:5432 refused the connection").
+				SetInternal(errors.New("dial tcp 10.0.3.7:5432: connect: connection refused"))
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/todos", nil)
+		if accept != "" {
+			req.Header.Set(echo.HeaderAccept, accept)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	decode := func(t *testing.T, rec *httptest.ResponseRecorder) errs.HTTPError {
+		t.Helper()
+
+		var envelope struct {
+			Error errs.HTTPError `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &envelope))
+		return envelope.Error
+	}
+
+	t.Run("production hides the underlying error", func(t *testing.T) {
+		rec := serve(t, &config.Config{Primary: config.PrimaryConfig{Env: "production"}}, "")
+
+		assert.Equal(t, http.StatusBadGateway, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "10.0.3.7")
+
+		httpErr := decode(t, rec)
+		assert.Equal(t, "BAD_GATEWAY", httpErr.Code)
+		assert.Equal(t, "Bad Gateway", httpErr.Message)
+		assert.Empty(t, httpErr.Debug)
+	})
+
+	t.Run("production hides it from problem details too", func(t *testing.T) {
+		rec := serve(t, &config.Config{Primary: config.PrimaryConfig{Env: "production"}}, errs.MIMEApplicationProblemJSON)
+
+		assert.Equal(t, http.StatusBadGateway, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "10.0.3.7")
+	})
+
+	t.Run("development shows the underlying error", func(t *testing.T) {
+		rec := serve(t, &config.Config{Primary: config.PrimaryConfig{Env: "development"}}, "")
+
+		httpErr := decode(t, rec)
+		assert.Equal(t, "upstream 10.0.3.7:5432 refused the connection", httpErr.Message)
+		assert.Contains(t, httpErr.Debug, "connect: connection refused")
+	})
+
+	t.Run("configured mode overrides the environment default", func(t *testing.T) {
+		cfg := &config.Config{
+			Primary: config.PrimaryConfig{Env: "production"},
+			Server:  config.ServerConfig{ErrorDetails: config.ErrorDetailsMessage},
+		}
+		httpErr := decode(t, serve(t, cfg, ""))
+
+		assert.Equal(t, "upstream 10.0.3.7:5432 refused the connection", httpErr.Message)
+		assert.Empty(t, httpErr.Debug)
+	})
+
+	t.Run("client errors are untouched in production", func(t *testing.T) {
+		logger := zerolog.Nop()
+		global := middleware.NewGlobalMiddlewares(&app.Server{
+			Logger: &logger,
+			Config: &config.Config{Primary: config.PrimaryConfig{Env: "production"}},
+		})
+
+		e := echo.New()
+		e.HTTPErrorHandler = global.GlobalErrorHandler
+		e.GET("/api/v1/todos", func(c echo.Context) error {
+			return errs.NewBadRequestError("Todo title is too long", false, nil, nil, nil)
+		})
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/todos", nil))
+
+		assert.Equal(t, "Todo title is too long", decode(t, rec).Message)
+	})
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	PlainHTTPActionRedirect = "redirect"
+	PlainHTTPActionReject   = "reject"
+	PlainHTTPActionAllow    = "allow"
+)
+
+// httpsExemptPaths are served over plain http so load balancer health checks keep working
+var httpsExemptPaths = map[string]bool{
+	"/status": true,
+}
+
+// EnforceHTTPS handles plain-http requests in production. TLS usually terminates at the
+// proxy, so the scheme comes from X-Forwarded-Proto (via echo's Context.Scheme).
+// Depending on config the request is redirected to https, rejected, or only logged.
+// Redirects go to the host of the configured server URL, never to the client's Host
+// header, which would make this an open redirect; without one the request is rejected.
+func (global *GlobalMiddlewares) EnforceHTTPS() echo.MiddlewareFunc {
+	action := PlainHTTPActionRedirect
+	if security := global.server.Config.Security; security != nil && security.PlainHTTPAction != "" {
+		action = security.PlainHTTPAction
+	}
+
+	var redirectHost string
+	if serverURL, err := url.Parse(global.server.Config.Server.ServerURL); err == nil {
+		redirectHost = serverURL.Host
+	}
+	if action == PlainHTTPActionRedirect && redirectHost == "" {
+		action = PlainHTTPActionReject
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if global.server.Config.Primary.Env != "production" ||
+				httpsExemptPaths[c.Request().URL.Path] ||
+				c.Scheme() == "https" {
+				return next(c)
+			}
+
+			req := c.Request()
+			global.server.Logger.Warn().
+				Str("method", req.Method).
+				Str("host", req.Host).
+				Str("path", req.URL.Path).
+				Str("ip", c.RealIP()).
+				Str("action", action).
+				Msg("plain http request received in production")
+
+			switch action {
+			case PlainHTTPActionAllow:
+				return next(c)
+			case PlainHTTPActionReject:
+				return errs.NewForbiddenError("HTTPS is required", false)
+			default:
+				// 308 keeps the method and body, unlike 301/302
+				return c.Redirect(http.StatusPermanentRedirect, "https://"+redirectHost+req.URL.RequestURI())
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveEnforceHTTPS(env, action, method, path, forwardedProto string) *httptest.ResponseRecorder {
+	return serveEnforceHTTPSFor("http://api.example.com", "api.example.com", env, action, method, path, forwardedProto)
+}
+
+// serveEnforceHTTPSFor sends the request with the given Host header to a server
+// configured with serverURL
+func serveEnforceHTTPSFor(serverURL, host, env, action, method, path, forwardedProto string) *httptest.ResponseRecorder {
+	logger := zerolog.Nop()
+	s := &app.Server{
+		Logger: &logger,
+		Config: &config.Config{
+			Primary:  config.PrimaryConfig{Env: env},
+			Server:   config.ServerConfig{ServerURL: serverURL},
+			Security: &config.SecurityConfig{PlainHTTPAction: action},
+		},
+	}
+	global := middleware.NewGlobalMiddlewares(s)
+
+	e := echo.New()
+	e.HTTPErrorHandler = global.GlobalErrorHandler
+	e.Use(global.EnforceHTTPS())
+	ok := func(c echo.Context) error { return c.String(http.StatusOK, "ok") }
+	e.GET("/status", ok)
+	e.POST("/api/v1/todos", ok)
+	e.GET("/api/v1/todos", ok)
+
+	req := httptest.NewRequest(method, "http://"+host+path, nil)
+	if forwardedProto != "" {
+		req.Header.Set(echo.HeaderXForwardedProto, forwardedProto)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestGlobalMiddlewares_EnforceHTTPS(t *testing.T) {
+	t.Run("redirects plain http in production", func(t *testing.T) {
+		rec := serveEnforceHTTPS("production", middleware.PlainHTTPActionRedirect, http.MethodPost, "/api/v1/todos?page=2", "http")
+
+		assert.Equal(t, http.StatusPermanentRedirect, rec.Code)
+		assert.Equal(t, "https://api.example.com/api/v1/todos?page=2", rec.Header().Get(echo.HeaderLocation))
+	})
+
+	t.Run("redirects to the configured host, not the Host header", func(t *testing.T) {
+		rec := serveEnforceHTTPSFor("https://api.example.com", "evil.example", "production",
+			middleware.PlainHTTPActionRedirect, http.MethodGet, "/api/v1/todos", "http")
+
+		assert.Equal(t, http.StatusPermanentRedirect, rec.Code)
+		assert.Equal(t, "https://api.example.com/api/v1/todos", rec.Header().Get(echo.HeaderLocation))
+	})
+
+	t.Run("rejects instead of redirecting without a configured host", func(t *testing.T) {
+		rec := serveEnforceHTTPSFor("", "evil.example", "production",
+			middleware.PlainHTTPActionRedirect, http.MethodGet, "/api/v1/todos", "http")
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Empty(t, rec.Header().Get(echo.HeaderLocation))
+	})
+
+	t.Run("rejects plain http in production", func(t *testing.T) {
+		rec := serveEnforceHTTPS("production", middleware.PlainHTTPActionReject, http.MethodGet, "/api/v1/todos", "http")
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("allow only logs", func(t *testing.T) {
+		rec := serveEnforceHTTPS("production", middleware.PlainHTTPActionAllow, http.MethodGet, "/api/v1/todos", "http")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("passes https in production", func(t *testing.T) {
+		rec := serveEnforceHTTPS("production", middleware.PlainHTTPActionReject, http.MethodGet, "/api/v1/todos", "https")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("exempts health checks", func(t *testing.T) {
+		rec := serveEnforceHTTPS("production", middleware.PlainHTTPActionReject, http.MethodGet, "/status", "http")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("does nothing outside production", func(t *testing.T) {
+		rec := serveEnforceHTTPS("development", middleware.PlainHTTPActionReject, http.MethodGet, "/api/v1/todos", "http")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RequireInternalOrRole lets requests from the configured internal networks through
+// untouched (probes, scrapers inside the cluster) and requires an authenticated user
+// holding one of roles for everyone else.
+//
+// Only the direct peer address is trusted: a request carrying X-Forwarded-For went
+// through a proxy, so the peer is the proxy and the request is treated as external.
+func (auth *AuthMiddleware) RequireInternalOrRole(roles ...string) echo.MiddlewareFunc {
+	var internal []netip.Prefix
+	if security := auth.server.Config.Security; security != nil {
+		internal = auth.parseInternalCIDRs(security.InternalCIDRs)
+	}
+
+	requireRole := auth.RequireRole(roles...)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		authenticated := auth.RequireAuth(requireRole(next))
+
+		return func(c echo.Context) error {
+			if isInternalRequest(c.Request().RemoteAddr, c.Request().Header, internal) {
+				return next(c)
+			}
+			return authenticated(c)
+		}
+	}
+}
+
+func (auth *AuthMiddleware) parseInternalCIDRs(value string) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, cidr := range strings.Split(value, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			auth.server.Logger.Warn().Err(err).Str("cidr", cidr).Msg("ignoring invalid internal CIDR")
+			continue
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes
+}
+
+func isInternalRequest(remoteAddr string, header http.Header, internal []netip.Prefix) bool {
+	if len(internal) == 0 || header.Get(echo.HeaderXForwardedFor) != "" {
+		return false
+	}
+
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+
+	for _, prefix := range internal {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
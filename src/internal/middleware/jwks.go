@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/clerk/clerk-sdk-go/v2/jwks"
+	"github.com/rs/zerolog"
+)
+
+const (
+	DefaultJWKSRefreshInterval = time.Hour
+
+	// Bound on a single JWKS fetch so a slow Clerk API cannot hold up startup
+	jwksFetchTimeout = 5 * time.Second
+
+	// A lookup for an unknown key ID re-fetches the set once it is at least this old,
+	// so rotated keys are picked up without waiting for the next scheduled refresh
+	jwksMinRefreshAge = time.Minute
+)
+
+// JWKSFetchFunc retrieves the current JSON Web Key Set from Clerk
+type JWKSFetchFunc func(ctx context.Context) (*clerk.JSONWebKeySet, error)
+
+// JWKSCache keeps Clerk's JSON Web Key Set in memory so verifying a session token never
+// waits on the JWKS endpoint. Warm it at startup and Start it to refresh in the background.
+type JWKSCache struct {
+	fetch    JWKSFetchFunc
+	interval time.Duration
+	logger   *zerolog.Logger
+
+	mu        sync.RWMutex
+	keys      []*clerk.JSONWebKey
+	fetchedAt time.Time
+
+	stop chan struct{}
+	once sync.Once
+}
+
+func NewJWKSCache(fetch JWKSFetchFunc, interval time.Duration, logger *zerolog.Logger) *JWKSCache {
+	if interval <= 0 {
+		interval = DefaultJWKSRefreshInterval
+	}
+
+	return &JWKSCache{
+		fetch:    fetch,
+		interval: interval,
+		logger:   logger,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Warm fetches the key set once. A failure is only logged: requests then fetch the keys
+// on demand, exactly as they would without the cache.
+func (c *JWKSCache) Warm(ctx context.Context) {
+	if err := c.Refresh(ctx); err != nil {
+		c.logger.Warn().Err(err).Msg("failed to pre-warm Clerk JWKS, keys will be fetched on first use")
+		return
+	}
+
+	c.logger.Info().Int("keys", len(c.Keys())).Msg("Clerk JWKS pre-warmed")
+}
+
+// Start refreshes the key set every interval until Stop is called
+func (c *JWKSCache) Start() {
+	go func() {
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-c.stop:
+				return
+			case <-ticker.C:
+			}
+
+			if err := c.Refresh(context.Background()); err != nil {
+				c.logger.Warn().Err(err).Msg("failed to refresh Clerk JWKS, keeping cached keys")
+			}
+		}
+	}()
+}
+
+func (c *JWKSCache) Stop() {
+	c.once.Do(func() { close(c.stop) })
+}
+
+// Refresh replaces the cached keys with a freshly fetched set
+func (c *JWKSCache) Refresh(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, jwksFetchTimeout)
+	defer cancel()
+
+	set, err := c.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	if set == nil || len(set.Keys) == 0 {
+		return errors.New("clerk returned an empty JWKS")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.keys = set.Keys
+	c.fetchedAt = time.Now()
+	return nil
+}
+
+// Keys returns the cached keys, or nil when the set has never been fetched
+func (c *JWKSCache) Keys() []*clerk.JSONWebKey {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.keys
+}
+
+func (c *JWKSCache) age() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.fetchedAt.IsZero() {
+		return -1
+	}
+	return time.Since(c.fetchedAt)
+}
+
+// Client returns a JWKS client for the Clerk middleware that answers from the cache
+func (c *JWKSCache) Client() *jwks.Client {
+	return &jwks.Client{Backend: jwksCacheBackend{cache: c}}
+}
+
+// jwksCacheBackend serves the JWKS endpoint from the cache. Clerk only asks for the set
+// when it meets a key ID it has not seen, which after a rotation means the cache is
+// stale, so a set that is not brand new is re-fetched first.
+type jwksCacheBackend struct {
+	cache *JWKSCache
+}
+
+func (b jwksCacheBackend) Call(ctx context.Context, _ *clerk.APIRequest, resource clerk.ResponseReader) error {
+	set, ok := resource.(*clerk.JSONWebKeySet)
+	if !ok {
+		return errors.New("jwks cache can only serve JSON web key sets")
+	}
+
+	if age := b.cache.age(); age < 0 || age >= jwksMinRefreshAge {
+		if err := b.cache.Refresh(ctx); err != nil && b.cache.Keys() == nil {
+			return err
+		}
+	}
+
+	set.Keys = b.cache.Keys()
+	return nil
+}
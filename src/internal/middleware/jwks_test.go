@@ -0,0 +1,134 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/clerk/clerk-sdk-go/v2/jwks"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeJWKS serves a configurable key set and counts fetches
+type fakeJWKS struct {
+	mu    sync.Mutex
+	kids  []string
+	err   error
+	calls int
+}
+
+func (f *fakeJWKS) set(err error, kids ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.kids, f.err = kids, err
+}
+
+func (f *fakeJWKS) fetch(ctx context.Context) (*clerk.JSONWebKeySet, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	set := &clerk.JSONWebKeySet{}
+	for _, kid := range f.kids {
+		set.Keys = append(set.Keys, &clerk.JSONWebKey{KeyID: kid})
+	}
+	return set, nil
+}
+
+func (f *fakeJWKS) fetches() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func keyIDs(keys []*clerk.JSONWebKey) []string {
+	ids := make([]string, 0, len(keys))
+	for _, key := range keys {
+		ids = append(ids, key.KeyID)
+	}
+	return ids
+}
+
+func TestJWKSCache(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("warm populates the cache", func(t *testing.T) {
+		fake := &fakeJWKS{}
+		fake.set(nil, "ins_key_1")
+		cache := middleware.NewJWKSCache(fake.fetch, time.Hour, &logger)
+
+		cache.Warm(context.Background())
+
+		assert.Equal(t, []string{"ins_key_1"}, keyIDs(cache.Keys()))
+
+		// The Clerk middleware is served from the warm cache without another fetch
+		set, err := cache.Client().Get(context.Background(), &jwks.GetParams{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ins_key_1"}, keyIDs(set.Keys))
+		assert.Equal(t, 1, fake.fetches())
+	})
+
+	t.Run("warm fails soft", func(t *testing.T) {
+		var logs bytes.Buffer
+		warnLogger := zerolog.New(&logs)
+		fake := &fakeJWKS{}
+		fake.set(errors.New("clerk unavailable"))
+		cache := middleware.NewJWKSCache(fake.fetch, time.Hour, &warnLogger)
+
+		cache.Warm(context.Background())
+
+		assert.Nil(t, cache.Keys())
+		assert.Contains(t, logs.String(), `"level":"warn"`)
+		assert.Contains(t, logs.String(), "clerk unavailable")
+	})
+
+	t.Run("refresh replaces the keys", func(t *testing.T) {
+		fake := &fakeJWKS{}
+		fake.set(nil, "ins_key_1")
+		cache := middleware.NewJWKSCache(fake.fetch, time.Hour, &logger)
+		cache.Warm(context.Background())
+
+		fake.set(nil, "ins_key_1", "ins_key_2")
+		require.NoError(t, cache.Refresh(context.Background()))
+
+		assert.Equal(t, []string{"ins_key_1", "ins_key_2"}, keyIDs(cache.Keys()))
+	})
+
+	t.Run("failed refresh keeps the cached keys", func(t *testing.T) {
+		fake := &fakeJWKS{}
+		fake.set(nil, "ins_key_1")
+		cache := middleware.NewJWKSCache(fake.fetch, time.Hour, &logger)
+		cache.Warm(context.Background())
+
+		fake.set(errors.New("timeout"))
+		require.Error(t, cache.Refresh(context.Background()))
+
+		assert.Equal(t, []string{"ins_key_1"}, keyIDs(cache.Keys()))
+	})
+
+	t.Run("background refresh picks up rotated keys", func(t *testing.T) {
+		fake := &fakeJWKS{}
+		fake.set(nil, "ins_key_1")
+		cache := middleware.NewJWKSCache(fake.fetch, 10*time.Millisecond, &logger)
+		cache.Warm(context.Background())
+
+		fake.set(nil, "ins_key_2")
+		cache.Start()
+		defer cache.Stop()
+
+		assert.Eventually(t, func() bool {
+			keys := cache.Keys()
+			return len(keys) == 1 && keys[0].KeyID == "ins_key_2"
+		}, time.Second, 5*time.Millisecond)
+	})
+}
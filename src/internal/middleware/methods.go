@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/labstack/echo/v4"
+)
+
+// RouteMethodsMiddleware holds the HTTP methods each route accepts, so a route can
+// advertise exactly those in its Allow header and CORS preflight instead of everything
+// registered on (or forwarded to) the path
+type RouteMethodsMiddleware struct {
+	server *app.Server
+	routes map[string][]string
+	// declared are the paths given to Allow, which AllowRegistered leaves alone
+	declared map[string]bool
+}
+
+func NewRouteMethodsMiddleware(s *app.Server) *RouteMethodsMiddleware {
+	return &RouteMethodsMiddleware{
+		server:   s,
+		routes:   make(map[string][]string),
+		declared: make(map[string]bool),
+	}
+}
+
+// Allow declares the methods accepted on path, the route pattern as registered (e.g.
+// "/api/v1/todos/:id"). It is only needed where the registered methods say too much,
+// such as a route forwarded with Any. Declare routes while building the router, before
+// serving.
+func (rm *RouteMethodsMiddleware) Allow(path string, methods ...string) {
+	allowed := make([]string, 0, len(methods))
+	for _, method := range methods {
+		allowed = append(allowed, strings.ToUpper(method))
+	}
+	rm.routes[path] = allowed
+	rm.declared[path] = true
+}
+
+// AllowRegistered derives the methods of every route not declared with Allow from the
+// routes as registered. Call it once with echo's Routes() after all routes are added.
+func (rm *RouteMethodsMiddleware) AllowRegistered(routes []*echo.Route) {
+	for _, route := range routes {
+		if rm.declared[route.Path] || route.Method == echo.RouteNotFound {
+			continue
+		}
+		if !slices.Contains(rm.routes[route.Path], route.Method) {
+			rm.routes[route.Path] = append(rm.routes[route.Path], route.Method)
+			// Routes come in no particular order; keep the advertised list stable
+			slices.Sort(rm.routes[route.Path])
+		}
+	}
+}
+
+// Advertise applies the declared methods. It must run before CORS: echo's router hands
+// the Allow list for 405 responses and preflights to later middleware through the
+// context, and this replaces it with the declared list. A method that is routed but not
+// declared is answered with 405 as well.
+func (rm *RouteMethodsMiddleware) Advertise() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			methods, ok := rm.routes[c.Path()]
+			if !ok {
+				return next(c)
+			}
+
+			allow := strings.Join(methods, ", ")
+			method := c.Request().Method
+			if _, routed := c.Get(echo.ContextKeyHeaderAllow).(string); routed || method == http.MethodOptions {
+				c.Set(echo.ContextKeyHeaderAllow, allow)
+				return next(c)
+			}
+
+			if !slices.Contains(methods, method) {
+				c.Response().Header().Set(echo.HeaderAllow, allow)
+				return echo.ErrMethodNotAllowed
+			}
+			return next(c)
+		}
+	}
+}
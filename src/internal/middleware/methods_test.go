@@ -0,0 +1,115 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRouteMethodsServer() *echo.Echo {
+	logger := zerolog.Nop()
+	s := &app.Server{
+		Logger: &logger,
+		Config: &config.Config{
+			Server: config.ServerConfig{CORSAllowedOrigins: "https://app.example.com"},
+		},
+	}
+	global := middleware.NewGlobalMiddlewares(s)
+	methods := middleware.NewRouteMethodsMiddleware(s)
+
+	e := echo.New()
+	e.Use(methods.Advertise(), global.CORS())
+
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusNoContent) }
+	e.GET("/api/v1/todos/export", ok)
+	methods.Allow("/api/v1/todos/export", http.MethodGet)
+	// Forwarded with Any, so the router alone would accept and advertise every method
+	e.Any("/api/v1/reports", ok)
+	methods.Allow("/api/v1/reports", http.MethodGet)
+	e.GET("/api/v1/todos", ok)
+	e.POST("/api/v1/todos", ok)
+
+	return e
+}
+
+func serveRouteMethods(e *echo.Echo, method, path string, preflight bool) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if preflight {
+		req.Header.Set(echo.HeaderOrigin, "https://app.example.com")
+		req.Header.Set(echo.HeaderAccessControlRequestMethod, http.MethodGet)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRouteMethodsMiddleware_Advertise(t *testing.T) {
+	e := newRouteMethodsServer()
+
+	for _, path := range []string{"/api/v1/todos/export", "/api/v1/reports"} {
+		t.Run("GET-only route "+path, func(t *testing.T) {
+			rec := serveRouteMethods(e, http.MethodOptions, path, true)
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+			assert.Equal(t, "GET", rec.Header().Get(echo.HeaderAccessControlAllowMethods))
+
+			rec = serveRouteMethods(e, http.MethodDelete, path, false)
+			assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+			assert.Equal(t, "GET", rec.Header().Get(echo.HeaderAllow))
+
+			rec = serveRouteMethods(e, http.MethodGet, path, false)
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+		})
+	}
+
+	t.Run("undeclared routes keep the router's methods", func(t *testing.T) {
+		rec := serveRouteMethods(e, http.MethodOptions, "/api/v1/todos", true)
+
+		assert.Equal(t, "OPTIONS, GET, POST", rec.Header().Get(echo.HeaderAccessControlAllowMethods))
+	})
+}
+
+func TestRouteMethodsMiddleware_AllowRegistered(t *testing.T) {
+	logger := zerolog.Nop()
+	s := &app.Server{
+		Logger: &logger,
+		Config: &config.Config{
+			Server: config.ServerConfig{CORSAllowedOrigins: "https://app.example.com"},
+		},
+	}
+	global := middleware.NewGlobalMiddlewares(s)
+	methods := middleware.NewRouteMethodsMiddleware(s)
+
+	e := echo.New()
+	e.Use(methods.Advertise(), global.CORS())
+
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusNoContent) }
+	e.GET("/api/v1/todos", ok)
+	e.POST("/api/v1/todos", ok)
+	e.GET("/api/v1/todos/export", ok)
+	e.Any("/api/v1/reports", ok)
+	methods.Allow("/api/v1/reports", http.MethodGet)
+	methods.AllowRegistered(e.Routes())
+
+	t.Run("registered methods are advertised", func(t *testing.T) {
+		rec := serveRouteMethods(e, http.MethodOptions, "/api/v1/todos", true)
+		assert.Equal(t, "GET, POST", rec.Header().Get(echo.HeaderAccessControlAllowMethods))
+
+		rec = serveRouteMethods(e, http.MethodDelete, "/api/v1/todos/export", false)
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, "GET", rec.Header().Get(echo.HeaderAllow))
+	})
+
+	t.Run("declared methods take precedence", func(t *testing.T) {
+		rec := serveRouteMethods(e, http.MethodDelete, "/api/v1/reports", false)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, "GET", rec.Header().Get(echo.HeaderAllow))
+	})
+}
@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/labstack/echo/v4"
+	"github.com/newrelic/go-agent/v3/newrelic"
+)
+
+// requestLatencyBuckets are the upper bounds of the latency histogram buckets
+var requestLatencyBuckets = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// RequestMetricsKey labels a request series. The status is reduced to its class
+// (2xx, 4xx, ...) and the path to its route template to keep cardinality bounded.
+type RequestMetricsKey struct {
+	Method      string
+	Route       string
+	StatusClass string
+}
+
+// RequestMetrics is a snapshot of a single request series
+type RequestMetrics struct {
+	Count      int64
+	LatencySum time.Duration
+	// Buckets holds cumulative counts matching the latency bucket bounds, plus a final +Inf bucket
+	Buckets []int64
+}
+
+type MetricsMiddleware struct {
+	server *app.Server
+	nrApp  *newrelic.Application
+
+	mu     sync.Mutex
+	series map[RequestMetricsKey]*RequestMetrics
+}
+
+func NewMetricsMiddleware(s *app.Server, nrApp *newrelic.Application) *MetricsMiddleware {
+	return &MetricsMiddleware{
+		server: s,
+		nrApp:  nrApp,
+		series: make(map[RequestMetricsKey]*RequestMetrics),
+	}
+}
+
+// RecordRequests counts requests and observes their latency per method, route template and status class
+func (m *MetricsMiddleware) RecordRequests() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+			duration := time.Since(start)
+
+			key := RequestMetricsKey{
+				Method:      c.Request().Method,
+				Route:       GetRouteTemplate(c),
+				StatusClass: StatusClass(responseStatus(c, err)),
+			}
+			m.observe(key, duration)
+
+			return err
+		}
+	}
+}
+
+// Snapshot returns a copy of every recorded series
+func (m *MetricsMiddleware) Snapshot() map[RequestMetricsKey]RequestMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[RequestMetricsKey]RequestMetrics, len(m.series))
+	for key, metrics := range m.series {
+		snapshot[key] = RequestMetrics{
+			Count:      metrics.Count,
+			LatencySum: metrics.LatencySum,
+			Buckets:    append([]int64(nil), metrics.Buckets...),
+		}
+	}
+	return snapshot
+}
+
+func (m *MetricsMiddleware) observe(key RequestMetricsKey, duration time.Duration) {
+	m.mu.Lock()
+	metrics, ok := m.series[key]
+	if !ok {
+		metrics = &RequestMetrics{Buckets: make([]int64, len(requestLatencyBuckets)+1)}
+		m.series[key] = metrics
+	}
+
+	metrics.Count++
+	metrics.LatencySum += duration
+	for i, bound := range requestLatencyBuckets {
+		if duration <= bound {
+			metrics.Buckets[i]++
+		}
+	}
+	metrics.Buckets[len(requestLatencyBuckets)]++
+	m.mu.Unlock()
+
+	if m.nrApp != nil {
+		name := "Custom/HTTP/" + key.Method + key.Route + "/" + key.StatusClass
+		m.nrApp.RecordCustomMetric(name+"/Count", 1)
+		m.nrApp.RecordCustomMetric(name+"/Duration", duration.Seconds())
+	}
+}
+
+// StatusClass reduces a status code to its class, e.g. 404 becomes "4xx"
+func StatusClass(status int) string {
+	if status < 100 || status > 599 {
+		return "unknown"
+	}
+	return strconv.Itoa(status/100) + "xx"
+}
+
+// responseStatus resolves the final status, including errors the global error handler
+// has not written yet. It maps them the way GlobalErrorHandler does, so a cancelled or
+// timed-out request is reported as 499 or 503 rather than 500.
+func responseStatus(c echo.Context, err error) int {
+	if err == nil {
+		return c.Response().Status
+	}
+
+	if status, _, _, ok := contextErrorStatus(err); ok {
+		return status
+	}
+
+	var httpErr *errs.HTTPError
+	var echoErr *echo.HTTPError
+	switch {
+	case errors.As(err, &httpErr):
+		return httpErr.Status
+	case errors.As(err, &echoErr):
+		return echoErr.Code
+	case c.Response().Committed:
+		return c.Response().Status
+	default:
+		return http.StatusInternalServerError
+	}
+}
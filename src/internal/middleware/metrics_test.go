@@ -0,0 +1,97 @@
+package middleware_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsMiddleware_RecordRequests(t *testing.T) {
+	metrics := middleware.NewMetricsMiddleware(&app.Server{}, nil)
+
+	e := echo.New()
+	e.Use(metrics.RecordRequests())
+	e.GET("/api/v1/todos/:id", func(c echo.Context) error {
+		switch c.Param("id") {
+		case "missing":
+			return errs.NewNotFoundError("Todo not found", false, nil)
+		case "broken":
+			return echo.NewHTTPError(http.StatusServiceUnavailable)
+		default:
+			return c.NoContent(http.StatusOK)
+		}
+	})
+	e.POST("/api/v1/todos", func(c echo.Context) error {
+		return c.NoContent(http.StatusCreated)
+	})
+
+	for _, target := range []string{"/api/v1/todos/1", "/api/v1/todos/2", "/api/v1/todos/missing", "/api/v1/todos/broken"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/v1/todos", nil))
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/nope", nil))
+
+	snapshot := metrics.Snapshot()
+
+	ok := snapshot[middleware.RequestMetricsKey{Method: http.MethodGet, Route: "/api/v1/todos/:id", StatusClass: "2xx"}]
+	assert.Equal(t, int64(2), ok.Count, "distinct IDs share the route template series")
+
+	assert.Equal(t, int64(1),
+		snapshot[middleware.RequestMetricsKey{Method: http.MethodGet, Route: "/api/v1/todos/:id", StatusClass: "4xx"}].Count)
+	assert.Equal(t, int64(1),
+		snapshot[middleware.RequestMetricsKey{Method: http.MethodGet, Route: "/api/v1/todos/:id", StatusClass: "5xx"}].Count)
+	assert.Equal(t, int64(1),
+		snapshot[middleware.RequestMetricsKey{Method: http.MethodPost, Route: "/api/v1/todos", StatusClass: "2xx"}].Count)
+
+	for key := range snapshot {
+		assert.NotContains(t, key.Route, "/nope", "unmatched paths must not become labels")
+	}
+
+	t.Run("latency histogram observes every request", func(t *testing.T) {
+		require.NotEmpty(t, ok.Buckets)
+		assert.Equal(t, ok.Count, ok.Buckets[len(ok.Buckets)-1])
+		assert.Greater(t, ok.LatencySum, time.Duration(0))
+	})
+}
+
+func TestMetricsMiddleware_RecordRequests_ContextErrors(t *testing.T) {
+	metrics := middleware.NewMetricsMiddleware(&app.Server{}, nil)
+
+	e := echo.New()
+	e.Use(metrics.RecordRequests())
+	e.GET("/api/v1/todos", func(c echo.Context) error {
+		if c.QueryParam("timeout") != "" {
+			return fmt.Errorf("failed to execute get todos query: %w", context.DeadlineExceeded)
+		}
+		return fmt.Errorf("failed to execute get todos query: %w", context.Canceled)
+	})
+
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/todos", nil))
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/todos?timeout=1", nil))
+
+	snapshot := metrics.Snapshot()
+
+	// A client that went away is counted as 499, not as a server error
+	assert.Equal(t, int64(1),
+		snapshot[middleware.RequestMetricsKey{Method: http.MethodGet, Route: "/api/v1/todos", StatusClass: "4xx"}].Count)
+	assert.Equal(t, int64(1),
+		snapshot[middleware.RequestMetricsKey{Method: http.MethodGet, Route: "/api/v1/todos", StatusClass: "5xx"}].Count)
+}
+
+func TestStatusClass(t *testing.T) {
+	assert.Equal(t, "2xx", middleware.StatusClass(http.StatusNoContent))
+	assert.Equal(t, "3xx", middleware.StatusClass(http.StatusPermanentRedirect))
+	assert.Equal(t, "4xx", middleware.StatusClass(http.StatusTooManyRequests))
+	assert.Equal(t, "5xx", middleware.StatusClass(http.StatusBadGateway))
+	assert.Equal(t, "unknown", middleware.StatusClass(0))
+}
@@ -11,6 +11,9 @@ type Middlewares struct {
 	ContextEnhancer *ContextEnhancer
 	Tracing         *TracingMiddleware
 	RateLimit       *RateLimitMiddleware
+	Cache           *CacheMiddleware
+	Metrics         *MetricsMiddleware
+	RouteMethods    *RouteMethodsMiddleware
 }
 
 func NewMiddlewares(s *app.Server) *Middlewares {
@@ -26,5 +29,8 @@ func NewMiddlewares(s *app.Server) *Middlewares {
 		ContextEnhancer: NewContextEnhancer(s),
 		Tracing:         NewTracingMiddleware(s, nrApp),
 		RateLimit:       NewRateLimitMiddleware(s),
+		Cache:           NewCacheMiddleware(s),
+		Metrics:         NewMetricsMiddleware(s, nrApp),
+		RouteMethods:    NewRouteMethodsMiddleware(s),
 	}
 }
@@ -1,7 +1,12 @@
 package middleware
 
 import (
+	"net/http"
+
 	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
 )
 
 type RateLimitMiddleware struct {
@@ -22,3 +27,36 @@ func (r *RateLimitMiddleware) RecordRateLimitHit(endpoint string) {
 	}
 }
 
+// UserRateLimitKey is the limiter identifier of the authenticated user, kept apart from
+// the client IPs the global limiter keys its buckets by
+func UserRateLimitKey(c echo.Context) string {
+	return "user:" + GetUserID(c)
+}
+
+// PerUser limits each authenticated user however many addresses they call from. It must
+// run after RequireAuth, which identifies the user.
+func (r *RateLimitMiddleware) PerUser(store echoMiddleware.RateLimiterStore) echo.MiddlewareFunc {
+	return echoMiddleware.RateLimiterWithConfig(echoMiddleware.RateLimiterConfig{
+		Store: store,
+		IdentifierExtractor: func(c echo.Context) (string, error) {
+			if GetUserID(c) == "" {
+				return "", errs.NewUnauthorizedError("Unauthorized", false)
+			}
+			return UserRateLimitKey(c), nil
+		},
+		ErrorHandler: func(c echo.Context, err error) error {
+			return err
+		},
+		DenyHandler: func(c echo.Context, identifier string, err error) error {
+			r.RecordRateLimitHit(c.Path())
+
+			r.server.Logger.Warn().
+				Str("identifier", identifier).
+				Str("path", c.Path()).
+				Str("method", c.Request().Method).
+				Msg("user rate limit exceeded")
+
+			return echo.NewHTTPError(http.StatusTooManyRequests, "Rate limit exceeded")
+		},
+	})
+}
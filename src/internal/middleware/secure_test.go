@@ -0,0 +1,96 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveSecure(security *config.SecurityConfig) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	// HSTS is only sent for requests that arrived over HTTPS
+	req.Header.Set(echo.HeaderXForwardedProto, "https")
+
+	global := middleware.NewGlobalMiddlewares(&app.Server{Config: &config.Config{Security: security}})
+
+	e := echo.New()
+	e.Use(global.Secure())
+	e.GET("/", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestGlobalMiddlewares_SecureCSP(t *testing.T) {
+	t.Run("enforced policy", func(t *testing.T) {
+		rec := serveSecure(&config.SecurityConfig{CSP: config.CSPConfig{DefaultSrc: "'self'", ImgSrc: "'self' data:"}})
+
+		assert.Equal(t, "default-src 'self'; img-src 'self' data:", rec.Header().Get(echo.HeaderContentSecurityPolicy))
+		assert.Empty(t, rec.Header().Get(echo.HeaderContentSecurityPolicyReportOnly))
+	})
+
+	t.Run("report-only policy", func(t *testing.T) {
+		rec := serveSecure(&config.SecurityConfig{CSP: config.CSPConfig{DefaultSrc: "'self'", ReportOnly: true}})
+
+		assert.Equal(t, "default-src 'self'", rec.Header().Get(echo.HeaderContentSecurityPolicyReportOnly))
+		assert.Empty(t, rec.Header().Get(echo.HeaderContentSecurityPolicy))
+	})
+}
+
+func TestGlobalMiddlewares_SecureHSTS(t *testing.T) {
+	tests := []struct {
+		name     string
+		hsts     config.HSTSConfig
+		expected string
+	}{
+		{
+			name:     "defaults leave preload off",
+			hsts:     config.DefaultSecurityConfig().HSTS,
+			expected: "max-age=31536000; includeSubdomains",
+		},
+		{
+			name:     "preload with subdomains",
+			hsts:     config.HSTSConfig{MaxAge: 63072000, IncludeSubDomains: true, Preload: true},
+			expected: "max-age=63072000; includeSubdomains; preload",
+		},
+		{
+			name:     "host only",
+			hsts:     config.HSTSConfig{MaxAge: 86400},
+			expected: "max-age=86400",
+		},
+		{
+			name:     "zero max-age disables the header",
+			hsts:     config.HSTSConfig{MaxAge: 0, IncludeSubDomains: true, Preload: true},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveSecure(&config.SecurityConfig{HSTS: tt.hsts})
+
+			assert.Equal(t, tt.expected, rec.Header().Get(echo.HeaderStrictTransportSecurity))
+		})
+	}
+}
+
+func TestGlobalMiddlewares_SecurePermissionsPolicy(t *testing.T) {
+	t.Run("configured directives are sent", func(t *testing.T) {
+		rec := serveSecure(&config.SecurityConfig{PermissionsPolicy: "geolocation=(self), camera=()"})
+
+		assert.Equal(t, "geolocation=(self), camera=()", rec.Header().Get("Permissions-Policy"))
+	})
+
+	t.Run("no header when unset", func(t *testing.T) {
+		rec := serveSecure(config.DefaultSecurityConfig())
+
+		assert.Empty(t, rec.Header().Get("Permissions-Policy"))
+	})
+}
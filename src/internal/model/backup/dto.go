@@ -0,0 +1,21 @@
+package backup
+
+import "time"
+
+// ------------------------------------------------------------
+
+type TriggerBackupPayload struct{}
+
+func (p *TriggerBackupPayload) Validate() error {
+	return nil
+}
+
+// ------------------------------------------------------------
+
+type BackupResponse struct {
+	// Key is the S3 object key the dump was uploaded to
+	Key string `json:"key"`
+	// Size is the size of the uploaded dump in bytes
+	Size        int64     `json:"size"`
+	CompletedAt time.Time `json:"completedAt"`
+}
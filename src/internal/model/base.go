@@ -25,9 +25,20 @@ type Base struct {
 }
 
 type PaginatedResponse[T interface{}] struct {
-	Data       []T `json:"data"`
-	Page       int `json:"page"`
-	Limit      int `json:"limit"`
-	Total      int `json:"total"`
-	TotalPages int `json:"totalPages"`
+	Data  []T `json:"data"`
+	Page  int `json:"page"`
+	Limit int `json:"limit"`
+	// Total and TotalPages are omitted when the client skipped the count (with_total=false)
+	Total      *int `json:"total,omitempty"`
+	TotalPages *int `json:"totalPages,omitempty"`
+}
+
+// SetTotal records the number of matching rows and derives the page count from it
+func (r *PaginatedResponse[T]) SetTotal(total int) {
+	totalPages := 0
+	if r.Limit > 0 {
+		totalPages = (total + r.Limit - 1) / r.Limit
+	}
+	r.Total = &total
+	r.TotalPages = &totalPages
 }
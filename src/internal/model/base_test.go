@@ -0,0 +1,38 @@
+package model_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginatedResponse_Total(t *testing.T) {
+	t.Run("includes total when set", func(t *testing.T) {
+		resp := model.PaginatedResponse[string]{Data: []string{"a", "b"}, Page: 1, Limit: 2}
+		resp.SetTotal(5)
+
+		body, err := json.Marshal(resp)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"data":["a","b"],"page":1,"limit":2,"total":5,"totalPages":3}`, string(body))
+	})
+
+	t.Run("keeps a zero total", func(t *testing.T) {
+		resp := model.PaginatedResponse[string]{Data: []string{}, Page: 1, Limit: 20}
+		resp.SetTotal(0)
+
+		body, err := json.Marshal(resp)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"data":[],"page":1,"limit":20,"total":0,"totalPages":0}`, string(body))
+	})
+
+	t.Run("omits total when the count was skipped", func(t *testing.T) {
+		resp := model.PaginatedResponse[string]{Data: []string{"a"}, Page: 2, Limit: 1}
+
+		body, err := json.Marshal(resp)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"data":["a"],"page":2,"limit":1}`, string(body))
+	})
+}
@@ -0,0 +1,19 @@
+package cache
+
+// ------------------------------------------------------------
+
+type PurgeCachePayload struct {
+	// Prefix limits the purge to keys starting with it, e.g. "http_cache:user_123:".
+	// Empty purges every cache namespace
+	Prefix string `json:"prefix" validate:"omitempty,max=256"`
+}
+
+func (p *PurgeCachePayload) Validate() error {
+	return nil
+}
+
+// ------------------------------------------------------------
+
+type PurgeCacheResponse struct {
+	Purged int64 `json:"purged"`
+}
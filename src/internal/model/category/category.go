@@ -9,3 +9,14 @@ type Category struct {
 	Color       string  `json:"color" db:"color"`
 	Description *string `json:"description" db:"description"`
 }
+
+// CategoryWithTodoCount is a category together with the number of todos filed under it
+type CategoryWithTodoCount struct {
+	Category
+	TodoCount int `json:"todoCount" db:"todo_count"`
+}
+
+// Columns lists the category columns clients may sort by
+var Columns = model.Columns{
+	Sortable: []string{"created_at", "updated_at", "name"},
+}
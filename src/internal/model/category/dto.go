@@ -1,7 +1,7 @@
 package category
 
 import (
-	"github.com/go-playground/validator/v10"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model"
 	"github.com/google/uuid"
 )
 
@@ -13,8 +13,7 @@ type CreateCategoryPayload struct {
 }
 
 func (p *CreateCategoryPayload) Validate() error {
-	validate := validator.New()
-	return validate.Struct(p)
+	return nil
 }
 
 // ------------------------------------------------------------
@@ -27,36 +26,33 @@ type UpdateCategoryPayload struct {
 }
 
 func (p *UpdateCategoryPayload) Validate() error {
-	validate := validator.New()
-	return validate.Struct(p)
+	return nil
 }
 
 // ------------------------------------------------------------
 
 type GetCategoriesQuery struct {
-	Page   *int    `query:"page" validate:"omitempty,min=1"`
-	Limit  *int    `query:"limit" validate:"omitempty,min=1,max=100"`
-	Sort   *string `query:"sort" validate:"omitempty,oneof=created_at updated_at name"`
-	Order  *string `query:"order" validate:"omitempty,oneof=asc desc"`
-	Search *string `query:"search" validate:"omitempty,min=1"`
+	Page     *int    `query:"page" validate:"omitempty,min=1"`
+	PageSize *int    `query:"page_size" validate:"omitempty,min=1,max=100"`
+	Limit    *int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Offset   *int    `query:"offset" validate:"omitempty,min=0"`
+	Sort     *string `query:"sort"`
+	Order    *string `query:"order" validate:"omitempty,oneof=asc desc"`
+	Search   *string `query:"search" validate:"omitempty,min=1"`
+	// WithTotal=false skips the count query and omits total/totalPages
+	WithTotal *bool `query:"with_total"`
+	// WithCounts=true adds each category's todoCount
+	WithCounts *bool `query:"with_counts"`
 }
 
 func (q *GetCategoriesQuery) Validate() error {
-	validate := validator.New()
-
-	if err := validate.Struct(q); err != nil {
+	// Resolve either pagination style to limit/offset
+	pagination, err := model.ResolvePagination(q.Page, q.PageSize, q.Limit, q.Offset, 50)
+	if err != nil {
 		return err
 	}
-
-	// Set defaults
-	if q.Page == nil {
-		defaultPage := 1
-		q.Page = &defaultPage
-	}
-	if q.Limit == nil {
-		defaultLimit := 50
-		q.Limit = &defaultLimit
-	}
+	page := pagination.Page()
+	q.Page, q.Limit, q.Offset = &page, &pagination.Limit, &pagination.Offset
 	if q.Sort == nil {
 		defaultSort := "name"
 		q.Sort = &defaultSort
@@ -65,16 +61,30 @@ func (q *GetCategoriesQuery) Validate() error {
 		defaultOrder := "asc"
 		q.Order = &defaultOrder
 	}
+	if q.WithTotal == nil {
+		defaultWithTotal := true
+		q.WithTotal = &defaultWithTotal
+	}
+
+	return Columns.ValidateSort("sort", *q.Sort)
+}
+
+// ------------------------------------------------------------
+
+type GetCategoryByIDPayload struct {
+	ID uuid.UUID `param:"id" validate:"required,uuid"`
+}
 
+func (p *GetCategoryByIDPayload) Validate() error {
 	return nil
 }
 
+// ------------------------------------------------------------
 
 type DeleteCategoryPayload struct {
 	ID uuid.UUID `param:"id" validate:"required,uuid"`
 }
 
 func (p *DeleteCategoryPayload) Validate() error {
-	validate := validator.New()
-	return validate.Struct(p)
+	return nil
 }
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
+)
+
+// Columns is a resource's allowlist of the columns clients may sort by. Column names
+// cannot be bound as query parameters, so a dynamic column must pass this check before
+// it is written into SQL.
+type Columns struct {
+	Sortable []string
+}
+
+func (c Columns) CanSort(column string) bool {
+	return slices.Contains(c.Sortable, column)
+}
+
+// ValidateSort rejects a sort column outside the allowlist with a validation error on field
+func (c Columns) ValidateSort(field, column string) error {
+	if c.CanSort(column) {
+		return nil
+	}
+	return validation.CustomValidationErrors{{
+		Field:   field,
+		Message: "must be one of: " + strings.Join(c.Sortable, " "),
+	}}
+}
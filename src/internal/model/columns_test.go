@@ -0,0 +1,50 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/category"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/todo"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func assertSortRejected(t *testing.T, err error) {
+	t.Helper()
+
+	var validationErrs validation.CustomValidationErrors
+	require.ErrorAs(t, err, &validationErrs)
+	require.Len(t, validationErrs, 1)
+	assert.Equal(t, "sort", validationErrs[0].Field)
+	assert.Contains(t, validationErrs[0].Message, "must be one of")
+}
+
+func TestColumns_Sort(t *testing.T) {
+	v := validation.NewValidator()
+	disallowed := []string{"user_id", "password", "title; DROP TABLE todos", "t.title"}
+
+	t.Run("todos", func(t *testing.T) {
+		for _, column := range todo.Columns.Sortable {
+			sort := column
+			assert.NoError(t, v.Validate(&todo.GetTodosQuery{Sort: &sort}), column)
+		}
+
+		for _, column := range disallowed {
+			sort := column
+			assertSortRejected(t, v.Validate(&todo.GetTodosQuery{Sort: &sort}))
+		}
+	})
+
+	t.Run("categories", func(t *testing.T) {
+		for _, column := range category.Columns.Sortable {
+			sort := column
+			assert.NoError(t, v.Validate(&category.GetCategoriesQuery{Sort: &sort}), column)
+		}
+
+		for _, column := range append(disallowed, "title") {
+			sort := column
+			assertSortRejected(t, v.Validate(&category.GetCategoriesQuery{Sort: &sort}))
+		}
+	})
+}
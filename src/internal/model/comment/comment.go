@@ -10,4 +10,6 @@ type Comment struct {
 	TodoID  uuid.UUID `json:"todoId" db:"todo_id"`
 	UserID  string    `json:"userId" db:"user_id"`
 	Content string    `json:"content" db:"content"`
+	// ContentHTML is the sanitized rendering of Content, only set when requested
+	ContentHTML *string `json:"contentHtml,omitempty" db:"-"`
 }
@@ -1,7 +1,8 @@
 package comment
 
 import (
-	"github.com/go-playground/validator/v10"
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -13,19 +14,19 @@ type AddCommentPayload struct {
 }
 
 func (p *AddCommentPayload) Validate() error {
-	validate := validator.New()
-	return validate.Struct(p)
+	return nil
 }
 
 // ------------------------------------------------------------
 
 type GetCommentsByTodoIDPayload struct {
 	TodoID uuid.UUID `param:"id" validate:"required,uuid"`
+	// Render=html adds a sanitized HTML rendering of each comment's markdown
+	Render *string `query:"render" validate:"omitempty,oneof=html"`
 }
 
 func (p *GetCommentsByTodoIDPayload) Validate() error {
-	validate := validator.New()
-	return validate.Struct(p)
+	return nil
 }
 
 // ------------------------------------------------------------
@@ -33,11 +34,13 @@ func (p *GetCommentsByTodoIDPayload) Validate() error {
 type UpdateCommentPayload struct {
 	ID      uuid.UUID `param:"id" validate:"required,uuid"`
 	Content string    `json:"content" validate:"required,min=1,max=1000"`
+	// ExpectedUpdatedAt is the updatedAt the client last read. When set, the update is
+	// rejected with 409 if the comment has changed since
+	ExpectedUpdatedAt *time.Time `json:"expectedUpdatedAt"`
 }
 
 func (p *UpdateCommentPayload) Validate() error {
-	validate := validator.New()
-	return validate.Struct(p)
+	return nil
 }
 
 // ------------------------------------------------------------
@@ -47,6 +50,5 @@ type DeleteCommentPayload struct {
 }
 
 func (p *DeleteCommentPayload) Validate() error {
-	validate := validator.New()
-	return validate.Struct(p)
+	return nil
 }
@@ -0,0 +1,9 @@
+package me
+
+// ------------------------------------------------------------
+
+type GetRateLimitStatusPayload struct{}
+
+func (p *GetRateLimitStatusPayload) Validate() error {
+	return nil
+}
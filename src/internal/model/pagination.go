@@ -0,0 +1,54 @@
+package model
+
+import "github.com/Harmeet10000/Fortress_API/src/internal/validation"
+
+// Pagination is the window a list query reads, whichever parameter style the client used
+type Pagination struct {
+	Limit  int
+	Offset int
+}
+
+// Page is the 1-based page the window starts on
+func (p Pagination) Page() int {
+	return p.Offset/p.Limit + 1
+}
+
+// ResolvePagination reconciles the page/page_size and limit/offset parameter styles.
+// page_size and limit both size the window and page and offset both position it, so
+// each pair is mutually exclusive. page with limit is how the API has always been
+// called and remains valid.
+func ResolvePagination(page, pageSize, limit, offset *int, defaultLimit int) (Pagination, error) {
+	var conflicts validation.CustomValidationErrors
+	if pageSize != nil && limit != nil {
+		conflicts = append(conflicts, validation.CustomValidationError{
+			Field:   "page_size",
+			Message: "cannot be combined with limit",
+		})
+	}
+	if page != nil && offset != nil {
+		conflicts = append(conflicts, validation.CustomValidationError{
+			Field:   "offset",
+			Message: "cannot be combined with page",
+		})
+	}
+	if len(conflicts) > 0 {
+		return Pagination{}, conflicts
+	}
+
+	p := Pagination{Limit: defaultLimit}
+	switch {
+	case pageSize != nil:
+		p.Limit = *pageSize
+	case limit != nil:
+		p.Limit = *limit
+	}
+
+	switch {
+	case offset != nil:
+		p.Offset = *offset
+	case page != nil:
+		p.Offset = (*page - 1) * p.Limit
+	}
+
+	return p, nil
+}
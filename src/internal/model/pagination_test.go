@@ -0,0 +1,99 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/model"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/category"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/todo"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestResolvePagination(t *testing.T) {
+	t.Run("defaults without any parameters", func(t *testing.T) {
+		p, err := model.ResolvePagination(nil, nil, nil, nil, 20)
+		require.NoError(t, err)
+		assert.Equal(t, model.Pagination{Limit: 20, Offset: 0}, p)
+		assert.Equal(t, 1, p.Page())
+	})
+
+	t.Run("page and page_size convert to limit and offset", func(t *testing.T) {
+		p, err := model.ResolvePagination(intPtr(3), intPtr(25), nil, nil, 20)
+		require.NoError(t, err)
+		assert.Equal(t, model.Pagination{Limit: 25, Offset: 50}, p)
+		assert.Equal(t, 3, p.Page())
+	})
+
+	t.Run("limit and offset pass through", func(t *testing.T) {
+		p, err := model.ResolvePagination(nil, nil, intPtr(10), intPtr(15), 20)
+		require.NoError(t, err)
+		assert.Equal(t, model.Pagination{Limit: 10, Offset: 15}, p)
+		assert.Equal(t, 2, p.Page())
+	})
+
+	t.Run("page with limit stays valid", func(t *testing.T) {
+		p, err := model.ResolvePagination(intPtr(2), nil, intPtr(10), nil, 20)
+		require.NoError(t, err)
+		assert.Equal(t, model.Pagination{Limit: 10, Offset: 10}, p)
+	})
+
+	t.Run("mixing both styles is rejected", func(t *testing.T) {
+		_, err := model.ResolvePagination(intPtr(2), intPtr(10), intPtr(10), intPtr(5), 20)
+
+		var validationErrs validation.CustomValidationErrors
+		require.ErrorAs(t, err, &validationErrs)
+		require.Len(t, validationErrs, 2)
+		assert.Equal(t, "page_size", validationErrs[0].Field)
+		assert.Equal(t, "offset", validationErrs[1].Field)
+	})
+}
+
+func TestListQueries_Pagination(t *testing.T) {
+	v := validation.NewValidator()
+
+	t.Run("todos accept page and page_size", func(t *testing.T) {
+		q := &todo.GetTodosQuery{Page: intPtr(2), PageSize: intPtr(5)}
+		require.NoError(t, v.Validate(q))
+
+		assert.Equal(t, 5, *q.Limit)
+		assert.Equal(t, 5, *q.Offset)
+		assert.Equal(t, 2, *q.Page)
+	})
+
+	t.Run("categories accept limit and offset", func(t *testing.T) {
+		q := &category.GetCategoriesQuery{Limit: intPtr(10), Offset: intPtr(30)}
+		require.NoError(t, v.Validate(q))
+
+		assert.Equal(t, 10, *q.Limit)
+		assert.Equal(t, 30, *q.Offset)
+		assert.Equal(t, 4, *q.Page)
+	})
+
+	t.Run("defaults differ per resource", func(t *testing.T) {
+		todos := &todo.GetTodosQuery{}
+		require.NoError(t, v.Validate(todos))
+		assert.Equal(t, 20, *todos.Limit)
+
+		categories := &category.GetCategoriesQuery{}
+		require.NoError(t, v.Validate(categories))
+		assert.Equal(t, 50, *categories.Limit)
+	})
+
+	t.Run("conflicting styles are a validation error", func(t *testing.T) {
+		err := v.Validate(&todo.GetTodosQuery{PageSize: intPtr(10), Limit: intPtr(10)})
+
+		var validationErrs validation.CustomValidationErrors
+		require.ErrorAs(t, err, &validationErrs)
+		assert.Equal(t, "page_size", validationErrs[0].Field)
+	})
+
+	t.Run("page_size is bounded like limit", func(t *testing.T) {
+		assert.Error(t, v.Validate(&todo.GetTodosQuery{PageSize: intPtr(500)}))
+	})
+}
@@ -0,0 +1,41 @@
+package system
+
+import "time"
+
+// ------------------------------------------------------------
+
+type GetFeaturesPayload struct{}
+
+func (p *GetFeaturesPayload) Validate() error {
+	return nil
+}
+
+// ------------------------------------------------------------
+
+type GetTimePayload struct{}
+
+func (p *GetTimePayload) Validate() error {
+	return nil
+}
+
+// ServerTime is the server's clock, for clients checking their own against it
+type ServerTime struct {
+	Time        time.Time `json:"time"`
+	EpochMillis int64     `json:"epochMillis"`
+}
+
+func NewServerTime(now time.Time) ServerTime {
+	now = now.UTC().Truncate(time.Millisecond)
+	return ServerTime{
+		Time:        now,
+		EpochMillis: now.UnixMilli(),
+	}
+}
+
+// ClockSkew estimates how far the server's clock is ahead of the client's (negative when
+// behind) from a request the client sent at sentAt and got the answer to at receivedAt,
+// both by its own clock. The server is assumed to have answered halfway through the round trip.
+func (t ServerTime) ClockSkew(sentAt, receivedAt time.Time) time.Duration {
+	midpoint := sentAt.Add(receivedAt.Sub(sentAt) / 2)
+	return t.Time.Sub(midpoint)
+}
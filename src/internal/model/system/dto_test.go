@@ -0,0 +1,32 @@
+package system_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/system"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerTime_ClockSkew(t *testing.T) {
+	sentAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	receivedAt := sentAt.Add(200 * time.Millisecond)
+
+	t.Run("server ahead", func(t *testing.T) {
+		serverTime := system.NewServerTime(sentAt.Add(100*time.Millisecond + 3*time.Second))
+
+		assert.Equal(t, 3*time.Second, serverTime.ClockSkew(sentAt, receivedAt))
+	})
+
+	t.Run("server behind", func(t *testing.T) {
+		serverTime := system.NewServerTime(sentAt.Add(100*time.Millisecond - 2*time.Second))
+
+		assert.Equal(t, -2*time.Second, serverTime.ClockSkew(sentAt, receivedAt))
+	})
+
+	t.Run("clocks in sync", func(t *testing.T) {
+		serverTime := system.NewServerTime(sentAt.Add(100 * time.Millisecond))
+
+		assert.Zero(t, serverTime.ClockSkew(sentAt, receivedAt))
+	})
+}
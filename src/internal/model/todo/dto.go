@@ -3,7 +3,7 @@ package todo
 import (
 	"time"
 
-	"github.com/go-playground/validator/v10"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model"
 	"github.com/google/uuid"
 )
 
@@ -20,8 +20,7 @@ type CreateTodoPayload struct {
 }
 
 func (p *CreateTodoPayload) Validate() error {
-	validate := validator.New()
-	return validate.Struct(p)
+	return nil
 }
 
 // ------------------------------------------------------------
@@ -39,16 +38,28 @@ type UpdateTodoPayload struct {
 }
 
 func (p *UpdateTodoPayload) Validate() error {
-	validate := validator.New()
-	return validate.Struct(p)
+	return nil
+}
+
+// ------------------------------------------------------------
+
+type UpdateTodoStatusPayload struct {
+	ID     uuid.UUID `param:"id" validate:"required,uuid"`
+	Status Status    `json:"status" validate:"required,oneof=draft active completed archived"`
+}
+
+func (p *UpdateTodoStatusPayload) Validate() error {
+	return nil
 }
 
 // ------------------------------------------------------------
 
 type GetTodosQuery struct {
 	Page         *int       `query:"page" validate:"omitempty,min=1"`
+	PageSize     *int       `query:"page_size" validate:"omitempty,min=1,max=100"`
 	Limit        *int       `query:"limit" validate:"omitempty,min=1,max=100"`
-	Sort         *string    `query:"sort" validate:"omitempty,oneof=created_at updated_at title priority due_date status"`
+	Offset       *int       `query:"offset" validate:"omitempty,min=0"`
+	Sort         *string    `query:"sort"`
 	Order        *string    `query:"order" validate:"omitempty,oneof=asc desc"`
 	Search       *string    `query:"search" validate:"omitempty,min=1"`
 	Status       *Status    `query:"status" validate:"omitempty,oneof=draft active completed archived"`
@@ -59,24 +70,18 @@ type GetTodosQuery struct {
 	DueTo        *time.Time `query:"dueTo"`
 	Overdue      *bool      `query:"overdue"`
 	Completed    *bool      `query:"completed"`
+	// WithTotal=false skips the count query and omits total/totalPages
+	WithTotal *bool `query:"with_total"`
 }
 
 func (q *GetTodosQuery) Validate() error {
-	validate := validator.New()
-
-	if err := validate.Struct(q); err != nil {
+	// Resolve either pagination style to limit/offset
+	pagination, err := model.ResolvePagination(q.Page, q.PageSize, q.Limit, q.Offset, 20)
+	if err != nil {
 		return err
 	}
-
-	// Set defaults for pagination
-	if q.Page == nil {
-		defaultPage := 1
-		q.Page = &defaultPage
-	}
-	if q.Limit == nil {
-		defaultLimit := 20
-		q.Limit = &defaultLimit
-	}
+	page := pagination.Page()
+	q.Page, q.Limit, q.Offset = &page, &pagination.Limit, &pagination.Offset
 	if q.Sort == nil {
 		defaultSort := "created_at"
 		q.Sort = &defaultSort
@@ -85,8 +90,12 @@ func (q *GetTodosQuery) Validate() error {
 		defaultOrder := "desc"
 		q.Order = &defaultOrder
 	}
+	if q.WithTotal == nil {
+		defaultWithTotal := true
+		q.WithTotal = &defaultWithTotal
+	}
 
-	return nil
+	return Columns.ValidateSort("sort", *q.Sort)
 }
 
 // ------------------------------------------------------------
@@ -96,8 +105,7 @@ type GetTodoByIDPayload struct {
 }
 
 func (p *GetTodoByIDPayload) Validate() error {
-	validate := validator.New()
-	return validate.Struct(p)
+	return nil
 }
 
 // ------------------------------------------------------------
@@ -107,8 +115,21 @@ type DeleteTodoPayload struct {
 }
 
 func (p *DeleteTodoPayload) Validate() error {
-	validate := validator.New()
-	return validate.Struct(p)
+	return nil
+}
+
+// ------------------------------------------------------------
+
+type ExportTodosQuery struct {
+	Format *string `query:"format" validate:"omitempty,oneof=csv json"`
+}
+
+func (q *ExportTodosQuery) Validate() error {
+	if q.Format == nil {
+		defaultFormat := "csv"
+		q.Format = &defaultFormat
+	}
+	return nil
 }
 
 // ------------------------------------------------------------
@@ -128,8 +149,7 @@ type UploadTodoAttachmentPayload struct {
 }
 
 func (p *UploadTodoAttachmentPayload) Validate() error {
-	validate := validator.New()
-	return validate.Struct(p)
+	return nil
 }
 
 // ------------------------------------------------------------
@@ -140,8 +160,7 @@ type DeleteTodoAttachmentPayload struct {
 }
 
 func (p *DeleteTodoAttachmentPayload) Validate() error {
-	validate := validator.New()
-	return validate.Struct(p)
+	return nil
 }
 
 // ------------------------------------------------------------
@@ -152,6 +171,5 @@ type GetAttachmentPresignedURLPayload struct {
 }
 
 func (p *GetAttachmentPresignedURLPayload) Validate() error {
-	validate := validator.New()
-	return validate.Struct(p)
+	return nil
 }
@@ -41,6 +41,11 @@ type Todo struct {
 	SortOrder    int        `json:"sortOrder" db:"sort_order"`
 }
 
+// Columns lists the todo columns clients may sort by
+var Columns = model.Columns{
+	Sortable: []string{"created_at", "updated_at", "title", "priority", "due_date", "status"},
+}
+
 type Metadata struct {
 	Tags       []string `json:"tags"`
 	Reminder   *string  `json:"reminder"`
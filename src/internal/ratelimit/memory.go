@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+type visitor struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// MemoryStore keeps one token bucket per identifier in process memory
+type MemoryStore struct {
+	mu        sync.Mutex
+	visitors  map[string]*visitor
+	rate      rate.Limit
+	burst     int
+	expiresIn time.Duration
+	lastClean time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStore creates an in-memory store; buckets idle for longer than expiresIn are dropped
+func NewMemoryStore(r rate.Limit, burst int, expiresIn time.Duration) *MemoryStore {
+	return &MemoryStore{
+		visitors:  make(map[string]*visitor),
+		rate:      r,
+		burst:     burst,
+		expiresIn: expiresIn,
+		lastClean: time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (s *MemoryStore) Allow(identifier string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	v := s.visitor(identifier, now)
+	v.lastSeen = now
+
+	if now.Sub(s.lastClean) > s.expiresIn {
+		s.cleanupStaleVisitors(now)
+	}
+
+	return v.limiter.AllowN(now, 1), nil
+}
+
+func (s *MemoryStore) Status(identifier string) (Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	v, ok := s.visitors[identifier]
+	if !ok {
+		return newStatus(s.rate, s.burst, float64(s.burst), now), nil
+	}
+
+	return newStatus(s.rate, s.burst, v.limiter.TokensAt(now), now), nil
+}
+
+func (s *MemoryStore) visitor(identifier string, now time.Time) *visitor {
+	v, ok := s.visitors[identifier]
+	if !ok {
+		v = &visitor{limiter: rate.NewLimiter(s.rate, s.burst), lastSeen: now}
+		s.visitors[identifier] = v
+	}
+	return v
+}
+
+func (s *MemoryStore) cleanupStaleVisitors(now time.Time) {
+	for id, v := range s.visitors {
+		if now.Sub(v.lastSeen) > s.expiresIn {
+			delete(s.visitors, id)
+		}
+	}
+	s.lastClean = now
+}
@@ -0,0 +1,102 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/time/rate"
+)
+
+const redisKeyPrefix = "ratelimit:"
+
+// tokenBucketScript refills and optionally consumes from a bucket atomically.
+// ARGV: rate (tokens/s), burst, now (ms), cost. A cost of 0 only reads the bucket.
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local cost = tonumber(ARGV[4])
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+
+local allowed = 1
+if cost > 0 then
+	if tokens >= cost then
+		tokens = tokens - cost
+	else
+		allowed = 0
+	end
+	redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+	redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate * 1000))
+end
+
+return {allowed, tostring(tokens)}
+`)
+
+// RedisStore keeps token buckets in Redis so limits are shared across instances
+type RedisStore struct {
+	client  redis.Scripter
+	rate    rate.Limit
+	burst   int
+	timeout time.Duration
+}
+
+// NewRedisStore creates a store backed by the given Redis client
+func NewRedisStore(client redis.Scripter, r rate.Limit, burst int) *RedisStore {
+	return &RedisStore{
+		client:  client,
+		rate:    r,
+		burst:   burst,
+		timeout: time.Second,
+	}
+}
+
+func (s *RedisStore) Allow(identifier string) (bool, error) {
+	allowed, _, err := s.run(identifier, 1, time.Now())
+	return allowed, err
+}
+
+func (s *RedisStore) Status(identifier string) (Status, error) {
+	now := time.Now()
+	_, tokens, err := s.run(identifier, 0, now)
+	if err != nil {
+		return Status{}, err
+	}
+	return newStatus(s.rate, s.burst, tokens, now), nil
+}
+
+func (s *RedisStore) run(identifier string, cost int, now time.Time) (bool, float64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	res, err := tokenBucketScript.Run(ctx, s.client, []string{redisKeyPrefix + identifier},
+		float64(s.rate), s.burst, now.UnixMilli(), cost).Slice()
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to evaluate rate limit for identifier=%s: %w", identifier, err)
+	}
+
+	if len(res) != 2 {
+		return false, 0, fmt.Errorf("unexpected rate limit script result for identifier=%s: %v", identifier, res)
+	}
+
+	allowed, _ := res[0].(int64)
+	tokensStr, _ := res[1].(string)
+	tokens, err := strconv.ParseFloat(tokensStr, 64)
+	if err != nil || math.IsNaN(tokens) {
+		return false, 0, fmt.Errorf("invalid token count %q for identifier=%s", tokensStr, identifier)
+	}
+
+	return allowed == 1, tokens, nil
+}
@@ -0,0 +1,39 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Status is a point-in-time view of a client's token bucket
+type Status struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+// Store is a rate limiter store whose buckets can be inspected without consuming a token.
+// It satisfies echo's middleware.RateLimiterStore so it can back the global limiter directly.
+type Store interface {
+	Allow(identifier string) (bool, error)
+	Status(identifier string) (Status, error)
+}
+
+// newStatus derives the public status from the tokens left in a bucket
+func newStatus(limit rate.Limit, burst int, tokens float64, now time.Time) Status {
+	tokens = math.Max(0, math.Min(float64(burst), tokens))
+
+	// Reset is the moment the bucket is full again
+	var untilFull time.Duration
+	if limit > 0 {
+		untilFull = time.Duration((float64(burst) - tokens) / float64(limit) * float64(time.Second))
+	}
+
+	return Status{
+		Limit:     burst,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     now.Add(untilFull),
+	}
+}
@@ -0,0 +1,78 @@
+package ratelimit_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/ratelimit"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func assertStatusReflectsConsumption(t *testing.T, store ratelimit.Store, identifier string) {
+	t.Helper()
+
+	status, err := store.Status(identifier)
+	require.NoError(t, err)
+	assert.Equal(t, 5, status.Limit)
+	assert.Equal(t, 5, status.Remaining, "reading the status must not consume a token")
+
+	for i := 0; i < 3; i++ {
+		allowed, err := store.Allow(identifier)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	}
+
+	status, err = store.Status(identifier)
+	require.NoError(t, err)
+	assert.Equal(t, 5, status.Limit)
+	assert.Equal(t, 2, status.Remaining)
+	assert.True(t, status.Reset.After(time.Now()), "reset should be in the future once tokens are spent")
+
+	for i := 0; i < 2; i++ {
+		allowed, err := store.Allow(identifier)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	}
+
+	allowed, err := store.Allow(identifier)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	status, err = store.Status(identifier)
+	require.NoError(t, err)
+	assert.Equal(t, 0, status.Remaining)
+}
+
+func TestMemoryStore_Status(t *testing.T) {
+	// A slow refill keeps the bucket from topping up while the test runs
+	store := ratelimit.NewMemoryStore(rate.Limit(0.01), 5, time.Minute)
+
+	assertStatusReflectsConsumption(t, store, "192.0.2.1")
+
+	t.Run("identifiers are tracked independently", func(t *testing.T) {
+		status, err := store.Status("192.0.2.2")
+		require.NoError(t, err)
+		assert.Equal(t, 5, status.Remaining)
+	})
+}
+
+func TestRedisStore_Status(t *testing.T) {
+	addr := os.Getenv("TEST_REDIS_ADDR")
+	if addr == "" {
+		t.Skip("TEST_REDIS_ADDR not set, skipping redis rate limit store test")
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	t.Cleanup(func() { _ = client.Close() })
+	require.NoError(t, client.Ping(context.Background()).Err())
+
+	store := ratelimit.NewRedisStore(client, rate.Limit(0.01), 5)
+
+	assertStatusReflectsConsumption(t, store, uuid.New().String())
+}
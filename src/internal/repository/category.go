@@ -8,11 +8,15 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
 	"github.com/Harmeet10000/Fortress_API/src/internal/model"
 	"github.com/Harmeet10000/Fortress_API/src/internal/model/category"
 	"github.com/Harmeet10000/Fortress_API/src/internal/app"
 )
 
+// CategoryAlreadyExistsCode is the error code of a create whose name the user already has
+const CategoryAlreadyExistsCode = "CATEGORY_ALREADY_EXISTS"
+
 type CategoryRepository struct {
 	server *app.Server
 }
@@ -39,11 +43,12 @@ func (r *CategoryRepository) CreateCategory(ctx context.Context, userID string,
 				@color,
 				@description
 			)
+		ON CONFLICT (user_id, name) DO NOTHING
 		RETURNING
 		*
 	`
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+	rows, err := db(r.server).Query(ctx, stmt, pgx.NamedArgs{
 		"user_id":     userID,
 		"name":        payload.Name,
 		"color":       payload.Color,
@@ -55,6 +60,12 @@ func (r *CategoryRepository) CreateCategory(ctx context.Context, userID string,
 
 	categoryItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[category.Category])
 	if err != nil {
+		// No row means the insert lost to an existing category of the same name, whether
+		// created earlier or by a concurrent request
+		if errors.Is(err, pgx.ErrNoRows) {
+			code := CategoryAlreadyExistsCode
+			return nil, errs.NewConflictError("A category with this name already exists", true, &code)
+		}
 		return nil, fmt.Errorf("failed to collect row from table:todo_categories for user_id=%s name=%s: %w", userID, payload.Name, err)
 	}
 
@@ -72,7 +83,7 @@ func (r *CategoryRepository) GetCategoryByID(ctx context.Context, userID string,
 			AND user_id=@user_id
 	`
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+	rows, err := db(r.server).Query(ctx, stmt, pgx.NamedArgs{
 		"id":      categoryID,
 		"user_id": userID,
 	})
@@ -88,6 +99,33 @@ func (r *CategoryRepository) GetCategoryByID(ctx context.Context, userID string,
 	return &categoryItem, nil
 }
 
+// Exists reports whether the category exists and belongs to the user without fetching the row
+func (r *CategoryRepository) Exists(ctx context.Context, userID string, categoryID uuid.UUID) (bool, error) {
+	stmt := `
+		SELECT
+			EXISTS (
+				SELECT
+					1
+				FROM
+					todo_categories
+				WHERE
+					id=@id
+					AND user_id=@user_id
+			)
+	`
+
+	var exists bool
+	err := db(r.server).QueryRow(ctx, stmt, pgx.NamedArgs{
+		"id":      categoryID,
+		"user_id": userID,
+	}).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check if category exists for category_id=%s user_id=%s: %w", categoryID.String(), userID, err)
+	}
+
+	return exists, nil
+}
+
 func (r *CategoryRepository) GetCategories(ctx context.Context, userID string,
 	query *category.GetCategoriesQuery,
 ) (*model.PaginatedResponse[category.Category], error) {
@@ -100,6 +138,45 @@ func (r *CategoryRepository) GetCategories(ctx context.Context, userID string,
 			user_id=@user_id
 	`
 
+	return listCategories[category.Category](ctx, r, userID, query, stmt)
+}
+
+// GetCategoriesWithTodoCounts is GetCategories with each category's number of todos,
+// counted in the same query so listing stays a single round trip however many
+// categories there are. Categories without todos report zero.
+func (r *CategoryRepository) GetCategoriesWithTodoCounts(ctx context.Context, userID string,
+	query *category.GetCategoriesQuery,
+) (*model.PaginatedResponse[category.CategoryWithTodoCount], error) {
+	stmt := `
+		SELECT
+			todo_categories.*,
+			COALESCE(counts.todo_count, 0) AS todo_count
+		FROM
+			todo_categories
+			LEFT JOIN (
+				SELECT
+					category_id,
+					COUNT(*) AS todo_count
+				FROM
+					todos
+				WHERE
+					user_id=@user_id
+					AND category_id IS NOT NULL
+				GROUP BY
+					category_id
+			) counts ON counts.category_id=todo_categories.id
+		WHERE
+			user_id=@user_id
+	`
+
+	return listCategories[category.CategoryWithTodoCount](ctx, r, userID, query, stmt)
+}
+
+// listCategories filters, sorts and paginates the user's categories selected by stmt,
+// which must end in a WHERE clause on user_id that further conditions can extend
+func listCategories[T any](ctx context.Context, r *CategoryRepository, userID string,
+	query *category.GetCategoriesQuery, stmt string,
+) (*model.PaginatedResponse[T], error) {
 	args := pgx.NamedArgs{
 		"user_id": userID,
 	}
@@ -115,9 +192,12 @@ func (r *CategoryRepository) GetCategories(ctx context.Context, userID string,
 	if query.Sort != nil {
 		sortColumn = *query.Sort
 	}
+	if !category.Columns.CanSort(sortColumn) {
+		return nil, fmt.Errorf("refusing to sort categories by non-allowlisted column %q", sortColumn)
+	}
 	sortOrder := "asc"
-	if query.Order != nil {
-		sortOrder = *query.Order
+	if query.Order != nil && *query.Order == "desc" {
+		sortOrder = "desc"
 	}
 	stmt += fmt.Sprintf(" ORDER BY %s %s", sortColumn, sortOrder)
 
@@ -125,24 +205,33 @@ func (r *CategoryRepository) GetCategories(ctx context.Context, userID string,
 	stmt += ` LIMIT @limit OFFSET @offset`
 	args["limit"] = *query.Limit
 	args["offset"] = (*query.Page - 1) * (*query.Limit)
+	if query.Offset != nil {
+		args["offset"] = *query.Offset
+	}
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	rows, err := db(r.server).Query(ctx, stmt, args)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute get categories query for user_id=%s: %w", userID, err)
 	}
 
-	categories, err := pgx.CollectRows(rows, pgx.RowToStructByName[category.Category])
+	response := &model.PaginatedResponse[T]{
+		Data:  []T{},
+		Page:  *query.Page,
+		Limit: *query.Limit,
+	}
+
+	categories, err := pgx.CollectRows(rows, pgx.RowToStructByName[T])
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return &model.PaginatedResponse[category.Category]{
-				Data:       []category.Category{},
-				Page:       *query.Page,
-				Limit:      *query.Limit,
-				Total:      0,
-				TotalPages: 0,
-			}, nil
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("failed to collect rows from table:todo_categories for user_id=%s: %w", userID, err)
 		}
-		return nil, fmt.Errorf("failed to collect rows from table:todo_categories for user_id=%s: %w", userID, err)
+		categories = []T{}
+	}
+	response.Data = categories
+
+	// The count doubles the work, so it only runs when the client wants the total
+	if query.WithTotal != nil && !*query.WithTotal {
+		return response, nil
 	}
 
 	// Get total count
@@ -165,18 +254,13 @@ func (r *CategoryRepository) GetCategories(ctx context.Context, userID string,
 	}
 
 	var total int
-	err = r.server.DB.Pool.QueryRow(ctx, countStmt, countArgs).Scan(&total)
+	err = db(r.server).QueryRow(ctx, countStmt, countArgs).Scan(&total)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get total count of categories for user_id=%s: %w", userID, err)
 	}
+	response.SetTotal(total)
 
-	return &model.PaginatedResponse[category.Category]{
-		Data:       categories,
-		Page:       *query.Page,
-		Limit:      *query.Limit,
-		Total:      total,
-		TotalPages: (total + *query.Limit - 1) / *query.Limit,
-	}, nil
+	return response, nil
 }
 
 func (r *CategoryRepository) UpdateCategory(ctx context.Context, userID string,
@@ -209,7 +293,7 @@ func (r *CategoryRepository) UpdateCategory(ctx context.Context, userID string,
 	stmt += strings.Join(setClauses, ", ")
 	stmt += ` WHERE id = @id AND user_id = @user_id RETURNING *`
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	rows, err := db(r.server).Query(ctx, stmt, args)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute update category query for category_id=%s user_id=%s: %w", categoryID.String(), userID, err)
 	}
@@ -223,7 +307,7 @@ func (r *CategoryRepository) UpdateCategory(ctx context.Context, userID string,
 }
 
 func (r *CategoryRepository) DeleteCategory(ctx context.Context, userID string, categoryID uuid.UUID) error {
-	result, err := r.server.DB.Pool.Exec(ctx, `
+	result, err := db(r.server).Exec(ctx, `
 		DELETE FROM todo_categories
 		WHERE id = @id AND user_id = @user_id
 	`, pgx.NamedArgs{
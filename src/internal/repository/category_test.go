@@ -0,0 +1,177 @@
+package repository_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/category"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/todo"
+	"github.com/Harmeet10000/Fortress_API/src/internal/repository"
+	"github.com/Harmeet10000/Fortress_API/src/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategoryRepository_CreateCategory(t *testing.T) {
+	_, testServer, cleanup := testutil.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	categoryRepo := repository.NewCategoryRepository(testServer)
+
+	t.Run("duplicate name is a conflict", func(t *testing.T) {
+		userID := uuid.New().String()
+		payload := &category.CreateCategoryPayload{Name: "Work", Color: "#FF5733"}
+
+		_, err := categoryRepo.CreateCategory(ctx, userID, payload)
+		require.NoError(t, err)
+
+		_, err = categoryRepo.CreateCategory(ctx, userID, payload)
+		var httpErr *errs.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, 409, httpErr.Status)
+		assert.Equal(t, repository.CategoryAlreadyExistsCode, httpErr.Code)
+	})
+
+	t.Run("same name for another user is allowed", func(t *testing.T) {
+		payload := &category.CreateCategoryPayload{Name: "Home", Color: "#00FF00"}
+
+		_, err := categoryRepo.CreateCategory(ctx, uuid.New().String(), payload)
+		require.NoError(t, err)
+		_, err = categoryRepo.CreateCategory(ctx, uuid.New().String(), payload)
+		require.NoError(t, err)
+	})
+
+	t.Run("concurrent creates produce exactly one row", func(t *testing.T) {
+		userID := uuid.New().String()
+		payload := &category.CreateCategoryPayload{Name: "Errands", Color: "#0000FF"}
+
+		const attempts = 8
+		results := make([]error, attempts)
+		var wg sync.WaitGroup
+		for i := range attempts {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, results[i] = categoryRepo.CreateCategory(ctx, userID, payload)
+			}()
+		}
+		wg.Wait()
+
+		created := 0
+		for _, err := range results {
+			if err == nil {
+				created++
+				continue
+			}
+			var httpErr *errs.HTTPError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, 409, httpErr.Status)
+		}
+		assert.Equal(t, 1, created)
+
+		page, err := categoryRepo.GetCategories(ctx, userID, &category.GetCategoriesQuery{
+			Page:  testutil.Ptr(1),
+			Limit: testutil.Ptr(10),
+		})
+		require.NoError(t, err)
+		assert.Len(t, page.Data, 1)
+	})
+}
+
+func TestCategoryRepository_GetCategoriesWithTodoCounts(t *testing.T) {
+	_, testServer, cleanup := testutil.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	categoryRepo := repository.NewCategoryRepository(testServer)
+	todoRepo := repository.NewTodoRepository(testServer)
+
+	userID := uuid.New().String()
+	createCategory := func(name string) *category.Category {
+		created, err := categoryRepo.CreateCategory(ctx, userID, &category.CreateCategoryPayload{Name: name, Color: "#FF5733"})
+		require.NoError(t, err)
+		return created
+	}
+	createTodo := func(owner string, categoryID *uuid.UUID) {
+		_, err := todoRepo.CreateTodo(ctx, owner, &todo.CreateTodoPayload{Title: "Todo", CategoryID: categoryID})
+		require.NoError(t, err)
+	}
+
+	work := createCategory("Work")
+	home := createCategory("Home")
+	createCategory("Someday")
+
+	for range 3 {
+		createTodo(userID, &work.ID)
+	}
+	createTodo(userID, &home.ID)
+	// Uncategorised todos and another user's todos are not counted
+	createTodo(userID, nil)
+	createTodo(uuid.New().String(), nil)
+
+	query := &category.GetCategoriesQuery{
+		Page:  testutil.Ptr(1),
+		Limit: testutil.Ptr(10),
+	}
+
+	t.Run("counts todos per category including empty ones", func(t *testing.T) {
+		page, err := categoryRepo.GetCategoriesWithTodoCounts(ctx, userID, query)
+		require.NoError(t, err)
+		require.Len(t, page.Data, 3)
+
+		counts := map[string]int{}
+		for _, item := range page.Data {
+			counts[item.Name] = item.TodoCount
+		}
+		assert.Equal(t, map[string]int{"Work": 3, "Home": 1, "Someday": 0}, counts)
+
+		require.NotNil(t, page.Total)
+		assert.Equal(t, 3, *page.Total)
+	})
+
+	t.Run("filters and paginates like the plain list", func(t *testing.T) {
+		page, err := categoryRepo.GetCategoriesWithTodoCounts(ctx, userID, &category.GetCategoriesQuery{
+			Page:   testutil.Ptr(1),
+			Limit:  testutil.Ptr(10),
+			Search: testutil.Ptr("wor"),
+		})
+		require.NoError(t, err)
+		require.Len(t, page.Data, 1)
+		assert.Equal(t, work.ID, page.Data[0].ID)
+		assert.Equal(t, 3, page.Data[0].TodoCount)
+	})
+}
+
+func TestCategoryRepository_Exists(t *testing.T) {
+	_, testServer, cleanup := testutil.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	categoryRepo := repository.NewCategoryRepository(testServer)
+
+	userID := uuid.New().String()
+	created, err := categoryRepo.CreateCategory(ctx, userID, &category.CreateCategoryPayload{Name: "Work", Color: "#FF5733"})
+	require.NoError(t, err)
+
+	t.Run("existing category", func(t *testing.T) {
+		exists, err := categoryRepo.Exists(ctx, userID, created.ID)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("missing category", func(t *testing.T) {
+		exists, err := categoryRepo.Exists(ctx, userID, uuid.New())
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("another user's category", func(t *testing.T) {
+		exists, err := categoryRepo.Exists(ctx, uuid.New().String(), created.ID)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
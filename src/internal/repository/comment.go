@@ -2,14 +2,25 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
 	"github.com/Harmeet10000/Fortress_API/src/internal/model/comment"
 	"github.com/Harmeet10000/Fortress_API/src/internal/app"
 )
 
+// CommentLimitReachedCode is the error code of a comment rejected because its todo
+// already holds the maximum number of comments
+const CommentLimitReachedCode = "COMMENT_LIMIT_REACHED"
+
+// CommentVersionConflictCode is the error code of an update whose expected updated_at
+// no longer matches the stored comment
+const CommentVersionConflictCode = "COMMENT_VERSION_CONFLICT"
+
 type CommentRepository struct {
 	server *app.Server
 }
@@ -18,9 +29,39 @@ func NewCommentRepository(server *app.Server) *CommentRepository {
 	return &CommentRepository{server: server}
 }
 
+// AddComment inserts the comment unless the todo already holds maxPerTodo comments, in
+// which case a 422 COMMENT_LIMIT_REACHED is returned. The todo row is locked first so
+// concurrent adds are serialized; the count and the insert are then a single statement.
 func (r *CommentRepository) AddComment(ctx context.Context, userID string, todoID uuid.UUID,
-	payload *comment.AddCommentPayload,
+	payload *comment.AddCommentPayload, maxPerTodo int,
 ) (*comment.Comment, error) {
+	conn, err := db(r.server).acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin add comment transaction for todo_id=%s: %w", todoID.String(), err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		SELECT
+			id
+		FROM
+			todos
+		WHERE
+			id=@todo_id
+		FOR UPDATE
+	`, pgx.NamedArgs{
+		"todo_id": todoID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock todo for todo_id=%s: %w", todoID.String(), err)
+	}
+
 	stmt := `
 		INSERT INTO
 			todo_comments (
@@ -28,20 +69,28 @@ func (r *CommentRepository) AddComment(ctx context.Context, userID string, todoI
 				user_id,
 				content
 			)
-		VALUES
+		SELECT
+			@todo_id,
+			@user_id,
+			@content
+		WHERE
 			(
-				@todo_id,
-				@user_id,
-				@content
-			)
+				SELECT
+					COUNT(*)
+				FROM
+					todo_comments
+				WHERE
+					todo_id=@todo_id
+			) < @max_per_todo
 		RETURNING
 		*
 	`
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
-		"todo_id": todoID,
-		"user_id": userID,
-		"content": payload.Content,
+	rows, err := tx.Query(ctx, stmt, pgx.NamedArgs{
+		"todo_id":      todoID,
+		"user_id":      userID,
+		"content":      payload.Content,
+		"max_per_todo": maxPerTodo,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute add comment query for todo_id=%s user_id=%s: %w", todoID.String(), userID, err)
@@ -49,9 +98,18 @@ func (r *CommentRepository) AddComment(ctx context.Context, userID string, todoI
 
 	commentItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[comment.Comment])
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			code := CommentLimitReachedCode
+			return nil, errs.NewUnprocessableEntityError(
+				fmt.Sprintf("Todo already has the maximum of %d comments", maxPerTodo), true, &code)
+		}
 		return nil, fmt.Errorf("failed to collect row from table:todo_comments for todo_id=%s user_id=%s: %w", todoID.String(), userID, err)
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit add comment for todo_id=%s: %w", todoID.String(), err)
+	}
+
 	return &commentItem, nil
 }
 
@@ -68,7 +126,7 @@ func (r *CommentRepository) GetCommentsByTodoID(ctx context.Context, userID stri
 			created_at ASC
 	`
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+	rows, err := db(r.server).Query(ctx, stmt, pgx.NamedArgs{
 		"todo_id": todoID,
 		"user_id": userID,
 	})
@@ -95,7 +153,7 @@ func (r *CommentRepository) GetCommentByID(ctx context.Context, userID string, c
 			AND user_id=@user_id
 	`
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+	rows, err := db(r.server).Query(ctx, stmt, pgx.NamedArgs{
 		"id":      commentID,
 		"user_id": userID,
 	})
@@ -111,7 +169,62 @@ func (r *CommentRepository) GetCommentByID(ctx context.Context, userID string, c
 	return &commentItem, nil
 }
 
-func (r *CommentRepository) UpdateComment(ctx context.Context, userID string, commentID uuid.UUID, content string) (*comment.Comment, error) {
+// Exists reports whether the comment exists and belongs to the user without fetching the row
+func (r *CommentRepository) Exists(ctx context.Context, userID string, commentID uuid.UUID) (bool, error) {
+	stmt := `
+		SELECT
+			EXISTS (
+				SELECT
+					1
+				FROM
+					todo_comments
+				WHERE
+					id=@id
+					AND user_id=@user_id
+			)
+	`
+
+	var exists bool
+	err := db(r.server).QueryRow(ctx, stmt, pgx.NamedArgs{
+		"id":      commentID,
+		"user_id": userID,
+	}).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check if comment exists for comment_id=%s user_id=%s: %w", commentID.String(), userID, err)
+	}
+
+	return exists, nil
+}
+
+// CountCommentsByTodoID returns how many comments the todo has. It is a single indexed
+// count on todo_id rather than loading the comments.
+func (r *CommentRepository) CountCommentsByTodoID(ctx context.Context, todoID uuid.UUID) (int, error) {
+	stmt := `
+		SELECT
+			COUNT(*)
+		FROM
+			todo_comments
+		WHERE
+			todo_id=@todo_id
+	`
+
+	var count int
+	err := db(r.server).QueryRow(ctx, stmt, pgx.NamedArgs{
+		"todo_id": todoID,
+	}).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count comments for todo_id=%s: %w", todoID.String(), err)
+	}
+
+	return count, nil
+}
+
+// UpdateComment changes the comment's content. When expectedUpdatedAt is set, the update
+// only applies if the comment is unchanged since the client read it; otherwise a 409 is
+// returned so concurrent edits are not silently overwritten.
+func (r *CommentRepository) UpdateComment(ctx context.Context, userID string, commentID uuid.UUID, content string,
+	expectedUpdatedAt *time.Time,
+) (*comment.Comment, error) {
 	stmt := `
 		UPDATE
 			todo_comments
@@ -120,14 +233,19 @@ func (r *CommentRepository) UpdateComment(ctx context.Context, userID string, co
 		WHERE
 			id=@id
 			AND user_id=@user_id
+			AND (
+				@expected_updated_at::TIMESTAMPTZ IS NULL
+				OR updated_at=@expected_updated_at
+			)
 		RETURNING
 		*
 	`
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
-		"id":      commentID,
-		"user_id": userID,
-		"content": content,
+	rows, err := db(r.server).Query(ctx, stmt, pgx.NamedArgs{
+		"id":                  commentID,
+		"user_id":             userID,
+		"content":             content,
+		"expected_updated_at": expectedUpdatedAt,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute update comment query for comment_id=%s user_id=%s: %w", commentID.String(), userID, err)
@@ -135,14 +253,34 @@ func (r *CommentRepository) UpdateComment(ctx context.Context, userID string, co
 
 	commentItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[comment.Comment])
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, r.updateMissed(ctx, userID, commentID)
+		}
 		return nil, fmt.Errorf("failed to collect row from table:todo_comments for comment_id=%s user_id=%s: %w", commentID.String(), userID, err)
 	}
 
 	return &commentItem, nil
 }
 
+// updateMissed explains an update that matched no row: a comment that is gone or not
+// the user's is not found, one that is still there was modified since it was read
+func (r *CommentRepository) updateMissed(ctx context.Context, userID string, commentID uuid.UUID) error {
+	exists, err := r.Exists(ctx, userID, commentID)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		code := "COMMENT_NOT_FOUND"
+		return errs.NewNotFoundError("comment not found", false, &code)
+	}
+
+	code := CommentVersionConflictCode
+	return errs.NewConflictError("Comment was modified since it was last read", false, &code)
+}
+
 func (r *CommentRepository) DeleteComment(ctx context.Context, userID string, commentID uuid.UUID) error {
-	result, err := r.server.DB.Pool.Exec(ctx, `
+	result, err := db(r.server).Exec(ctx, `
 		DELETE FROM todo_comments
 		WHERE id = @id AND user_id = @user_id
 	`, pgx.NamedArgs{
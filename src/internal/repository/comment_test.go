@@ -0,0 +1,131 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/comment"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/todo"
+	"github.com/Harmeet10000/Fortress_API/src/internal/repository"
+	"github.com/Harmeet10000/Fortress_API/src/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentRepository_UpdateComment(t *testing.T) {
+	_, testServer, cleanup := testutil.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	todoRepo := repository.NewTodoRepository(testServer)
+	commentRepo := repository.NewCommentRepository(testServer)
+
+	userID := uuid.New().String()
+	todoItem, err := todoRepo.CreateTodo(ctx, userID, &todo.CreateTodoPayload{Title: "Todo with comments"})
+	require.NoError(t, err)
+
+	addComment := func(t *testing.T) *comment.Comment {
+		t.Helper()
+		created, err := commentRepo.AddComment(ctx, userID, todoItem.ID, &comment.AddCommentPayload{
+			TodoID:  todoItem.ID,
+			Content: "first draft",
+		}, config.DefaultCommentsConfig().MaxPerTodo)
+		require.NoError(t, err)
+		return created
+	}
+
+	t.Run("update with the current version succeeds", func(t *testing.T) {
+		created := addComment(t)
+
+		updated, err := commentRepo.UpdateComment(ctx, userID, created.ID, "second draft", &created.UpdatedAt)
+		require.NoError(t, err)
+		assert.Equal(t, "second draft", updated.Content)
+	})
+
+	t.Run("update without a version is unconditional", func(t *testing.T) {
+		created := addComment(t)
+
+		updated, err := commentRepo.UpdateComment(ctx, userID, created.ID, "overwritten", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "overwritten", updated.Content)
+	})
+
+	t.Run("stale version conflicts", func(t *testing.T) {
+		created := addComment(t)
+		stale := created.UpdatedAt.Add(-time.Second)
+
+		_, err := commentRepo.UpdateComment(ctx, userID, created.ID, "lost update", &stale)
+		require.Error(t, err)
+
+		var httpErr *errs.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, 409, httpErr.Status)
+		assert.Equal(t, repository.CommentVersionConflictCode, httpErr.Code)
+
+		current, err := commentRepo.GetCommentByID(ctx, userID, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "first draft", current.Content)
+	})
+
+	t.Run("deleted comment is not found rather than conflicting", func(t *testing.T) {
+		created := addComment(t)
+		require.NoError(t, commentRepo.DeleteComment(ctx, userID, created.ID))
+
+		_, err := commentRepo.UpdateComment(ctx, userID, created.ID, "too late", &created.UpdatedAt)
+
+		var httpErr *errs.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, 404, httpErr.Status)
+	})
+
+	t.Run("another user's comment is not found rather than conflicting", func(t *testing.T) {
+		created := addComment(t)
+
+		_, err := commentRepo.UpdateComment(ctx, uuid.New().String(), created.ID, "not mine", &created.UpdatedAt)
+
+		var httpErr *errs.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, 404, httpErr.Status)
+	})
+}
+
+func TestCommentRepository_Exists(t *testing.T) {
+	_, testServer, cleanup := testutil.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	todoRepo := repository.NewTodoRepository(testServer)
+	commentRepo := repository.NewCommentRepository(testServer)
+
+	userID := uuid.New().String()
+	todoItem, err := todoRepo.CreateTodo(ctx, userID, &todo.CreateTodoPayload{Title: "Todo with comments"})
+	require.NoError(t, err)
+
+	created, err := commentRepo.AddComment(ctx, userID, todoItem.ID, &comment.AddCommentPayload{
+		TodoID:  todoItem.ID,
+		Content: "hello",
+	}, config.DefaultCommentsConfig().MaxPerTodo)
+	require.NoError(t, err)
+
+	t.Run("existing comment", func(t *testing.T) {
+		exists, err := commentRepo.Exists(ctx, userID, created.ID)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("non-existing comment", func(t *testing.T) {
+		exists, err := commentRepo.Exists(ctx, userID, uuid.New())
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("comment of another user", func(t *testing.T) {
+		exists, err := commentRepo.Exists(ctx, uuid.New().String(), created.ID)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"github.com/jackc/pgx/v5"
+)
+
+// rowCursor reads query results one row at a time instead of collecting them, so an
+// export never holds the whole result set in memory. It satisfies export.Cursor.
+type rowCursor[T any] struct {
+	rows pgx.Rows
+}
+
+func newRowCursor[T any](rows pgx.Rows) *rowCursor[T] {
+	return &rowCursor[T]{rows: rows}
+}
+
+func (c *rowCursor[T]) Next() bool {
+	return c.rows.Next()
+}
+
+func (c *rowCursor[T]) Record() (T, error) {
+	return pgx.RowToStructByName[T](c.rows)
+}
+
+func (c *rowCursor[T]) Err() error {
+	return c.rows.Err()
+}
+
+// Close releases the connection back to the pool; it is safe to call more than once
+func (c *rowCursor[T]) Close() {
+	c.rows.Close()
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// PoolSaturatedCode is the error code of a request that timed out waiting for a
+	// free database connection
+	PoolSaturatedCode = "DATABASE_POOL_SATURATED"
+
+	// poolSaturatedRetryAfter is the Retry-After, in seconds, sent while the pool is saturated
+	poolSaturatedRetryAfter = 1
+)
+
+// dbPool runs statements like *pgxpool.Pool but acquires the connection itself, so a
+// deadline hit while every connection is in use is told apart from a failed query: it
+// is reported as a 503 with Retry-After instead of a generic error. A deadline hit while
+// the pool had room, such as a slow dial to an unreachable database, is returned as is.
+type dbPool struct {
+	pool *pgxpool.Pool
+}
+
+func db(s *app.Server) dbPool {
+	return dbPool{pool: s.DB.Pool}
+}
+
+func (p dbPool) acquire(ctx context.Context) (*pgxpool.Conn, error) {
+	conn, err := p.pool.Acquire(ctx)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) && p.saturated() {
+			code := PoolSaturatedCode
+			return nil, errs.NewServiceUnavailableError(
+				"The service is busy, please retry shortly", false, &code, poolSaturatedRetryAfter)
+		}
+		return nil, err
+	}
+	return conn, nil
+}
+
+// saturated reports whether every connection the pool may open is checked out
+func (p dbPool) saturated() bool {
+	stat := p.pool.Stat()
+	return stat.AcquiredConns() >= stat.MaxConns()
+}
+
+func (p dbPool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	conn, err := p.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.Query(ctx, sql, args...)
+	if err != nil {
+		conn.Release()
+		return nil, err
+	}
+	return &connRows{Rows: rows, conn: conn}, nil
+}
+
+func (p dbPool) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	conn, err := p.acquire(ctx)
+	if err != nil {
+		return errRow{err: err}
+	}
+	return &connRow{row: conn.QueryRow(ctx, sql, args...), conn: conn}
+}
+
+func (p dbPool) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	conn, err := p.acquire(ctx)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	defer conn.Release()
+
+	return conn.Exec(ctx, sql, args...)
+}
+
+// connRows releases its connection once the rows are exhausted or closed
+type connRows struct {
+	pgx.Rows
+	conn *pgxpool.Conn
+	once sync.Once
+}
+
+func (r *connRows) Next() bool {
+	if r.Rows.Next() {
+		return true
+	}
+	r.release()
+	return false
+}
+
+func (r *connRows) Close() {
+	r.Rows.Close()
+	r.release()
+}
+
+func (r *connRows) release() {
+	r.once.Do(r.conn.Release)
+}
+
+// connRow releases its connection after the row is scanned
+type connRow struct {
+	row  pgx.Row
+	conn *pgxpool.Conn
+}
+
+func (r *connRow) Scan(dest ...any) error {
+	defer r.conn.Release()
+	return r.row.Scan(dest...)
+}
+
+type errRow struct {
+	err error
+}
+
+func (r errRow) Scan(...any) error {
+	return r.err
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/Harmeet10000/Fortress_API/src/internal/helper/export"
 	"github.com/Harmeet10000/Fortress_API/src/internal/model"
 	"github.com/Harmeet10000/Fortress_API/src/internal/model/todo"
 	"github.com/Harmeet10000/Fortress_API/src/internal/app"
@@ -55,7 +56,7 @@ func (r *TodoRepository) CreateTodo(ctx context.Context, userID string, payload
 		priority = *payload.Priority
 	}
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+	rows, err := db(r.server).Query(ctx, stmt, pgx.NamedArgs{
 		"user_id":        userID,
 		"title":          payload.Title,
 		"description":    payload.Description,
@@ -136,7 +137,7 @@ func (r *TodoRepository) GetTodoByID(ctx context.Context, userID string, todoID
 		c.id
 `
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+	rows, err := db(r.server).Query(ctx, stmt, pgx.NamedArgs{
 		"id":      todoID,
 		"user_id": userID,
 	})
@@ -163,7 +164,7 @@ func (r *TodoRepository) CheckTodoExists(ctx context.Context, userID string, tod
 			AND user_id=@user_id
 	`
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+	rows, err := db(r.server).Query(ctx, stmt, pgx.NamedArgs{
 		"id":      todoID,
 		"user_id": userID,
 	})
@@ -179,6 +180,33 @@ func (r *TodoRepository) CheckTodoExists(ctx context.Context, userID string, tod
 	return &todoItem, nil
 }
 
+// Exists reports whether the todo exists and belongs to the user without fetching the row
+func (r *TodoRepository) Exists(ctx context.Context, userID string, todoID uuid.UUID) (bool, error) {
+	stmt := `
+		SELECT
+			EXISTS (
+				SELECT
+					1
+				FROM
+					todos
+				WHERE
+					id=@id
+					AND user_id=@user_id
+			)
+	`
+
+	var exists bool
+	err := db(r.server).QueryRow(ctx, stmt, pgx.NamedArgs{
+		"id":      todoID,
+		"user_id": userID,
+	}).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check if todo exists for todo_id=%s user_id=%s: %w", todoID.String(), userID, err)
+	}
+
+	return exists, nil
+}
+
 func (r *TodoRepository) GetTodos(ctx context.Context, userID string, query *todo.GetTodosQuery) (*model.PaginatedResponse[todo.PopulatedTodo], error) {
 	stmt := `
 	SELECT
@@ -291,20 +319,33 @@ func (r *TodoRepository) GetTodos(ctx context.Context, userID string, query *tod
 		stmt += " WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	countStmt := "SELECT COUNT(*) FROM todos t"
-	if len(conditions) > 0 {
-		countStmt += " WHERE " + strings.Join(conditions, " AND ")
+	response := &model.PaginatedResponse[todo.PopulatedTodo]{
+		Data:  []todo.PopulatedTodo{},
+		Page:  *query.Page,
+		Limit: *query.Limit,
 	}
 
-	var total int
-	err := r.server.DB.Pool.QueryRow(ctx, countStmt, args).Scan(&total)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get total count for todos user_id=%s: %w", userID, err)
+	// The count doubles the work, so it only runs when the client wants the total
+	if query.WithTotal == nil || *query.WithTotal {
+		countStmt := "SELECT COUNT(*) FROM todos t"
+		if len(conditions) > 0 {
+			countStmt += " WHERE " + strings.Join(conditions, " AND ")
+		}
+
+		var total int
+		err := db(r.server).QueryRow(ctx, countStmt, args).Scan(&total)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get total count for todos user_id=%s: %w", userID, err)
+		}
+		response.SetTotal(total)
 	}
 
 	stmt += " GROUP BY t.id, c.id"
 
 	if query.Sort != nil {
+		if !todo.Columns.CanSort(*query.Sort) {
+			return nil, fmt.Errorf("refusing to sort todos by non-allowlisted column %q", *query.Sort)
+		}
 		stmt += " ORDER BY t." + *query.Sort
 		if query.Order != nil && *query.Order == "desc" {
 			stmt += " DESC"
@@ -318,8 +359,11 @@ func (r *TodoRepository) GetTodos(ctx context.Context, userID string, query *tod
 	stmt += " LIMIT @limit OFFSET @offset"
 	args["limit"] = *query.Limit
 	args["offset"] = (*query.Page - 1) * (*query.Limit)
+	if query.Offset != nil {
+		args["offset"] = *query.Offset
+	}
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	rows, err := db(r.server).Query(ctx, stmt, args)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute get todos query for user_id=%s: %w", userID, err)
 	}
@@ -327,24 +371,97 @@ func (r *TodoRepository) GetTodos(ctx context.Context, userID string, query *tod
 	todos, err := pgx.CollectRows(rows, pgx.RowToStructByName[todo.PopulatedTodo])
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return &model.PaginatedResponse[todo.PopulatedTodo]{
-				Data:       []todo.PopulatedTodo{},
-				Page:       *query.Page,
-				Limit:      *query.Limit,
-				Total:      0,
-				TotalPages: 0,
-			}, nil
+			return response, nil
 		}
 		return nil, fmt.Errorf("failed to collect rows from table:todos for user_id=%s: %w", userID, err)
 	}
 
-	return &model.PaginatedResponse[todo.PopulatedTodo]{
-		Data:       todos,
-		Page:       *query.Page,
-		Limit:      *query.Limit,
-		Total:      total,
-		TotalPages: (total + *query.Limit - 1) / *query.Limit,
-	}, nil
+	response.Data = todos
+	return response, nil
+}
+
+// StreamTodos opens a cursor over all of a user's todos, oldest first. The caller must
+// close it.
+func (r *TodoRepository) StreamTodos(ctx context.Context, userID string) (export.Cursor[todo.Todo], error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			todos
+		WHERE
+			user_id = @user_id
+		ORDER BY
+			created_at ASC
+	`
+
+	rows, err := db(r.server).Query(ctx, stmt, pgx.NamedArgs{
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute stream todos query for user_id=%s: %w", userID, err)
+	}
+
+	return newRowCursor[todo.Todo](rows), nil
+}
+
+// IterateTodos pages through every todo, oldest first, handing fn at most batchSize rows
+// at a time. The next batch is only read once fn returns, so memory stays bounded by one
+// batch however slow the consumer is. Iteration stops at the first error from fn or when
+// ctx is done. Batches are keyed on (created_at, id) rather than an offset, so rows
+// inserted mid-iteration cannot shift later pages.
+func (r *TodoRepository) IterateTodos(ctx context.Context, batchSize int, fn func([]todo.Todo) error) error {
+	if batchSize <= 0 {
+		return fmt.Errorf("batch size must be positive, got %d", batchSize)
+	}
+
+	args := pgx.NamedArgs{
+		"limit": batchSize,
+	}
+	after := ""
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		stmt := `
+			SELECT
+				*
+			FROM
+				todos
+		` + after + `
+			ORDER BY
+				created_at ASC,
+				id ASC
+			LIMIT
+				@limit
+		`
+
+		rows, err := db(r.server).Query(ctx, stmt, args)
+		if err != nil {
+			return fmt.Errorf("failed to execute iterate todos query: %w", err)
+		}
+
+		batch, err := pgx.CollectRows(rows, pgx.RowToStructByName[todo.Todo])
+		if err != nil {
+			return fmt.Errorf("failed to collect rows from table:todos: %w", err)
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if err := fn(batch); err != nil {
+			return err
+		}
+		if len(batch) < batchSize {
+			return nil
+		}
+
+		last := batch[len(batch)-1]
+		after = "WHERE (created_at, id) > (@after_created_at, @after_id)"
+		args["after_created_at"] = last.CreatedAt
+		args["after_id"] = last.ID
+	}
 }
 
 func (r *TodoRepository) UpdateTodo(ctx context.Context, userID string, payload *todo.UpdateTodoPayload) (*todo.Todo, error) {
@@ -369,13 +486,13 @@ func (r *TodoRepository) UpdateTodo(ctx context.Context, userID string, payload
 		setClauses = append(setClauses, "status = @status")
 		args["status"] = *payload.Status
 
-		// Auto-set completed_at when status changes to completed
-		if *payload.Status == todo.StatusCompleted {
-			setClauses = append(setClauses, "completed_at = @completed_at")
-			args["completed_at"] = time.Now()
-		} else if *payload.Status != todo.StatusCompleted {
-			setClauses = append(setClauses, "completed_at = NULL")
-		}
+		// completed_at only changes when the status crosses the completed boundary:
+		// stamped on entering completed, kept when already completed, cleared on leaving
+		setClauses = append(setClauses, `completed_at = CASE
+			WHEN @status = 'completed' THEN COALESCE(completed_at, @completed_at)
+			ELSE NULL
+		END`)
+		args["completed_at"] = time.Now()
 	}
 
 	if payload.Priority != nil {
@@ -410,7 +527,7 @@ func (r *TodoRepository) UpdateTodo(ctx context.Context, userID string, payload
 	stmt += strings.Join(setClauses, ", ")
 	stmt += " WHERE id = @todo_id AND user_id = @user_id RETURNING *"
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	rows, err := db(r.server).Query(ctx, stmt, args)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
@@ -431,7 +548,7 @@ func (r *TodoRepository) DeleteTodo(ctx context.Context, userID string, todoID u
 			AND user_id=@user_id
 	`
 
-	result, err := r.server.DB.Pool.Exec(ctx, stmt, pgx.NamedArgs{
+	result, err := db(r.server).Exec(ctx, stmt, pgx.NamedArgs{
 		"todo_id": todoID,
 		"user_id": userID,
 	})
@@ -483,7 +600,7 @@ func (r *TodoRepository) GetTodoStats(ctx context.Context, userID string) (*todo
 			user_id=@user_id
 	`
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+	rows, err := db(r.server).Query(ctx, stmt, pgx.NamedArgs{
 		"user_id": userID,
 	})
 	if err != nil {
@@ -513,7 +630,7 @@ func (r *TodoRepository) GetTodoAttachment(
 			AND id = @attachment_id
 	`
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+	rows, err := db(r.server).Query(ctx, stmt, pgx.NamedArgs{
 		"todo_id":       todoID,
 		"attachment_id": attachmentID,
 	})
@@ -548,7 +665,7 @@ func (r *TodoRepository) GetTodoAttachments(
 			created_at DESC
 	`
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+	rows, err := db(r.server).Query(ctx, stmt, pgx.NamedArgs{
 		"todo_id": todoID,
 	})
 	if err != nil {
@@ -578,7 +695,7 @@ func (r *TodoRepository) DeleteTodoAttachment(
 			AND id = @attachment_id
 	`
 
-	result, err := r.server.DB.Pool.Exec(ctx, stmt, pgx.NamedArgs{
+	result, err := db(r.server).Exec(ctx, stmt, pgx.NamedArgs{
 		"todo_id":       todoID,
 		"attachment_id": attachmentID,
 	})
@@ -626,7 +743,7 @@ func (r *TodoRepository) UploadTodoAttachment(
 			*
 	`
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+	rows, err := db(r.server).Query(ctx, stmt, pgx.NamedArgs{
 		"todo_id":      todoID,
 		"name":         fileName,
 		"uploaded_by":  userID,
@@ -666,7 +783,7 @@ func (r *TodoRepository) GetTodosDueInHours(ctx context.Context, hours int, limi
 	`
 
 	query := fmt.Sprintf(stmt, hours, limit)
-	rows, err := r.server.DB.Pool.Query(ctx, query)
+	rows, err := db(r.server).Query(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute get todos due in %d hours query: %w", hours, err)
 	}
@@ -698,7 +815,7 @@ func (r *TodoRepository) GetOverdueTodos(ctx context.Context, limit int) ([]todo
 			@limit
 	`
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+	rows, err := db(r.server).Query(ctx, stmt, pgx.NamedArgs{
 		"limit": limit,
 	})
 	if err != nil {
@@ -732,7 +849,7 @@ func (r *TodoRepository) GetCompletedTodosOlderThan(ctx context.Context, cutoffD
 			@limit
 	`
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+	rows, err := db(r.server).Query(ctx, stmt, pgx.NamedArgs{
 		"cutoff_date": cutoffDate,
 		"limit":       limit,
 	})
@@ -760,7 +877,7 @@ func (r *TodoRepository) ArchiveTodos(ctx context.Context, todoIDs []uuid.UUID)
 			id = ANY(@todo_ids::uuid[])
 	`
 
-	result, err := r.server.DB.Pool.Exec(ctx, stmt, pgx.NamedArgs{
+	result, err := db(r.server).Exec(ctx, stmt, pgx.NamedArgs{
 		"todo_ids": todoIDs,
 	})
 	if err != nil {
@@ -790,7 +907,7 @@ func (r *TodoRepository) GetWeeklyStatsForUsers(ctx context.Context, startDate,
 			COUNT(*) > 0
 	`
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+	rows, err := db(r.server).Query(ctx, stmt, pgx.NamedArgs{
 		"start_date": startDate,
 		"end_date":   endDate,
 	})
@@ -870,7 +987,7 @@ func (r *TodoRepository) GetCompletedTodosForUser(ctx context.Context, userID st
 		LIMIT 10
 	`
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+	rows, err := db(r.server).Query(ctx, stmt, pgx.NamedArgs{
 		"user_id":    userID,
 		"start_date": startDate,
 		"end_date":   endDate,
@@ -948,7 +1065,7 @@ func (r *TodoRepository) GetOverdueTodosForUser(ctx context.Context, userID stri
 		LIMIT 10
 	`
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+	rows, err := db(r.server).Query(ctx, stmt, pgx.NamedArgs{
 		"user_id": userID,
 	})
 	if err != nil {
@@ -9,13 +9,13 @@ import (
 	"github.com/google/uuid"
 	"github.com/Harmeet10000/Fortress_API/src/internal/model/todo"
 	"github.com/Harmeet10000/Fortress_API/src/internal/repository"
-	testing_pkg "github.com/Harmeet10000/Fortress_API/tests"
+	"github.com/Harmeet10000/Fortress_API/src/internal/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestTodoRepository_CreateTodo(t *testing.T) {
-	_, testServer, cleanup := testing_pkg.SetupTest(t)
+	_, testServer, cleanup := testutil.SetupTest(t)
 	defer cleanup()
 
 	ctx := context.Background()
@@ -26,8 +26,8 @@ func TestTodoRepository_CreateTodo(t *testing.T) {
 		dueDate := time.Now().Add(24 * time.Hour)
 		payload := &todo.CreateTodoPayload{
 			Title:       "Test Todo",
-			Description: testing_pkg.Ptr("Test todo description"),
-			Priority:    testing_pkg.Ptr(todo.PriorityHigh),
+			Description: testutil.Ptr("Test todo description"),
+			Priority:    testutil.Ptr(todo.PriorityHigh),
 			DueDate:     &dueDate,
 		}
 
@@ -43,7 +43,7 @@ func TestTodoRepository_CreateTodo(t *testing.T) {
 		assert.Equal(t, payload.DueDate.Unix(), result.DueDate.Unix())
 		assert.Equal(t, todo.StatusDraft, result.Status)
 		assert.Nil(t, result.CompletedAt)
-		testing_pkg.AssertTimestampsValid(t, result)
+		testutil.AssertTimestampsValid(t, result)
 	})
 
 	t.Run("create todo with minimum required fields", func(t *testing.T) {
@@ -66,7 +66,7 @@ func TestTodoRepository_CreateTodo(t *testing.T) {
 		userID := uuid.New().String()
 		metadata := &todo.Metadata{
 			Tags:  []string{"work", "urgent"},
-			Color: testing_pkg.Ptr("#ff0000"),
+			Color: testutil.Ptr("#ff0000"),
 		}
 		payload := &todo.CreateTodoPayload{
 			Title:    "Todo with Metadata",
@@ -97,7 +97,7 @@ func TestTodoRepository_CreateTodo(t *testing.T) {
 }
 
 func TestTodoRepository_GetTodoByID(t *testing.T) {
-	_, testServer, cleanup := testing_pkg.SetupTest(t)
+	_, testServer, cleanup := testutil.SetupTest(t)
 	defer cleanup()
 
 	ctx := context.Background()
@@ -146,7 +146,7 @@ func TestTodoRepository_GetTodoByID(t *testing.T) {
 }
 
 func TestTodoRepository_CheckTodoExists(t *testing.T) {
-	_, testServer, cleanup := testing_pkg.SetupTest(t)
+	_, testServer, cleanup := testutil.SetupTest(t)
 	defer cleanup()
 
 	ctx := context.Background()
@@ -183,7 +183,7 @@ func TestTodoRepository_CheckTodoExists(t *testing.T) {
 }
 
 func TestTodoRepository_GetTodos(t *testing.T) {
-	_, testServer, cleanup := testing_pkg.SetupTest(t)
+	_, testServer, cleanup := testutil.SetupTest(t)
 	defer cleanup()
 
 	ctx := context.Background()
@@ -207,8 +207,9 @@ func TestTodoRepository_GetTodos(t *testing.T) {
 		assert.GreaterOrEqual(t, len(result.Data), 3)
 		assert.Equal(t, page, result.Page)
 		assert.Equal(t, limit, result.Limit)
-		assert.GreaterOrEqual(t, result.Total, 3)
-		assert.GreaterOrEqual(t, result.TotalPages, 1)
+		require.NotNil(t, result.Total)
+		assert.GreaterOrEqual(t, *result.Total, 3)
+		assert.GreaterOrEqual(t, *result.TotalPages, 1)
 	})
 
 	t.Run("get todos with pagination", func(t *testing.T) {
@@ -225,8 +226,27 @@ func TestTodoRepository_GetTodos(t *testing.T) {
 		assert.Len(t, result.Data, 2)
 		assert.Equal(t, page, result.Page)
 		assert.Equal(t, limit, result.Limit)
-		assert.GreaterOrEqual(t, result.Total, 3)
-		assert.GreaterOrEqual(t, result.TotalPages, 2)
+		require.NotNil(t, result.Total)
+		assert.GreaterOrEqual(t, *result.Total, 3)
+		assert.GreaterOrEqual(t, *result.TotalPages, 2)
+	})
+
+	t.Run("get todos without total", func(t *testing.T) {
+		page := 1
+		limit := 2
+		withTotal := false
+		query := &todo.GetTodosQuery{
+			Page:      &page,
+			Limit:     &limit,
+			WithTotal: &withTotal,
+		}
+
+		result, err := todoRepo.GetTodos(ctx, userID, query)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Len(t, result.Data, 2)
+		assert.Nil(t, result.Total)
+		assert.Nil(t, result.TotalPages)
 	})
 
 	t.Run("filter by status", func(t *testing.T) {
@@ -304,7 +324,7 @@ func TestTodoRepository_GetTodos(t *testing.T) {
 }
 
 func TestTodoRepository_UpdateTodo(t *testing.T) {
-	_, testServer, cleanup := testing_pkg.SetupTest(t)
+	_, testServer, cleanup := testutil.SetupTest(t)
 	defer cleanup()
 
 	ctx := context.Background()
@@ -345,6 +365,45 @@ func TestTodoRepository_UpdateTodo(t *testing.T) {
 		assert.NotNil(t, result.CompletedAt)
 	})
 
+	t.Run("completed_at follows the completed boundary", func(t *testing.T) {
+		completed := todo.StatusCompleted
+		active := todo.StatusActive
+		archived := todo.StatusArchived
+		update := func(status *todo.Status, title *string) *todo.Todo {
+			result, err := todoRepo.UpdateTodo(ctx, userID, &todo.UpdateTodoPayload{
+				ID:     testTodo.ID,
+				Status: status,
+				Title:  title,
+			})
+			require.NoError(t, err)
+			return result
+		}
+
+		first := update(&completed, nil)
+		require.NotNil(t, first.CompletedAt)
+
+		// Completing an already completed todo keeps the original timestamp
+		again := update(&completed, nil)
+		require.NotNil(t, again.CompletedAt)
+		assert.True(t, first.CompletedAt.Equal(*again.CompletedAt))
+
+		// Updates that leave status alone do not touch completed_at
+		renamed := update(nil, testutil.Ptr("Renamed while completed"))
+		require.NotNil(t, renamed.CompletedAt)
+		assert.True(t, first.CompletedAt.Equal(*renamed.CompletedAt))
+
+		// Leaving completed clears it, and completing again stamps a new time
+		reopened := update(&active, nil)
+		assert.Equal(t, todo.StatusActive, reopened.Status)
+		assert.Nil(t, reopened.CompletedAt)
+
+		recompleted := update(&completed, nil)
+		require.NotNil(t, recompleted.CompletedAt)
+		assert.True(t, recompleted.CompletedAt.After(*first.CompletedAt))
+
+		assert.Nil(t, update(&archived, nil).CompletedAt)
+	})
+
 	t.Run("update multiple fields successfully", func(t *testing.T) {
 		newTitle := "Multi Update Todo"
 		newPriority := todo.PriorityLow
@@ -403,7 +462,7 @@ func TestTodoRepository_UpdateTodo(t *testing.T) {
 }
 
 func TestTodoRepository_DeleteTodo(t *testing.T) {
-	_, testServer, cleanup := testing_pkg.SetupTest(t)
+	_, testServer, cleanup := testutil.SetupTest(t)
 	defer cleanup()
 
 	ctx := context.Background()
@@ -443,7 +502,7 @@ func TestTodoRepository_DeleteTodo(t *testing.T) {
 }
 
 func TestTodoRepository_GetTodoStats(t *testing.T) {
-	_, testServer, cleanup := testing_pkg.SetupTest(t)
+	_, testServer, cleanup := testutil.SetupTest(t)
 	defer cleanup()
 
 	ctx := context.Background()
@@ -482,8 +541,8 @@ func createTestTodo(t *testing.T, ctx context.Context, repo *repository.TodoRepo
 	dueDate := time.Now().Add(24 * time.Hour)
 	payload := &todo.CreateTodoPayload{
 		Title:       "Test Todo",
-		Description: testing_pkg.Ptr("Test todo description"),
-		Priority:    testing_pkg.Ptr(todo.PriorityHigh),
+		Description: testutil.Ptr("Test todo description"),
+		Priority:    testutil.Ptr(todo.PriorityHigh),
 		DueDate:     &dueDate,
 	}
 
@@ -502,8 +561,8 @@ func createTestTodos(t *testing.T, ctx context.Context, repo *repository.TodoRep
 		dueDate := time.Now().Add(time.Duration(i+1) * 24 * time.Hour)
 		payload := &todo.CreateTodoPayload{
 			Title:       fmt.Sprintf("Test Todo %d", i+1),
-			Description: testing_pkg.Ptr(fmt.Sprintf("Test todo description %d", i+1)),
-			Priority:    testing_pkg.Ptr(todo.PriorityHigh),
+			Description: testutil.Ptr(fmt.Sprintf("Test todo description %d", i+1)),
+			Priority:    testutil.Ptr(todo.PriorityHigh),
 			DueDate:     &dueDate,
 		}
 
@@ -517,3 +576,107 @@ func createTestTodos(t *testing.T, ctx context.Context, repo *repository.TodoRep
 
 	return todos
 }
+
+func TestTodoRepository_Exists(t *testing.T) {
+	_, testServer, cleanup := testutil.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	todoRepo := repository.NewTodoRepository(testServer)
+
+	userID := uuid.New().String()
+	todos := createTestTodos(t, ctx, todoRepo, userID, 1)
+
+	t.Run("existing todo", func(t *testing.T) {
+		exists, err := todoRepo.Exists(ctx, userID, todos[0].ID)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("non-existing todo", func(t *testing.T) {
+		exists, err := todoRepo.Exists(ctx, userID, uuid.New())
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("todo of another user", func(t *testing.T) {
+		exists, err := todoRepo.Exists(ctx, uuid.New().String(), todos[0].ID)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestTodoRepository_IterateTodos(t *testing.T) {
+	_, testServer, cleanup := testutil.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	todoRepo := repository.NewTodoRepository(testServer)
+
+	created := createTestTodos(t, ctx, todoRepo, uuid.New().String(), 4)
+	created = append(created, createTestTodos(t, ctx, todoRepo, uuid.New().String(), 3)...)
+
+	t.Run("visits every row once in bounded batches", func(t *testing.T) {
+		seen := map[uuid.UUID]int{}
+		var batchSizes []int
+
+		err := todoRepo.IterateTodos(ctx, 3, func(batch []todo.Todo) error {
+			batchSizes = append(batchSizes, len(batch))
+			for _, item := range batch {
+				seen[item.ID]++
+			}
+			return nil
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []int{3, 3, 1}, batchSizes)
+		require.Len(t, seen, len(created))
+		for _, item := range created {
+			assert.Equal(t, 1, seen[item.ID])
+		}
+	})
+
+	t.Run("batch size dividing the total evenly", func(t *testing.T) {
+		visited := 0
+		err := todoRepo.IterateTodos(ctx, 7, func(batch []todo.Todo) error {
+			visited += len(batch)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, len(created), visited)
+	})
+
+	t.Run("cancellation stops iteration", func(t *testing.T) {
+		cancelCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		batches := 0
+		err := todoRepo.IterateTodos(cancelCtx, 2, func(batch []todo.Todo) error {
+			batches++
+			cancel()
+			return nil
+		})
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, batches)
+	})
+
+	t.Run("callback error stops iteration", func(t *testing.T) {
+		stop := fmt.Errorf("webhook endpoint unavailable")
+
+		batches := 0
+		err := todoRepo.IterateTodos(ctx, 2, func(batch []todo.Todo) error {
+			batches++
+			return stop
+		})
+		require.ErrorIs(t, err, stop)
+		assert.Equal(t, 1, batches)
+	})
+
+	t.Run("rejects a non-positive batch size", func(t *testing.T) {
+		err := todoRepo.IterateTodos(ctx, 0, func(batch []todo.Todo) error {
+			t.Fatal("callback should not run")
+			return nil
+		})
+		assert.Error(t, err)
+	})
+}
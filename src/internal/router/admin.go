@@ -0,0 +1,22 @@
+package router
+
+import (
+	"github.com/Harmeet10000/Fortress_API/src/internal/handler"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+// adminRole is the Clerk organization role allowed to use admin operations
+const adminRole = "org:admin"
+
+func registerAdminRoutes(r *echo.Echo, h *handler.CacheHandler, backupHandler *handler.BackupHandler, auth *middleware.AuthMiddleware,
+	userRateLimit echo.MiddlewareFunc,
+) {
+	// Admin operations
+	admin := r.Group("/admin")
+	admin.Use(auth.RequireAuth, userRateLimit, auth.RequireRole(adminRole))
+
+	admin.POST("/cache/purge", h.PurgeCache)
+	admin.POST("/backup", backupHandler.TriggerBackup)
+}
@@ -1,16 +1,16 @@
 package router
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/Harmeet10000/Fortress_API/src/internal/app"
 	"github.com/Harmeet10000/Fortress_API/src/internal/handler"
-	"github.com/Harmeet10000/Fortress_API/src/internal/middlewares"
-	"github.com/Harmeet10000/Fortress_API/src/internal/services"
+	middlewares "github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	v1 "github.com/Harmeet10000/Fortress_API/src/internal/router/v1"
+	services "github.com/Harmeet10000/Fortress_API/src/internal/service"
 	"github.com/labstack/echo/v4"
 	echoMiddleware "github.com/labstack/echo/v4/middleware"
-
-	"golang.org/x/time/rate"
 )
 
 func NewRouter(s *app.Server, h *handler.Handlers, services *services.Services) *echo.Echo {
@@ -19,11 +19,20 @@ func NewRouter(s *app.Server, h *handler.Handlers, services *services.Services)
 	router := echo.New()
 
 	router.HTTPErrorHandler = middlewares.Global.GlobalErrorHandler
+	router.Validator = h.Validator
+
+	// Fetch Clerk's signing keys once the server starts rather than on the first
+	// authenticated request, and keep them refreshed while it serves
+	s.RegisterStartHook("jwks_refresh", middlewares.Auth.StartJWKS)
+	s.RegisterShutdownHook("jwks_refresh", func(ctx context.Context) error {
+		middlewares.Auth.StopJWKS()
+		return nil
+	})
 
 	// global middlewares
 	router.Use(
 		echoMiddleware.RateLimiterWithConfig(echoMiddleware.RateLimiterConfig{
-			Store: echoMiddleware.NewRateLimiterMemoryStore(rate.Limit(20)),
+			Store: services.RateLimit.Store(),
 			DenyHandler: func(c echo.Context, identifier string, err error) error {
 				// Record rate limit hit metrics
 				if rateLimitMiddleware := middlewares.RateLimit; rateLimitMiddleware != nil {
@@ -41,21 +50,37 @@ func NewRouter(s *app.Server, h *handler.Handlers, services *services.Services)
 				return echo.NewHTTPError(http.StatusTooManyRequests, "Rate limit exceeded")
 			},
 		}),
+		middlewares.Global.EnforceHTTPS(),
+		middlewares.RouteMethods.Advertise(),
 		middlewares.Global.CORS(),
 		middlewares.Global.Secure(),
-		// middlewares.CorrelationID(),
+		middlewares.Global.Deprecation(),
+		middlewares.Global.RejectAmbiguousFraming(),
+		middlewares.Global.RequestBudget(),
+		middlewares.Global.Decompress(),
+		middlewares.Global.Compress(),
+		middlewares.Global.CorrelationID(),
 		middlewares.Tracing.NewRelicMiddleware(),
 		middlewares.Tracing.EnhanceTracing(),
+		middlewares.Metrics.RecordRequests(),
 		middlewares.ContextEnhancer.EnhanceContext(),
 		middlewares.Global.RequestLogger(),
 		middlewares.Global.Recover(),
 	)
 
+	userRateLimit := middlewares.RateLimit.PerUser(services.RateLimit.Store())
+
 	// register system routes
-	registerSystemRoutes(router, h)
+	registerSystemRoutes(router, h, middlewares.Auth)
+
+	// register admin routes, which like system routes sit outside the API version
+	registerAdminRoutes(router, h.Cache, h.Backup, middlewares.Auth, userRateLimit)
 
 	// register versioned routes
-	router.Group("/api/v1")
+	v1.RegisterV1Routes(router.Group("/api/v1"), h, middlewares, userRateLimit)
+
+	// Advertise exactly the methods each route was registered with
+	middlewares.RouteMethods.AllowRegistered(router.Routes())
 
 	return router
 }
@@ -2,12 +2,16 @@ package router
 
 import (
 	"github.com/Harmeet10000/Fortress_API/src/internal/handler"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
 
 	"github.com/labstack/echo/v4"
 )
 
-func registerSystemRoutes(r *echo.Echo, h *handler.Handlers) {
+func registerSystemRoutes(r *echo.Echo, h *handler.Handlers, auth *middleware.AuthMiddleware) {
 	r.GET("/status", h.Health.CheckHealth)
+	r.GET("/status/detailed", h.Health.CheckHealthDetailed, auth.RequireInternalOrRole("org:admin"))
+	r.GET("/features", h.Features.GetFeatures, auth.RequireInternalOrRole("org:admin"))
+	r.GET("/time", h.Time.GetTime)
 
 	r.Static("/static", "static")
 
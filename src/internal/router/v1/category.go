@@ -1,22 +1,29 @@
 package v1
 
 import (
+	"time"
+
 	"github.com/labstack/echo/v4"
-	"github.com/sriniously/tasker/internal/handler"
-	"github.com/sriniously/tasker/internal/middleware"
+	"github.com/Harmeet10000/Fortress_API/src/internal/handler"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
 )
 
-func registerCategoryRoutes(r *echo.Group, h *handler.CategoryHandler, auth *middleware.AuthMiddleware) {
+const categoryCacheTTL = 30 * time.Second
+
+func registerCategoryRoutes(r *echo.Group, h *handler.CategoryHandler, auth *middleware.AuthMiddleware, cache *middleware.CacheMiddleware,
+	userRateLimit echo.MiddlewareFunc,
+) {
 	// Category operations
 	categories := r.Group("/categories")
-	categories.Use(auth.RequireAuth)
+	categories.Use(auth.RequireAuth, userRateLimit, cache.InvalidateCache("/api/v1/categories"))
 
 	// Category collection operations
 	categories.POST("", h.CreateCategory)
-	categories.GET("", h.GetCategories)
+	categories.GET("", h.GetCategories, cache.CacheGET(categoryCacheTTL))
 
 	// Individual category operations
 	dynamicCategory := categories.Group("/:id")
+	dynamicCategory.GET("", h.GetCategoryByID)
 	dynamicCategory.PATCH("", h.UpdateCategory)
 	dynamicCategory.DELETE("", h.DeleteCategory)
 }
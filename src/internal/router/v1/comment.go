@@ -2,14 +2,14 @@ package v1
 
 import (
 	"github.com/labstack/echo/v4"
-	"github.com/sriniously/tasker/internal/handler"
-	"github.com/sriniously/tasker/internal/middleware"
+	"github.com/Harmeet10000/Fortress_API/src/internal/handler"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
 )
 
-func registerCommentRoutes(r *echo.Group, h *handler.CommentHandler, auth *middleware.AuthMiddleware) {
+func registerCommentRoutes(r *echo.Group, h *handler.CommentHandler, auth *middleware.AuthMiddleware, userRateLimit echo.MiddlewareFunc) {
 	// Comment operations
 	comments := r.Group("/comments")
-	comments.Use(auth.RequireAuth)
+	comments.Use(auth.RequireAuth, userRateLimit)
 
 	// Individual comment operations
 	dynamicComment := comments.Group("/:id")
@@ -0,0 +1,15 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/Harmeet10000/Fortress_API/src/internal/handler"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+)
+
+func registerMeRoutes(r *echo.Group, h *handler.RateLimitHandler, auth *middleware.AuthMiddleware, userRateLimit echo.MiddlewareFunc) {
+	// Operations on the authenticated client
+	me := r.Group("/me")
+	me.Use(auth.RequireAuth, userRateLimit)
+
+	me.GET("/rate-limit", h.GetRateLimitStatus)
+}
@@ -2,25 +2,35 @@ package v1
 
 import (
 	"github.com/labstack/echo/v4"
-	"github.com/sriniously/tasker/internal/handler"
-	"github.com/sriniously/tasker/internal/middleware"
+	"github.com/Harmeet10000/Fortress_API/src/internal/handler"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
 )
 
-func registerTodoRoutes(r *echo.Group, h *handler.TodoHandler, ch *handler.CommentHandler, auth *middleware.AuthMiddleware) {
+func registerTodoRoutes(r *echo.Group, h *handler.TodoHandler, ch *handler.CommentHandler, auth *middleware.AuthMiddleware,
+	cache *middleware.CacheMiddleware, global *middleware.GlobalMiddlewares, userRateLimit echo.MiddlewareFunc,
+) {
 	// Todo operations
 	todos := r.Group("/todos")
-	todos.Use(auth.RequireAuth)
+	todos.Use(auth.RequireAuth, userRateLimit)
+
+	// Cached category listings can carry todo counts, which todo mutations change
+	invalidateCategories := cache.InvalidateCache("/api/v1/categories")
+
+	// Integrations may send a Content-MD5 or Digest header with the todos they write
+	verifyDigest := global.VerifyBodyDigest(false)
 
 	// Collection operations
-	todos.POST("", h.CreateTodo)
+	todos.POST("", h.CreateTodo, verifyDigest, invalidateCategories)
 	todos.GET("", h.GetTodos)
 	todos.GET("/stats", h.GetTodoStats)
+	todos.GET("/export", h.ExportTodos)
 
 	// Individual todo operations
 	dynamicTodo := todos.Group("/:id")
 	dynamicTodo.GET("", h.GetTodoByID)
-	dynamicTodo.PATCH("", h.UpdateTodo)
-	dynamicTodo.DELETE("", h.DeleteTodo)
+	dynamicTodo.PATCH("", h.UpdateTodo, verifyDigest, invalidateCategories)
+	dynamicTodo.PATCH("/status", h.UpdateTodoStatus)
+	dynamicTodo.DELETE("", h.DeleteTodo, invalidateCategories)
 
 	// Todo comments
 	todoComments := dynamicTodo.Group("/comments")
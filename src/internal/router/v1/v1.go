@@ -2,17 +2,24 @@ package v1
 
 import (
 	"github.com/labstack/echo/v4"
-	"github.com/sriniously/tasker/internal/handler"
-	"github.com/sriniously/tasker/internal/middleware"
+	"github.com/Harmeet10000/Fortress_API/src/internal/handler"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
 )
 
-func RegisterV1Routes(router *echo.Group, handlers *handler.Handlers, middleware *middleware.Middlewares) {
+// RegisterV1Routes registers the versioned API. userRateLimit limits each authenticated
+// user and goes right after RequireAuth, which identifies the user.
+func RegisterV1Routes(router *echo.Group, handlers *handler.Handlers, middleware *middleware.Middlewares,
+	userRateLimit echo.MiddlewareFunc,
+) {
 	// Register todo routes
-	registerTodoRoutes(router, handlers.Todo, handlers.Comment, middleware.Auth)
+	registerTodoRoutes(router, handlers.Todo, handlers.Comment, middleware.Auth, middleware.Cache, middleware.Global, userRateLimit)
 
 	// Register category routes
-	registerCategoryRoutes(router, handlers.Category, middleware.Auth)
+	registerCategoryRoutes(router, handlers.Category, middleware.Auth, middleware.Cache, userRateLimit)
 
 	// Register comment routes
-	registerCommentRoutes(router, handlers.Comment, middleware.Auth)
+	registerCommentRoutes(router, handlers.Comment, middleware.Auth, userRateLimit)
+
+	// Register routes for the authenticated client
+	registerMeRoutes(router, handlers.RateLimit, middleware.Auth, userRateLimit)
 }
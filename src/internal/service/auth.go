@@ -2,27 +2,50 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/helper/breaker"
+	"github.com/Harmeet10000/Fortress_API/src/internal/helper/httpclient"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
 
 	"github.com/clerk/clerk-sdk-go/v2"
 	clerkUser "github.com/clerk/clerk-sdk-go/v2/user"
 )
 
+const (
+	clerkHTTPTimeout = 5 * time.Second
+	clerkHTTPRetries = 2
+)
+
 type AuthService struct {
 	server *app.Server
+	// clerk fails fast while Clerk is down, so callers get a 503 instead of waiting
+	// out the timeouts and retries of every request
+	clerk *breaker.Breaker
 }
 
 func NewAuthService(s *app.Server) *AuthService {
 	clerk.SetKey(s.Config.Auth.SecretKey)
+	clerk.SetBackend(clerk.NewBackend(&clerk.BackendConfig{
+		HTTPClient: httpclient.New(clerkHTTPTimeout, clerkHTTPRetries),
+		Key:        clerk.String(s.Config.Auth.SecretKey),
+	}))
 	return &AuthService{
 		server: s,
+		clerk:  breaker.FromConfig("Clerk", s.Config.Breaker),
 	}
 }
 
 func (s *AuthService) GetUserEmail(ctx context.Context, userID string) (string, error) {
-	user, err := clerkUser.Get(ctx, userID)
+	var user *clerk.User
+	err := s.clerk.Do(func() error {
+		var err error
+		user, err = clerkUser.Get(ctx, userID)
+		return clerkStatusError(err)
+	})
 	if err != nil {
 		return "", fmt.Errorf("failed to get user from Clerk: %w", err)
 	}
@@ -31,11 +54,27 @@ func (s *AuthService) GetUserEmail(ctx context.Context, userID string) (string,
 		return "", fmt.Errorf("user %s has no email addresses", userID)
 	}
 
+	address := user.EmailAddresses[0].EmailAddress
 	for _, email := range user.EmailAddresses {
 		if user.PrimaryEmailAddressID != nil && email.ID == *user.PrimaryEmailAddressID {
-			return email.EmailAddress, nil
+			address = email.EmailAddress
+			break
 		}
 	}
 
-	return user.EmailAddresses[0].EmailAddress, nil
+	normalized, err := validation.NormalizeEmail(address)
+	if err != nil {
+		return "", fmt.Errorf("user %s: %w", userID, err)
+	}
+	return normalized, nil
+}
+
+// clerkStatusError exposes the status of a Clerk error response to the breaker, so a
+// 404 for an unknown user is not mistaken for Clerk being down
+func clerkStatusError(err error) error {
+	var apiErr *clerk.APIErrorResponse
+	if errors.As(err, &apiErr) {
+		return &breaker.StatusError{Status: apiErr.HTTPStatusCode, Err: err}
+	}
+	return err
 }
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/backup"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// backupLockKey guards against two backups running at once across all instances
+	backupLockKey = "backup:lock"
+
+	// backupTimeout bounds a single backup; the lock expires with it so a crashed
+	// instance cannot block backups forever
+	backupTimeout = 30 * time.Minute
+)
+
+// BackupDumper writes a database dump to w
+type BackupDumper interface {
+	Dump(ctx context.Context, w io.Writer) error
+}
+
+// BackupUploader stores a dump and returns the object key it was stored under
+type BackupUploader interface {
+	UploadFile(ctx context.Context, bucket string, fileName string, file io.Reader) (string, error)
+}
+
+type BackupService struct {
+	server   *app.Server
+	dumper   BackupDumper
+	uploader BackupUploader
+}
+
+func NewBackupService(s *app.Server, dumper BackupDumper, uploader BackupUploader) *BackupService {
+	return &BackupService{
+		server:   s,
+		dumper:   dumper,
+		uploader: uploader,
+	}
+}
+
+// TriggerBackup dumps the database and uploads it to S3 right away. Only one backup
+// runs at a time; a trigger while another is in progress is rejected with 409.
+func (s *BackupService) TriggerBackup(ctx echo.Context) (*backup.BackupResponse, error) {
+	logger := middleware.GetLogger(ctx)
+
+	if !s.server.Config.S3.BackupEnabled {
+		code := "BACKUPS_DISABLED"
+		return nil, errs.NewConflictError("Backups are not enabled", true, &code)
+	}
+
+	// The backup outlives a client that disconnects, but not the lock
+	backupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx.Request().Context()), backupTimeout)
+	defer cancel()
+
+	token := uuid.NewString()
+	acquired, err := s.server.Redis.SetNX(backupCtx, backupLockKey, token, backupTimeout).Result()
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to acquire backup lock")
+		return nil, errors.Wrap(err, "failed to acquire backup lock")
+	}
+	if !acquired {
+		code := "BACKUP_IN_PROGRESS"
+		return nil, errs.NewConflictError("A backup is already in progress", true, &code)
+	}
+	defer s.releaseLock(backupCtx, token)
+
+	// The dump is streamed straight into the upload rather than held in memory
+	pr, pw := io.Pipe()
+	dump := &countingWriter{w: pw}
+	dumpErr := make(chan error, 1)
+	go func() {
+		err := s.dumper.Dump(backupCtx, dump)
+		pw.CloseWithError(err)
+		dumpErr <- err
+	}()
+
+	fileName := path.Join(s.server.Config.S3.Prefix, "backups",
+		fmt.Sprintf("db_%s.sql", time.Now().UTC().Format("20060102T150405Z")))
+	key, err := s.uploader.UploadFile(backupCtx, s.server.Config.S3.Bucket, fileName, pr)
+	// Unblocks the dump if the upload stopped reading early. The dump then fails with
+	// io.ErrClosedPipe, which is only worth reporting if the upload claims success.
+	pr.Close()
+	if dErr := <-dumpErr; dErr != nil && (err == nil || !errors.Is(dErr, io.ErrClosedPipe)) {
+		logger.Error().Err(dErr).Msg("failed to dump database")
+		return nil, errors.Wrap(dErr, "failed to dump database")
+	}
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to upload database backup")
+		return nil, errors.Wrap(err, "failed to upload database backup")
+	}
+	size := dump.n
+
+	// Business event log
+	eventLogger := middleware.GetLogger(ctx)
+	eventLogger.Info().
+		Str("event", "backup_completed").
+		Str("key", key).
+		Int64("size", size).
+		Msg("Database backup uploaded successfully")
+
+	return &backup.BackupResponse{
+		Key:         key,
+		Size:        size,
+		CompletedAt: time.Now().UTC(),
+	}, nil
+}
+
+// releaseLockScript deletes the lock only while it still holds our token, in one step so
+// the lock cannot expire and be taken by another backup between the check and the delete
+var releaseLockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// releaseLock frees the lock unless it has already expired and been taken by the next
+// backup, so a backup that overran its lock cannot release someone else's
+func (s *BackupService) releaseLock(ctx context.Context, token string) {
+	if err := releaseLockScript.Run(ctx, s.server.Redis, []string{backupLockKey}, token).Err(); err != nil {
+		s.server.Logger.Warn().Err(err).Msg("failed to release backup lock, it will expire on its own")
+	}
+}
+
+// countingWriter records how many bytes of the dump were written
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
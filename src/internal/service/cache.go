@@ -0,0 +1,106 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/cache"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+// Keys fetched per SCAN round trip; SCAN keeps Redis responsive unlike KEYS
+const cachePurgeScanCount = 500
+
+// cacheNamespaces are the Redis key prefixes that hold purgeable cache entries. Anything
+// outside them (rate limits, job queues) must never be touched by a purge.
+var cacheNamespaces = []string{
+	middleware.CacheKeyPrefix + ":",
+}
+
+type CacheService struct {
+	server     *app.Server
+	namespaces []string
+}
+
+func NewCacheService(s *app.Server) *CacheService {
+	return &CacheService{
+		server:     s,
+		namespaces: cacheNamespaces,
+	}
+}
+
+// PurgeCache deletes cached entries whose key starts with prefix, or every cache entry
+// when prefix is empty, and returns how many keys were removed
+func (s *CacheService) PurgeCache(ctx echo.Context, prefix string) (*cache.PurgeCacheResponse, error) {
+	logger := middleware.GetLogger(ctx)
+
+	prefixes := s.namespaces
+	if prefix != "" {
+		if !s.inNamespace(prefix) {
+			return nil, errs.NewBadRequestError(
+				"prefix must start with one of: "+strings.Join(s.namespaces, ", "), true, nil, nil, nil)
+		}
+		prefixes = []string{prefix}
+	}
+
+	var purged int64
+	for _, p := range prefixes {
+		n, err := s.deleteByPrefix(ctx, p)
+		purged += n
+		if err != nil {
+			logger.Error().Err(err).Str("prefix", p).Int64("purged", purged).Msg("failed to purge cache")
+			return nil, err
+		}
+	}
+
+	// Business event log
+	eventLogger := middleware.GetLogger(ctx)
+	eventLogger.Info().
+		Str("event", "cache_purged").
+		Str("prefix", prefix).
+		Int64("purged", purged).
+		Msg("Cache purged successfully")
+
+	return &cache.PurgeCacheResponse{Purged: purged}, nil
+}
+
+func (s *CacheService) inNamespace(prefix string) bool {
+	for _, ns := range s.namespaces {
+		if strings.HasPrefix(prefix, ns) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *CacheService) deleteByPrefix(ctx echo.Context, prefix string) (int64, error) {
+	reqCtx := ctx.Request().Context()
+	pattern := middleware.EscapeGlob(prefix) + "*"
+
+	var (
+		cursor  uint64
+		deleted int64
+	)
+	for {
+		keys, next, err := s.server.Redis.Scan(reqCtx, cursor, pattern, cachePurgeScanCount).Result()
+		if err != nil {
+			return deleted, errors.Wrap(err, "failed to scan cache keys")
+		}
+
+		if len(keys) > 0 {
+			n, err := s.server.Redis.Del(reqCtx, keys...).Result()
+			if err != nil {
+				return deleted, errors.Wrap(err, "failed to delete cache keys")
+			}
+			deleted += n
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return deleted, nil
+		}
+	}
+}
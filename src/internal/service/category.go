@@ -59,6 +59,20 @@ func (s *CategoryService) GetCategories(ctx echo.Context, userID string,
 	return categories, nil
 }
 
+func (s *CategoryService) GetCategoriesWithTodoCounts(ctx echo.Context, userID string,
+	query *category.GetCategoriesQuery,
+) (*model.PaginatedResponse[category.CategoryWithTodoCount], error) {
+	logger := middleware.GetLogger(ctx)
+
+	categories, err := s.categoryRepo.GetCategoriesWithTodoCounts(ctx.Request().Context(), userID, query)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch categories with todo counts")
+		return nil, err
+	}
+
+	return categories, nil
+}
+
 func (s *CategoryService) GetCategoryByID(ctx echo.Context, userID string, categoryID uuid.UUID) (*category.Category, error) {
 	logger := middleware.GetLogger(ctx)
 
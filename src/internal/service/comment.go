@@ -1,12 +1,17 @@
 package service
 
 import (
+	"errors"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
 	"github.com/Harmeet10000/Fortress_API/src/internal/model/comment"
 	"github.com/Harmeet10000/Fortress_API/src/internal/repository"
 	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
 )
 
 type CommentService struct {
@@ -29,14 +34,22 @@ func (s *CommentService) AddComment(ctx echo.Context, userID string, todoID uuid
 	logger := middleware.GetLogger(ctx)
 
 	// Validate todo exists and belongs to user
-	_, err := s.todoRepo.CheckTodoExists(ctx.Request().Context(), userID, todoID)
+	exists, err := s.todoRepo.Exists(ctx.Request().Context(), userID, todoID)
+	if err == nil && !exists {
+		err = notFound("todo")
+	}
 	if err != nil {
 		logger.Error().Err(err).Msg("todo validation failed")
 		return nil, err
 	}
 
-	commentItem, err := s.commentRepo.AddComment(ctx.Request().Context(), userID, todoID, payload)
+	commentItem, err := s.commentRepo.AddComment(ctx.Request().Context(), userID, todoID, payload, s.maxCommentsPerTodo())
 	if err != nil {
+		var httpErr *errs.HTTPError
+		if errors.As(err, &httpErr) && httpErr.Code == repository.CommentLimitReachedCode {
+			logger.Warn().Err(err).Str("todo_id", todoID.String()).Msg("comment limit reached")
+			return nil, err
+		}
 		logger.Error().Err(err).Msg("failed to add comment")
 		return nil, err
 	}
@@ -56,7 +69,10 @@ func (s *CommentService) GetCommentsByTodoID(ctx echo.Context, userID string, to
 	logger := middleware.GetLogger(ctx)
 
 	// Validate todo exists and belongs to user
-	_, err := s.todoRepo.CheckTodoExists(ctx.Request().Context(), userID, todoID)
+	exists, err := s.todoRepo.Exists(ctx.Request().Context(), userID, todoID)
+	if err == nil && !exists {
+		err = notFound("todo")
+	}
 	if err != nil {
 		logger.Error().Err(err).Msg("todo validation failed")
 		return nil, err
@@ -71,17 +87,22 @@ func (s *CommentService) GetCommentsByTodoID(ctx echo.Context, userID string, to
 	return comments, nil
 }
 
-func (s *CommentService) UpdateComment(ctx echo.Context, userID string, commentID uuid.UUID, content string) (*comment.Comment, error) {
+func (s *CommentService) UpdateComment(ctx echo.Context, userID string, commentID uuid.UUID, content string,
+	expectedUpdatedAt *time.Time,
+) (*comment.Comment, error) {
 	logger := middleware.GetLogger(ctx)
 
 	// Validate comment exists and belongs to user
-	_, err := s.commentRepo.GetCommentByID(ctx.Request().Context(), userID, commentID)
+	exists, err := s.commentRepo.Exists(ctx.Request().Context(), userID, commentID)
+	if err == nil && !exists {
+		err = notFound("comment")
+	}
 	if err != nil {
 		logger.Error().Err(err).Msg("comment validation failed")
 		return nil, err
 	}
 
-	commentItem, err := s.commentRepo.UpdateComment(ctx.Request().Context(), userID, commentID, content)
+	commentItem, err := s.commentRepo.UpdateComment(ctx.Request().Context(), userID, commentID, content, expectedUpdatedAt)
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to update comment")
 		return nil, err
@@ -101,7 +122,10 @@ func (s *CommentService) DeleteComment(ctx echo.Context, userID string, commentI
 	logger := middleware.GetLogger(ctx)
 
 	// Validate comment exists and belongs to user
-	_, err := s.commentRepo.GetCommentByID(ctx.Request().Context(), userID, commentID)
+	exists, err := s.commentRepo.Exists(ctx.Request().Context(), userID, commentID)
+	if err == nil && !exists {
+		err = notFound("comment")
+	}
 	if err != nil {
 		logger.Error().Err(err).Msg("comment validation failed")
 		return err
@@ -122,3 +146,11 @@ func (s *CommentService) DeleteComment(ctx echo.Context, userID string, commentI
 
 	return nil
 }
+
+// maxCommentsPerTodo returns the configured comment limit per todo or its default
+func (s *CommentService) maxCommentsPerTodo() int {
+	if s.server.Config != nil && s.server.Config.Comments != nil && s.server.Config.Comments.MaxPerTodo > 0 {
+		return s.server.Config.Comments.MaxPerTodo
+	}
+	return config.DefaultCommentsConfig().MaxPerTodo
+}
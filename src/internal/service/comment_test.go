@@ -0,0 +1,116 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/comment"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/todo"
+	"github.com/Harmeet10000/Fortress_API/src/internal/repository"
+	"github.com/Harmeet10000/Fortress_API/src/internal/service"
+	"github.com/Harmeet10000/Fortress_API/src/internal/testutil"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentService_AddComment_Limit(t *testing.T) {
+	_, testServer, cleanup := testutil.SetupTest(t)
+	defer cleanup()
+
+	const maxPerTodo = 3
+	testServer.Config.Comments = &config.CommentsConfig{MaxPerTodo: maxPerTodo}
+
+	todoRepo := repository.NewTodoRepository(testServer)
+	commentRepo := repository.NewCommentRepository(testServer)
+	commentService := service.NewCommentService(testServer, commentRepo, todoRepo)
+
+	userID := uuid.New().String()
+	todoItem, err := todoRepo.CreateTodo(context.Background(), userID, &todo.CreateTodoPayload{Title: "Busy todo"})
+	require.NoError(t, err)
+
+	addComment := func() (*comment.Comment, error) {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		c := echo.New().NewContext(req, httptest.NewRecorder())
+		return commentService.AddComment(c, userID, todoItem.ID, &comment.AddCommentPayload{
+			TodoID:  todoItem.ID,
+			Content: "another comment",
+		})
+	}
+
+	for i := 0; i < maxPerTodo; i++ {
+		_, err := addComment()
+		require.NoError(t, err, "comment %d is within the limit", i+1)
+	}
+
+	_, err = addComment()
+	require.Error(t, err)
+
+	var httpErr *errs.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusUnprocessableEntity, httpErr.Status)
+	assert.Equal(t, "COMMENT_LIMIT_REACHED", httpErr.Code)
+	assert.Contains(t, httpErr.Message, "maximum of 3 comments")
+
+	count, err := commentRepo.CountCommentsByTodoID(context.Background(), todoItem.ID)
+	require.NoError(t, err)
+	assert.Equal(t, maxPerTodo, count)
+}
+
+func TestCommentService_AddComment_ConcurrentLimit(t *testing.T) {
+	_, testServer, cleanup := testutil.SetupTest(t)
+	defer cleanup()
+
+	const maxPerTodo = 3
+	testServer.Config.Comments = &config.CommentsConfig{MaxPerTodo: maxPerTodo}
+
+	todoRepo := repository.NewTodoRepository(testServer)
+	commentRepo := repository.NewCommentRepository(testServer)
+	commentService := service.NewCommentService(testServer, commentRepo, todoRepo)
+
+	userID := uuid.New().String()
+	todoItem, err := todoRepo.CreateTodo(context.Background(), userID, &todo.CreateTodoPayload{Title: "Contended todo"})
+	require.NoError(t, err)
+
+	// Every add runs at once, so a count taken before the insert would let them all through
+	const attempts = 10
+	var wg sync.WaitGroup
+	errCh := make(chan error, attempts)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+			_, err := commentService.AddComment(c, userID, todoItem.ID, &comment.AddCommentPayload{
+				TodoID:  todoItem.ID,
+				Content: "racing comment",
+			})
+			errCh <- err
+		}()
+	}
+	wg.Wait()
+	close(errCh)
+
+	rejected := 0
+	for err := range errCh {
+		if err == nil {
+			continue
+		}
+		var httpErr *errs.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, repository.CommentLimitReachedCode, httpErr.Code)
+		rejected++
+	}
+	assert.Equal(t, attempts-maxPerTodo, rejected)
+
+	count, err := commentRepo.CountCommentsByTodoID(context.Background(), todoItem.ID)
+	require.NoError(t, err)
+	assert.Equal(t, maxPerTodo, count)
+}
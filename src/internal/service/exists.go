@@ -0,0 +1,14 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+)
+
+// notFound is returned when an existence check comes back negative, e.g. "todo"
+// yields "todo not found" with code TODO_NOT_FOUND
+func notFound(entity string) error {
+	code := strings.ToUpper(entity) + "_NOT_FOUND"
+	return errs.NewNotFoundError(entity+" not found", false, &code)
+}
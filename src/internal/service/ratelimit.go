@@ -0,0 +1,59 @@
+package service
+
+import (
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/Harmeet10000/Fortress_API/src/internal/ratelimit"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/time/rate"
+)
+
+// rateLimitVisitorTTL is how long an idle client's bucket is kept by the memory store
+const rateLimitVisitorTTL = 3 * time.Minute
+
+type RateLimitService struct {
+	server *app.Server
+	store  ratelimit.Store
+}
+
+func NewRateLimitService(s *app.Server) *RateLimitService {
+	cfg := s.Config.RateLimit
+	if cfg == nil {
+		cfg = config.DefaultRateLimitConfig()
+	}
+
+	limit := rate.Limit(cfg.RequestsPerSecond)
+	burst := cfg.BurstSize()
+
+	var store ratelimit.Store
+	if cfg.Store == "redis" && s.Redis != nil {
+		store = ratelimit.NewRedisStore(s.Redis, limit, burst)
+	} else {
+		store = ratelimit.NewMemoryStore(limit, burst, rateLimitVisitorTTL)
+	}
+
+	return &RateLimitService{
+		server: s,
+		store:  store,
+	}
+}
+
+// Store returns the store backing the global (per IP) and per-user rate limiters
+func (s *RateLimitService) Store() ratelimit.Store {
+	return s.store
+}
+
+func (s *RateLimitService) GetStatus(ctx echo.Context, identifier string) (*ratelimit.Status, error) {
+	logger := middleware.GetLogger(ctx)
+
+	status, err := s.store.Status(identifier)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to read rate limit status")
+		return nil, err
+	}
+
+	return &status, nil
+}
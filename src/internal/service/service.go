@@ -4,17 +4,21 @@ import (
 	"fmt"
 
 	"github.com/Harmeet10000/Fortress_API/src/internal/helper/aws"
+	"github.com/Harmeet10000/Fortress_API/src/internal/helper/backup"
 	"github.com/Harmeet10000/Fortress_API/src/internal/helper/job"
 	"github.com/Harmeet10000/Fortress_API/src/internal/repository"
 	"github.com/Harmeet10000/Fortress_API/src/internal/app"
 )
 
 type Services struct {
-	Auth     *AuthService
-	Job      *job.JobService
-	Todo     *TodoService
-	Comment  *CommentService
-	Category *CategoryService
+	Auth      *AuthService
+	Job       *job.JobService
+	Todo      *TodoService
+	Comment   *CommentService
+	Category  *CategoryService
+	RateLimit *RateLimitService
+	Cache     *CacheService
+	Backup    *BackupService
 }
 
 func NewServices(s *app.Server, repos *repository.Repositories) (*Services, error) {
@@ -27,11 +31,18 @@ func NewServices(s *app.Server, repos *repository.Repositories) (*Services, erro
 		return nil, fmt.Errorf("failed to create AWS client: %w", err)
 	}
 
+	todoService := NewTodoService(s, repos.Todo, repos.Category, awsClient)
+	// Let attachment deletes still in flight reach S3 before the process exits
+	s.RegisterShutdownHook("attachment_cleanup", todoService.WaitForAttachmentCleanup)
+
 	return &Services{
-		Job:      s.Job,
-		Auth:     authService,
-		Category: NewCategoryService(s, repos.Category),
-		Comment:  NewCommentService(s, repos.Comment, repos.Todo),
-		Todo:     NewTodoService(s, repos.Todo, repos.Category, awsClient),
+		Job:       s.Job,
+		Auth:      authService,
+		Category:  NewCategoryService(s, repos.Category),
+		Comment:   NewCommentService(s, repos.Comment, repos.Todo),
+		Todo:      todoService,
+		RateLimit: NewRateLimitService(s),
+		Cache:     NewCacheService(s),
+		Backup:    NewBackupService(s, backup.NewPgDump(s.Config.Database), awsClient.S3),
 	}, nil
 }
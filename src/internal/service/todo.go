@@ -1,12 +1,15 @@
 package service
 
 import (
+	"context"
 	"mime/multipart"
 	"net/http"
+	"sync"
 
 	"github.com/Harmeet10000/Fortress_API/src/internal/app"
 	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
 	"github.com/Harmeet10000/Fortress_API/src/internal/helper/aws"
+	"github.com/Harmeet10000/Fortress_API/src/internal/helper/export"
 	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
 	"github.com/Harmeet10000/Fortress_API/src/internal/model"
 	"github.com/Harmeet10000/Fortress_API/src/internal/model/todo"
@@ -23,6 +26,9 @@ type TodoService struct {
 	todoRepo     *repository.TodoRepository
 	categoryRepo *repository.CategoryRepository
 	awsClient    *aws.AWS
+
+	// attachmentCleanup tracks background S3 deletes so shutdown can wait for them
+	attachmentCleanup sync.WaitGroup
 }
 
 func NewTodoService(server *app.Server, todoRepo *repository.TodoRepository,
@@ -56,7 +62,10 @@ func (s *TodoService) CreateTodo(ctx echo.Context, userID string, payload *todo.
 
 	// Validate category exists and belongs to user (if provided)
 	if payload.CategoryID != nil {
-		_, err := s.categoryRepo.GetCategoryByID(ctx.Request().Context(), userID, *payload.CategoryID)
+		exists, err := s.categoryRepo.Exists(ctx.Request().Context(), userID, *payload.CategoryID)
+		if err == nil && !exists {
+			err = notFound("category")
+		}
 		if err != nil {
 			logger.Error().Err(err).Msg("category validation failed")
 			return nil, err
@@ -111,6 +120,19 @@ func (s *TodoService) GetTodos(ctx echo.Context, userID string, query *todo.GetT
 	return result, nil
 }
 
+// ExportTodos opens a cursor over the user's todos for streaming; the caller closes it
+func (s *TodoService) ExportTodos(ctx echo.Context, userID string) (export.Cursor[todo.Todo], error) {
+	logger := middleware.GetLogger(ctx)
+
+	cursor, err := s.todoRepo.StreamTodos(ctx.Request().Context(), userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to open todo export")
+		return nil, err
+	}
+
+	return cursor, nil
+}
+
 func (s *TodoService) UpdateTodo(ctx echo.Context, userID string, payload *todo.UpdateTodoPayload) (*todo.Todo, error) {
 	logger := middleware.GetLogger(ctx)
 
@@ -139,7 +161,10 @@ func (s *TodoService) UpdateTodo(ctx echo.Context, userID string, payload *todo.
 
 	// Validate category exists and belongs to user (if provided)
 	if payload.CategoryID != nil {
-		_, err := s.categoryRepo.GetCategoryByID(ctx.Request().Context(), userID, *payload.CategoryID)
+		exists, err := s.categoryRepo.Exists(ctx.Request().Context(), userID, *payload.CategoryID)
+		if err == nil && !exists {
+			err = notFound("category")
+		}
 		if err != nil {
 			logger.Error().Err(err).Msg("category validation failed")
 			return nil, err
@@ -173,6 +198,32 @@ func (s *TodoService) UpdateTodo(ctx echo.Context, userID string, payload *todo.
 	return updatedTodo, nil
 }
 
+// UpdateTodoStatus changes only the status. It goes through the same update as
+// UpdateTodo, so completed_at is managed identically on both paths.
+func (s *TodoService) UpdateTodoStatus(ctx echo.Context, userID string, payload *todo.UpdateTodoStatusPayload) (*todo.Todo, error) {
+	logger := middleware.GetLogger(ctx)
+
+	updatedTodo, err := s.todoRepo.UpdateTodo(ctx.Request().Context(), userID, &todo.UpdateTodoPayload{
+		ID:     payload.ID,
+		Status: &payload.Status,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update todo status")
+		return nil, err
+	}
+
+	// Business event log
+	eventLogger := middleware.GetLogger(ctx)
+	eventLogger.Info().
+		Str("event", "todo_status_updated").
+		Str("todo_id", updatedTodo.ID.String()).
+		Str("status", string(updatedTodo.Status)).
+		Bool("completed", updatedTodo.CompletedAt != nil).
+		Msg("Todo status updated successfully")
+
+	return updatedTodo, nil
+}
+
 func (s *TodoService) DeleteTodo(ctx echo.Context, userID string, todoID uuid.UUID) error {
 	logger := middleware.GetLogger(ctx)
 
@@ -204,6 +255,15 @@ func (s *TodoService) GetTodoStats(ctx echo.Context, userID string) (*todo.TodoS
 	return stats, nil
 }
 
+// checkAttachmentsEnabled rejects attachment requests while s3.attachments_enabled is off
+func (s *TodoService) checkAttachmentsEnabled() error {
+	if s.server.Config.S3.AttachmentsOn() {
+		return nil
+	}
+	code := "ATTACHMENTS_DISABLED"
+	return errs.NewConflictError("Attachments are not enabled", true, &code)
+}
+
 func (s *TodoService) UploadTodoAttachment(
 	ctx echo.Context,
 	userID string,
@@ -212,8 +272,15 @@ func (s *TodoService) UploadTodoAttachment(
 ) (*todo.TodoAttachment, error) {
 	logger := middleware.GetLogger(ctx)
 
+	if err := s.checkAttachmentsEnabled(); err != nil {
+		return nil, err
+	}
+
 	// Verify todo exists and belongs to user
-	_, err := s.todoRepo.CheckTodoExists(ctx.Request().Context(), userID, todoID)
+	exists, err := s.todoRepo.Exists(ctx.Request().Context(), userID, todoID)
+	if err == nil && !exists {
+		err = notFound("todo")
+	}
 	if err != nil {
 		logger.Error().Err(err).Msg("todo validation failed")
 		return nil, err
@@ -286,8 +353,15 @@ func (s *TodoService) DeleteTodoAttachment(
 ) error {
 	logger := middleware.GetLogger(ctx)
 
+	if err := s.checkAttachmentsEnabled(); err != nil {
+		return err
+	}
+
 	// Verify todo exists and belongs to user
-	_, err := s.todoRepo.CheckTodoExists(ctx.Request().Context(), userID, todoID)
+	exists, err := s.todoRepo.Exists(ctx.Request().Context(), userID, todoID)
+	if err == nil && !exists {
+		err = notFound("todo")
+	}
 	if err != nil {
 		logger.Error().Err(err).Msg("todo validation failed")
 		return err
@@ -315,10 +389,15 @@ func (s *TodoService) DeleteTodoAttachment(
 		return err
 	}
 
-	// Delete from S3 asynchronously
+	// Delete from S3 asynchronously. The delete is detached from the request, which ends
+	// before it does, and tracked so shutdown waits for it
+	deleteCtx := context.WithoutCancel(ctx.Request().Context())
+	s.attachmentCleanup.Add(1)
 	go func() {
+		defer s.attachmentCleanup.Done()
+
 		err := s.awsClient.S3.DeleteObject(
-			ctx.Request().Context(),
+			deleteCtx,
 			s.server.Config.S3.Bucket,
 			attachment.DownloadKey,
 		)
@@ -335,6 +414,23 @@ func (s *TodoService) DeleteTodoAttachment(
 	return nil
 }
 
+// WaitForAttachmentCleanup blocks until the S3 deletes started by DeleteTodoAttachment
+// have finished or ctx is done. It is registered as the attachment_cleanup shutdown hook.
+func (s *TodoService) WaitForAttachmentCleanup(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.attachmentCleanup.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func (s *TodoService) GetAttachmentPresignedURL(
 	ctx echo.Context,
 	userID string,
@@ -343,8 +439,15 @@ func (s *TodoService) GetAttachmentPresignedURL(
 ) (string, error) {
 	logger := middleware.GetLogger(ctx)
 
+	if err := s.checkAttachmentsEnabled(); err != nil {
+		return "", err
+	}
+
 	// Verify todo exists and belongs to user
-	_, err := s.todoRepo.CheckTodoExists(ctx.Request().Context(), userID, todoID)
+	exists, err := s.todoRepo.Exists(ctx.Request().Context(), userID, todoID)
+	if err == nil && !exists {
+		err = notFound("todo")
+	}
 	if err != nil {
 		logger.Error().Err(err).Msg("todo validation failed")
 		return "", err
@@ -1,4 +1,4 @@
-package testing
+package testutil
 
 import (
 	"context"
@@ -6,11 +6,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/connections"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog"
-	"github.com/sriniously/tasker/internal/config"
-	"github.com/sriniously/tasker/internal/database"
 	"github.com/stretchr/testify/require"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
@@ -22,9 +22,11 @@ type TestDB struct {
 	Config    *config.Config
 }
 
-// SetupTestDB creates a Postgres container and applies migrations
+// SetupTestDB creates a Postgres container and applies migrations. The test is skipped
+// when no Docker daemon is reachable
 func SetupTestDB(t *testing.T) (*TestDB, func()) {
 	t.Helper()
+	testcontainers.SkipIfProviderIsNotHealthy(t)
 
 	ctx := context.Background()
 	dbName := fmt.Sprintf("test_db_%s", uuid.New().String()[:8])
@@ -76,18 +78,16 @@ func SetupTestDB(t *testing.T) (*TestDB, func()) {
 			ConnMaxLifetime: 300,
 			ConnMaxIdleTime: 300,
 		},
-		Primary: config.Primary{
+		Primary: config.PrimaryConfig{
 			Env: "test",
 		},
 		Server: config.ServerConfig{
 			Port:               "8080",
+			ServerURL:          "http://localhost:8080",
 			ReadTimeout:        30,
 			WriteTimeout:       30,
 			IdleTimeout:        30,
-			CORSAllowedOrigins: []string{"*"},
-		},
-		Integration: config.IntegrationConfig{
-			ResendAPIKey: "test-key",
+			CORSAllowedOrigins: "*",
 		},
 		Redis: config.RedisConfig{
 			Address: "localhost:6379",
@@ -99,30 +99,23 @@ func SetupTestDB(t *testing.T) (*TestDB, func()) {
 
 	logger := zerolog.New(zerolog.NewConsoleWriter()).With().Timestamp().Logger()
 
-	var db *database.Database
+	var db *connections.Database
 	var lastErr error
 	for i := 0; i < 5; i++ {
 		// Sleep before first attempt too to give PostgreSQL time to initialize
 		time.Sleep(2 * time.Second)
 
-		db, lastErr = database.New(cfg, &logger, nil)
+		// connections.New pings the database before returning
+		db, lastErr = connections.New(cfg, &logger, nil)
 		if lastErr == nil {
-			// Try a ping to verify the connection
-			if err := db.Pool.Ping(ctx); err == nil {
-				break
-			} else {
-				lastErr = err
-				logger.Warn().Err(err).Msg("Failed to ping database, will retry")
-				db.Pool.Close() // Close the failed connection
-			}
-		} else {
-			logger.Warn().Err(lastErr).Msgf("Failed to connect to database (attempt %d/5)", i+1)
+			break
 		}
+		logger.Warn().Err(lastErr).Msgf("Failed to connect to database (attempt %d/5)", i+1)
 	}
 	require.NoError(t, lastErr, "failed to connect to database after multiple attempts")
 
 	// Apply migrations
-	err = database.Migrate(ctx, &logger, cfg)
+	err = Migrate(ctx, db.Pool, MigrationsDir(t))
 	require.NoError(t, err, "failed to apply database migrations")
 
 	testDB := &TestDB{
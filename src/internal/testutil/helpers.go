@@ -1,18 +1,21 @@
-package testing
+package testutil
 
 import (
 	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/connections"
 	"github.com/rs/zerolog"
-	"github.com/sriniously/tasker/internal/server"
 	"github.com/stretchr/testify/require"
 )
 
 // SetupTest prepares a test environment with a database and server
-func SetupTest(t *testing.T) (*TestDB, *server.Server, func()) {
+func SetupTest(t *testing.T) (*TestDB, *app.Server, func()) {
 	t.Helper()
 
 	logger := zerolog.New(zerolog.ConsoleWriter{Out: os.Stdout}).
@@ -73,3 +76,38 @@ func ProjectRoot(t *testing.T) string {
 func Ptr[T any](v T) *T {
 	return &v
 }
+
+// CreateTestServer creates a server instance for testing
+func CreateTestServer(logger *zerolog.Logger, db *TestDB) *app.Server {
+	// Set up observability config with defaults if not present
+	if db.Config.Observability == nil {
+		db.Config.Observability = &config.ObservabilityConfig{
+			ServiceName: "fortress-api-test",
+			Environment: "test",
+			Logging: config.LoggingConfig{
+				Level:              "info",
+				Format:             "json",
+				SlowQueryThreshold: 100 * time.Millisecond,
+			},
+			NewRelic: config.NewRelicConfig{
+				LicenseKey:                "",    // Empty for tests
+				AppLogForwardingEnabled:   false, // Disabled for tests
+				DistributedTracingEnabled: false, // Disabled for tests
+				DebugLogging:              false, // Disabled for tests
+			},
+			HealthChecks: config.HealthChecksConfig{
+				Enabled: false,
+			},
+		}
+	}
+
+	testServer := &app.Server{
+		Logger: logger,
+		DB: &connections.Database{
+			Pool: db.Pool,
+		},
+		Config: db.Config,
+	}
+
+	return testServer
+}
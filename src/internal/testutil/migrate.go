@@ -0,0 +1,71 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// MigrationsDir returns the goose migrations directory used by `make migrate-up`
+func MigrationsDir(t *testing.T) string {
+	t.Helper()
+
+	return filepath.Join(ProjectRoot(t), "src", "db", "migrations")
+}
+
+// Migrate applies the "-- +goose Up" section of every migration in dir, in file name
+// order. goose is a CLI tool here rather than a dependency, so tests apply the files
+// themselves. A missing dir applies nothing
+func Migrate(ctx context.Context, pool *pgxpool.Pool, dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.sql"))
+	if err != nil {
+		return fmt.Errorf("failed to list migrations: %w", err)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		contents, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read migration %s: %w", filepath.Base(file), err)
+		}
+
+		if _, err := pool.Exec(ctx, gooseUp(string(contents))); err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", filepath.Base(file), err)
+		}
+	}
+
+	return nil
+}
+
+// gooseUp returns the statements between "-- +goose Up" and "-- +goose Down"
+func gooseUp(migration string) string {
+	var up strings.Builder
+	inUp := false
+	for _, line := range strings.Split(migration, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "-- +goose Up"):
+			inUp = true
+			continue
+		case strings.HasPrefix(trimmed, "-- +goose Down"):
+			inUp = false
+			continue
+		case strings.HasPrefix(trimmed, "-- +goose"):
+			// StatementBegin/End only matter to goose's own statement splitting
+			continue
+		}
+
+		if inUp {
+			up.WriteString(line)
+			up.WriteString("\n")
+		}
+	}
+
+	return up.String()
+}
@@ -0,0 +1,183 @@
+package testutil
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/app"
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/connections"
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/Harmeet10000/Fortress_API/src/internal/handler"
+	"github.com/Harmeet10000/Fortress_API/src/internal/middleware"
+	"github.com/Harmeet10000/Fortress_API/src/internal/repository"
+	"github.com/Harmeet10000/Fortress_API/src/internal/router"
+	"github.com/Harmeet10000/Fortress_API/src/internal/service"
+	"github.com/Harmeet10000/Fortress_API/tests/redisfake"
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+// Options controls which dependencies NewTestServer wires into the router. Every field
+// is optional; the zero value gives a router backed by an in-memory Redis and no database.
+type Options struct {
+	// Config replaces the default test configuration from NewTestConfig
+	Config *config.Config
+	// Logger defaults to a no-op logger
+	Logger *zerolog.Logger
+	// Redis defaults to an in-memory fake, see tests/redisfake and TestServer.RedisFake
+	Redis *redis.Client
+	// DB, when set, backs the todo, category and comment services with the real
+	// repositories. Without it those routes still authenticate, then answer 503.
+	DB *connections.Database
+	// BackupDumper and BackupUploader back the admin backup service
+	BackupDumper   service.BackupDumper
+	BackupUploader service.BackupUploader
+	// Services, when set, is called with the wired services before the handlers are
+	// built so a test can swap one out
+	Services func(*service.Services)
+}
+
+// TestServer is a running httptest.Server serving the full router
+type TestServer struct {
+	*httptest.Server
+
+	App      *app.Server
+	Services *service.Services
+	Handlers *handler.Handlers
+	// RedisFake controls the in-memory Redis; nil when Options.Redis was given
+	RedisFake *redisfake.Fake
+
+	clerk *stubClerk
+}
+
+// Token returns a session token for userID holding the organization role (may be
+// empty), to be sent as "Authorization: Bearer <token>"
+func (ts *TestServer) Token(t *testing.T, userID, role string) string {
+	t.Helper()
+
+	return ts.clerk.token(t, userID, role)
+}
+
+// NewTestConfig returns a development configuration that passes the router's
+// middlewares without any external service. Requests from loopback count as internal.
+func NewTestConfig() *config.Config {
+	security := config.DefaultSecurityConfig()
+	security.InternalCIDRs = "127.0.0.0/8,::1/128"
+
+	return &config.Config{
+		Primary: config.PrimaryConfig{Env: "development"},
+		Server: config.ServerConfig{
+			Port:               "8080",
+			ServerURL:          "http://localhost:8080",
+			ReadTimeout:        30,
+			WriteTimeout:       30,
+			IdleTimeout:        60,
+			CORSAllowedOrigins: "http://localhost:3000",
+		},
+		Observability: config.DefaultObservabilityConfig(),
+		Cron:          config.DefaultCronConfig(),
+		RateLimit:     config.DefaultRateLimitConfig(),
+		Worker:        config.DefaultWorkerConfig(),
+		Security:      security,
+		Comments:      config.DefaultCommentsConfig(),
+		Compression:   config.DefaultCompressionConfig(),
+		Breaker:       config.DefaultBreakerConfig(),
+	}
+}
+
+// NewTestServer builds the complete Echo router, with every global middleware, from the
+// dependencies in opts and serves it on a local httptest.Server that is closed when the
+// test ends:
+//
+//	srv := testutil.NewTestServer(t, testutil.Options{})
+//	resp, err := srv.Client().Get(srv.URL + "/api/v1/todos")
+func NewTestServer(t *testing.T, opts Options) *TestServer {
+	t.Helper()
+
+	cfg := opts.Config
+	if cfg == nil {
+		cfg = NewTestConfig()
+	}
+
+	logger := opts.Logger
+	if logger == nil {
+		nop := zerolog.Nop()
+		logger = &nop
+	}
+
+	redisClient := opts.Redis
+	var fake *redisfake.Fake
+	if redisClient == nil {
+		redisClient, fake = redisfake.NewClient()
+	}
+
+	s := &app.Server{
+		Config: cfg,
+		Logger: logger,
+		DB:     opts.DB,
+		Redis:  redisClient,
+	}
+
+	services := &service.Services{
+		RateLimit: service.NewRateLimitService(s),
+		Cache:     service.NewCacheService(s),
+		Backup:    service.NewBackupService(s, opts.BackupDumper, opts.BackupUploader),
+	}
+	if opts.DB != nil {
+		repos := repository.NewRepositories(s)
+		services.Category = service.NewCategoryService(s, repos.Category)
+		services.Comment = service.NewCommentService(s, repos.Comment, repos.Todo)
+		services.Todo = service.NewTodoService(s, repos.Todo, repos.Category, nil)
+	}
+	if opts.Services != nil {
+		opts.Services(services)
+	}
+
+	stub := useStubClerkBackend(t)
+
+	handlers := handler.NewHandlers(s, services)
+	e := router.NewRouter(s, handlers, services)
+	if opts.DB == nil {
+		e.Use(databaseUnavailable(s))
+	}
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	return &TestServer{
+		Server:    srv,
+		App:       s,
+		Services:  services,
+		Handlers:  handlers,
+		RedisFake: fake,
+		clerk:     stub,
+	}
+}
+
+// databaseRoutes are served by the services NewTestServer only builds with a database
+var databaseRoutes = []string{"/api/v1/todos", "/api/v1/categories", "/api/v1/comments"}
+
+// databaseUnavailable stands in for the todo, category and comment services when no
+// database was given: requests still go through RequireAuth, then get a 503 instead of
+// reaching a handler without a service
+func databaseUnavailable(s *app.Server) echo.MiddlewareFunc {
+	auth := middleware.NewAuthMiddleware(s)
+	unavailable := auth.RequireAuth(func(c echo.Context) error {
+		code := "DATABASE_UNAVAILABLE"
+		return errs.NewServiceUnavailableError("No database is configured for this test server", false, &code, 0)
+	})
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			for _, prefix := range databaseRoutes {
+				if strings.HasPrefix(c.Path(), prefix) {
+					return unavailable(c)
+				}
+			}
+			return next(c)
+		}
+	}
+}
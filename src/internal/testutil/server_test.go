@@ -0,0 +1,164 @@
+package testutil_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/config"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/system"
+	"github.com/Harmeet10000/Fortress_API/src/internal/ratelimit"
+	"github.com/Harmeet10000/Fortress_API/src/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTestServer(t *testing.T) {
+	t.Run("serves system routes through the global middlewares", func(t *testing.T) {
+		cfg := testutil.NewTestConfig()
+		cfg.S3.BackupEnabled = true
+		srv := testutil.NewTestServer(t, testutil.Options{Config: cfg})
+
+		req, err := http.NewRequest(http.MethodGet, srv.URL+"/features", nil)
+		require.NoError(t, err)
+		req.Header.Set("X-Request-ID", "harness-test")
+
+		resp, err := srv.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "harness-test", resp.Header.Get("X-Request-ID"))
+
+		var features config.FeatureSet
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&features))
+		assert.True(t, features.Backup)
+	})
+
+	t.Run("serves the server time unauthenticated", func(t *testing.T) {
+		srv := testutil.NewTestServer(t, testutil.Options{})
+
+		resp, err := srv.Client().Get(srv.URL + "/time")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.NotEmpty(t, resp.Header.Get("Date"))
+
+		var serverTime system.ServerTime
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&serverTime))
+		assert.WithinDuration(t, time.Now(), serverTime.Time, 5*time.Second)
+	})
+
+	t.Run("answers undeclared methods with 405 and Allow", func(t *testing.T) {
+		srv := testutil.NewTestServer(t, testutil.Options{})
+
+		resp, err := srv.Client().Post(srv.URL+"/features", "application/json", strings.NewReader("{}"))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get("Allow"), http.MethodGet)
+	})
+
+	t.Run("advertises the methods registered on v1 routes", func(t *testing.T) {
+		srv := testutil.NewTestServer(t, testutil.Options{})
+
+		req, err := http.NewRequest(http.MethodDelete, srv.URL+"/api/v1/categories", nil)
+		require.NoError(t, err)
+		resp, err := srv.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+		assert.Equal(t, "GET, POST", resp.Header.Get("Allow"))
+	})
+
+	t.Run("requires authentication on v1 routes", func(t *testing.T) {
+		srv := testutil.NewTestServer(t, testutil.Options{})
+
+		resp, err := srv.Client().Get(srv.URL + "/api/v1/todos")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("answers unknown routes with 404", func(t *testing.T) {
+		srv := testutil.NewTestServer(t, testutil.Options{})
+
+		resp, err := srv.Client().Get(srv.URL + "/api/v1/does-not-exist")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("serves an authenticated feature route", func(t *testing.T) {
+		srv := testutil.NewTestServer(t, testutil.Options{})
+
+		req, err := http.NewRequest(http.MethodGet, srv.URL+"/api/v1/me/rate-limit", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+srv.Token(t, "user_harness", ""))
+
+		resp, err := srv.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var status ratelimit.Status
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&status))
+		assert.Equal(t, testutil.NewTestConfig().RateLimit.Burst, status.Limit)
+		// The user's own bucket, which this request took a token from
+		assert.Less(t, status.Remaining, status.Limit)
+	})
+
+	t.Run("enforces roles on admin routes", func(t *testing.T) {
+		srv := testutil.NewTestServer(t, testutil.Options{})
+
+		purge := func(role string) *http.Response {
+			req, err := http.NewRequest(http.MethodPost, srv.URL+"/admin/cache/purge", strings.NewReader(`{}`))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+srv.Token(t, "user_harness", role))
+
+			resp, err := srv.Client().Do(req)
+			require.NoError(t, err)
+			return resp
+		}
+
+		member := purge("org:member")
+		member.Body.Close()
+		assert.Equal(t, http.StatusForbidden, member.StatusCode)
+
+		admin := purge("org:admin")
+		defer admin.Body.Close()
+		assert.Equal(t, http.StatusOK, admin.StatusCode)
+		assert.Positive(t, srv.RedisFake.Calls())
+	})
+
+	t.Run("answers database routes with 503 without a database", func(t *testing.T) {
+		srv := testutil.NewTestServer(t, testutil.Options{})
+
+		req, err := http.NewRequest(http.MethodGet, srv.URL+"/api/v1/todos", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+srv.Token(t, "user_harness", ""))
+
+		resp, err := srv.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+		var body struct {
+			Error struct {
+				Code string `json:"code"`
+			} `json:"error"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Equal(t, "DATABASE_UNAVAILABLE", body.Error.Code)
+	})
+}
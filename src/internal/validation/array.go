@@ -0,0 +1,109 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrTooManyItems   = errors.New("array exceeds the maximum number of items")
+	ErrNotJSONArray   = errors.New("request body must be a JSON array")
+	ErrMalformedArray = errors.New("malformed JSON array")
+)
+
+// DecodeJSONArray streams a top-level JSON array element by element and stops as soon
+// as more than maxItems elements are seen, so an enormous array is never fully decoded
+// into memory.
+func DecodeJSONArray[T any](r io.Reader, maxItems int) ([]T, error) {
+	dec := json.NewDecoder(r)
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, ErrNotJSONArray
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return nil, ErrNotJSONArray
+	}
+
+	items := make([]T, 0)
+	for dec.More() {
+		if len(items) == maxItems {
+			return nil, ErrTooManyItems
+		}
+
+		var item T
+		if err := dec.Decode(&item); err != nil {
+			return nil, fmt.Errorf("%w: item %d: %v", ErrMalformedArray, len(items), err)
+		}
+		items = append(items, item)
+	}
+
+	// Consume the closing bracket and make sure nothing follows the array
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedArray, err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("%w: unexpected data after array", ErrMalformedArray)
+	}
+
+	return items, nil
+}
+
+// BindJSONArray decodes a bulk request body, answering 413 once the item cap is hit
+// and 400 for anything that is not a well-formed JSON array
+func BindJSONArray[T any](c echo.Context, maxItems int) ([]T, error) {
+	items, err := DecodeJSONArray[T](c.Request().Body, maxItems)
+	switch {
+	case err == nil:
+		return items, nil
+	case errors.Is(err, ErrTooManyItems):
+		return nil, errs.NewPayloadTooLargeError(
+			fmt.Sprintf("Request may contain at most %d items", maxItems), true)
+	default:
+		return nil, errs.NewBadRequestError(err.Error(), false, nil, nil, nil)
+	}
+}
+
+// BindAndValidateJSONArray decodes a bulk request body like BindJSONArray and then
+// validates every item with v. Field errors from all items are reported together,
+// each prefixed with the item's index (e.g. "[2].title").
+func BindAndValidateJSONArray[T any, PT interface {
+	*T
+	Validatable
+}](c echo.Context, v *Validator, maxItems int) ([]T, error) {
+	items, err := BindJSONArray[T](c, maxItems)
+	if err != nil {
+		return nil, err
+	}
+
+	var fieldErrors []errs.FieldError
+	for i := range items {
+		err := v.Validate(PT(&items[i]))
+		if err == nil {
+			continue
+		}
+
+		switch err.(type) {
+		case validator.ValidationErrors, CustomValidationErrors:
+		default:
+			return nil, err
+		}
+
+		_, itemErrors := extractValidationErrors(err)
+		for _, fieldErr := range itemErrors {
+			fieldErr.Field = fmt.Sprintf("[%d].%s", i, fieldErr.Field)
+			fieldErrors = append(fieldErrors, fieldErr)
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return nil, errs.NewBadRequestError("Validation failed", true, nil, fieldErrors, nil)
+	}
+	return items, nil
+}
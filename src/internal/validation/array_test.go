@@ -0,0 +1,118 @@
+package validation_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bulkItem struct {
+	Title string `json:"title"`
+}
+
+// countingReader reports how many bytes the decoder actually consumed
+type countingReader struct {
+	r    *strings.Reader
+	read int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.read += n
+	return n, err
+}
+
+func TestDecodeJSONArray(t *testing.T) {
+	t.Run("array at the cap is accepted", func(t *testing.T) {
+		items, err := validation.DecodeJSONArray[bulkItem](
+			strings.NewReader(`[{"title":"a"},{"title":"b"},{"title":"c"}]`), 3)
+
+		require.NoError(t, err)
+		assert.Equal(t, []bulkItem{{Title: "a"}, {Title: "b"}, {Title: "c"}}, items)
+	})
+
+	t.Run("array over the cap is rejected", func(t *testing.T) {
+		_, err := validation.DecodeJSONArray[bulkItem](
+			strings.NewReader(`[{"title":"a"},{"title":"b"},{"title":"c"},{"title":"d"}]`), 3)
+
+		assert.ErrorIs(t, err, validation.ErrTooManyItems)
+	})
+
+	t.Run("cap is enforced during decode", func(t *testing.T) {
+		body := "[" + strings.Repeat(`{"title":"x"},`, 100000) + `{"title":"x"}]`
+		reader := &countingReader{r: strings.NewReader(body)}
+
+		_, err := validation.DecodeJSONArray[bulkItem](reader, 10)
+
+		assert.ErrorIs(t, err, validation.ErrTooManyItems)
+		assert.Less(t, reader.read, len(body)/10, "decoding should stop long before the end of the body")
+	})
+
+	t.Run("non-array and malformed bodies", func(t *testing.T) {
+		_, err := validation.DecodeJSONArray[bulkItem](strings.NewReader(`{"title":"a"}`), 3)
+		assert.ErrorIs(t, err, validation.ErrNotJSONArray)
+
+		_, err = validation.DecodeJSONArray[bulkItem](strings.NewReader(`[{"title":"a"},`), 3)
+		assert.ErrorIs(t, err, validation.ErrMalformedArray)
+
+		_, err = validation.DecodeJSONArray[bulkItem](strings.NewReader(`[] []`), 3)
+		assert.ErrorIs(t, err, validation.ErrMalformedArray)
+	})
+}
+
+func TestBindJSONArray(t *testing.T) {
+	bind := func(body string) error {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/bulk", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		c := e.NewContext(req, httptest.NewRecorder())
+
+		_, err := validation.BindJSONArray[bulkItem](c, 2)
+		return err
+	}
+
+	require.NoError(t, bind(`[{"title":"a"},{"title":"b"}]`))
+
+	var httpErr *errs.HTTPError
+	require.ErrorAs(t, bind(`[{"title":"a"},{"title":"b"},{"title":"c"}]`), &httpErr)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, httpErr.Status)
+
+	require.ErrorAs(t, bind(`"not an array"`), &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+}
+
+type bulkTodo struct {
+	ID    json.Number `json:"id"`
+	Title string      `json:"title" validate:"required,max=20"`
+}
+
+func (t *bulkTodo) Validate() error {
+	return nil
+}
+
+func TestBindAndValidateJSONArray(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/todos/bulk",
+		strings.NewReader(`[{"title":"ok"},{"title":""},{"title":"`+strings.Repeat("a", 21)+`"}]`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	items, err := validation.BindAndValidateJSONArray[bulkTodo](c, validation.NewValidator(), 10)
+
+	var httpErr *errs.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+	assert.Equal(t, []errs.FieldError{
+		{Field: "[1].title", Error: "is required"},
+		{Field: "[2].title", Error: "must not exceed 20 characters"},
+	}, httpErr.Errors)
+	assert.Nil(t, items)
+}
@@ -0,0 +1,150 @@
+package validation
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const invalidParameterCode = "INVALID_PARAMETER"
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	uuidType            = reflect.TypeOf(uuid.UUID{})
+	timeType            = reflect.TypeOf(time.Time{})
+)
+
+// bindPayload binds path, query and body values into payload. Every failure, including
+// a panic inside the binder, becomes a 400 that names the offending parameter when it
+// can be identified.
+func bindPayload(c echo.Context, payload Validatable) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errs.NewBadRequestError("Request could not be bound", false, nil, nil, nil)
+		}
+	}()
+
+	bindErr := c.Bind(payload)
+	if bindErr == nil {
+		return nil
+	}
+
+	if fieldErr, source, ok := findInvalidParam(c, payload); ok {
+		code := invalidParameterCode
+		return errs.NewBadRequestError(
+			fmt.Sprintf("Invalid %s parameter %q", source, fieldErr.Field), true, &code,
+			[]errs.FieldError{fieldErr}, nil)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(bindErr, &typeErr) && typeErr.Field != "" {
+		return errs.NewBadRequestError(
+			fmt.Sprintf("Invalid value for field %q", typeErr.Field), true, nil,
+			[]errs.FieldError{{Field: typeErr.Field, Error: "must be a valid " + describeType(typeErr.Type)}}, nil)
+	}
+
+	message := "Invalid request"
+	var echoErr *echo.HTTPError
+	if errors.As(bindErr, &echoErr) {
+		if msg, ok := echoErr.Message.(string); ok {
+			message = msg
+		}
+	}
+	return errs.NewBadRequestError(message, false, nil, nil, nil)
+}
+
+// findInvalidParam re-checks each path and query value against the field it binds to
+// and reports the first one that cannot be converted
+func findInvalidParam(c echo.Context, payload Validatable) (errs.FieldError, string, bool) {
+	t := reflect.TypeOf(payload)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return errs.FieldError{}, "", false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if name := field.Tag.Get("param"); name != "" {
+			if value := c.Param(name); value != "" && !parsesAs(value, field.Type) {
+				return invalidParam(name, field.Type), "path", true
+			}
+		}
+
+		if name := field.Tag.Get("query"); name != "" {
+			for _, value := range c.QueryParams()[name] {
+				if !parsesAs(value, field.Type) {
+					return invalidParam(name, field.Type), "query", true
+				}
+			}
+		}
+	}
+
+	return errs.FieldError{}, "", false
+}
+
+func invalidParam(name string, t reflect.Type) errs.FieldError {
+	return errs.FieldError{Field: name, Error: "must be a valid " + describeType(t)}
+}
+
+// parsesAs reports whether value converts to t the way Echo's binder converts it
+func parsesAs(value string, t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		target := reflect.New(t).Interface().(encoding.TextUnmarshaler)
+		return target.UnmarshalText([]byte(value)) == nil
+	}
+
+	var err error
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		_, err = strconv.ParseInt(value, 10, t.Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		_, err = strconv.ParseUint(value, 10, t.Bits())
+	case reflect.Float32, reflect.Float64:
+		_, err = strconv.ParseFloat(value, t.Bits())
+	case reflect.Bool:
+		_, err = strconv.ParseBool(value)
+	}
+	return err == nil
+}
+
+func describeType(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == uuidType:
+		return "UUID"
+	case t == timeType:
+		return "RFC 3339 timestamp"
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.String:
+		return "string"
+	default:
+		return "value"
+	}
+}
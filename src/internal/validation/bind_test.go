@@ -0,0 +1,63 @@
+package validation_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/Harmeet10000/Fortress_API/src/internal/model/todo"
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bindTodosQuery(t *testing.T, target string) error {
+	t.Helper()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	return validation.NewValidator().BindAndValidate(c, &todo.GetTodosQuery{})
+}
+
+func TestValidator_BindAndValidate_BindErrors(t *testing.T) {
+	t.Run("non-numeric limit", func(t *testing.T) {
+		var httpErr *errs.HTTPError
+		require.ErrorAs(t, bindTodosQuery(t, "/todos?page=1&limit=ten"), &httpErr)
+
+		assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+		assert.Equal(t, "INVALID_PARAMETER", httpErr.Code)
+		assert.Equal(t, `Invalid query parameter "limit"`, httpErr.Message)
+		assert.Equal(t, []errs.FieldError{{Field: "limit", Error: "must be a valid integer"}}, httpErr.Errors)
+	})
+
+	t.Run("malformed UUID", func(t *testing.T) {
+		var httpErr *errs.HTTPError
+		require.ErrorAs(t, bindTodosQuery(t, "/todos?categoryId=not-a-uuid"), &httpErr)
+
+		assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+		assert.Equal(t, "INVALID_PARAMETER", httpErr.Code)
+		assert.Equal(t, []errs.FieldError{{Field: "categoryId", Error: "must be a valid UUID"}}, httpErr.Errors)
+	})
+
+	t.Run("wrong JSON type names the body field", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/todos", strings.NewReader(`{"title":42}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		c := e.NewContext(req, httptest.NewRecorder())
+
+		var httpErr *errs.HTTPError
+		require.ErrorAs(t, validation.NewValidator().BindAndValidate(c, &todo.CreateTodoPayload{}), &httpErr)
+
+		assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+		assert.Equal(t, []errs.FieldError{{Field: "title", Error: "must be a valid string"}}, httpErr.Errors)
+	})
+
+	t.Run("valid query binds", func(t *testing.T) {
+		require.NoError(t, bindTodosQuery(t, "/todos?limit=10&categoryId=0b9f6a3c-4a57-4f7e-9d55-0c4a7f0d2b11"))
+	})
+}
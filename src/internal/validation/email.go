@@ -0,0 +1,23 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
+
+func IsValidEmail(email string) bool {
+	return emailRegex.MatchString(email)
+}
+
+// NormalizeEmail trims and lowercases an address so the same mailbox always compares
+// equal, e.g. when deduplicating sends. It fails when the result is not a valid address.
+func NormalizeEmail(email string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(email))
+	if !IsValidEmail(normalized) {
+		return "", fmt.Errorf("invalid email address %q", email)
+	}
+	return normalized, nil
+}
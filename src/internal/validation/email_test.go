@@ -0,0 +1,34 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/Harmeet10000/Fortress_API/src/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	t.Run("variants of the same address normalize consistently", func(t *testing.T) {
+		variants := []string{
+			"ada@example.com",
+			"  ada@example.com",
+			"ada@example.com\n",
+			"Ada@Example.COM",
+			"\t ADA@EXAMPLE.COM  ",
+		}
+
+		for _, variant := range variants {
+			normalized, err := validation.NormalizeEmail(variant)
+			require.NoError(t, err, variant)
+			assert.Equal(t, "ada@example.com", normalized, variant)
+		}
+	})
+
+	t.Run("invalid addresses are rejected", func(t *testing.T) {
+		for _, invalid := range []string{"", "   ", "ada", "ada@example", "ada @example.com", "@example.com"} {
+			_, err := validation.NormalizeEmail(invalid)
+			assert.Error(t, err, invalid)
+		}
+	})
+}
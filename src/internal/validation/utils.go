@@ -6,11 +6,12 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/go-playground/validator/v10"
-	"github.com/labstack/echo/v4"
 	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/go-playground/validator/v10"
 )
 
+// Validatable is implemented by every request DTO. Struct tags are checked by the
+// Validator first, so Validate only needs the rules tags cannot express.
 type Validatable interface {
 	Validate() error
 }
@@ -26,26 +27,6 @@ func (c CustomValidationErrors) Error() string {
 	return "Validation failed"
 }
 
-func BindAndValidate(c echo.Context, payload Validatable) error {
-	if err := c.Bind(payload); err != nil {
-		message := strings.Split(strings.Split(err.Error(), ",")[1], "message=")[1]
-		return errs.NewBadRequestError(message, false, nil, nil, nil)
-	}
-
-	if msg, fieldErrors := validateStruct(payload); fieldErrors != nil {
-		return errs.NewBadRequestError(msg, true, nil, fieldErrors, nil)
-	}
-
-	return nil
-}
-
-func validateStruct(v Validatable) (string, []errs.FieldError) {
-	if err := v.Validate(); err != nil {
-		return extractValidationErrors(err)
-	}
-	return "", nil
-}
-
 func extractValidationErrors(err error) (string, []errs.FieldError) {
 	var fieldErrors []errs.FieldError
 	validationErrors, ok := err.(validator.ValidationErrors)
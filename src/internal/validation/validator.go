@@ -0,0 +1,50 @@
+package validation
+
+import (
+	"github.com/Harmeet10000/Fortress_API/src/internal/errs"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+// Validator is the single request validator shared by every handler. It checks the
+// `validate` struct tags once and then runs the payload's own Validate hook for rules
+// the tags cannot express (defaults, cross-field checks).
+type Validator struct {
+	validate *validator.Validate
+}
+
+var _ echo.Validator = (*Validator)(nil)
+
+// NewValidator builds a Validator. go-playground caches struct metadata per instance,
+// so create one at startup and pass it around instead of building one per request.
+func NewValidator() *Validator {
+	return &Validator{validate: validator.New()}
+}
+
+// Validate implements echo.Validator so c.Validate goes through the same rules as handlers
+func (v *Validator) Validate(i interface{}) error {
+	if err := v.validate.Struct(i); err != nil {
+		return err
+	}
+
+	if payload, ok := i.(Validatable); ok {
+		return payload.Validate()
+	}
+
+	return nil
+}
+
+// BindAndValidate binds the request into payload and validates it, mapping failures
+// to 400 responses with per-field errors
+func (v *Validator) BindAndValidate(c echo.Context, payload Validatable) error {
+	if err := bindPayload(c, payload); err != nil {
+		return err
+	}
+
+	if err := v.Validate(payload); err != nil {
+		msg, fieldErrors := extractValidationErrors(err)
+		return errs.NewBadRequestError(msg, true, nil, fieldErrors, nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,255 @@
+package redisfake
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Fake is a go-redis hook that serves the handful of commands the app uses (PING, GET,
+// SET, SET NX, SCAN, DEL and the compare-and-delete script) from memory, so tests
+// exercise a real *redis.Client without a server
+type Fake struct {
+	mu      sync.Mutex
+	values  map[string][]byte
+	expires map[string]time.Time
+	// err, when set, is returned for every command to simulate an unavailable Redis
+	err   error
+	calls int
+	// hang, when set, leaves every command unanswered until its context is done
+	hang bool
+}
+
+// NewClient returns a client whose commands are all answered by the returned fake
+func NewClient() (*redis.Client, *Fake) {
+	fake := &Fake{
+		values:  make(map[string][]byte),
+		expires: make(map[string]time.Time),
+	}
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"})
+	client.AddHook(fake)
+	return client, fake
+}
+
+// SetErr makes every subsequent command fail with err, simulating an unavailable Redis.
+// Pass nil to recover.
+func (f *Fake) SetErr(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+// Hang makes every subsequent command block until its context is done, simulating a
+// Redis that accepted the connection but stopped responding
+func (f *Fake) Hang() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.hang = true
+}
+
+// Calls returns how many commands reached the fake
+func (f *Fake) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func (f *Fake) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, context.Canceled
+	}
+}
+
+func (f *Fake) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+func (f *Fake) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		f.mu.Lock()
+		f.calls++
+		hang := f.hang
+		f.mu.Unlock()
+		if hang {
+			<-ctx.Done()
+			cmd.SetErr(ctx.Err())
+			return ctx.Err()
+		}
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		if f.err != nil {
+			cmd.SetErr(f.err)
+			return f.err
+		}
+
+		args := cmd.Args()
+		switch strings.ToLower(cmd.Name()) {
+		case "ping":
+			cmd.(*redis.StatusCmd).SetVal("PONG")
+		case "get":
+			key := args[1].(string)
+			value, ok := f.lookup(key)
+			c := cmd.(*redis.StringCmd)
+			if !ok {
+				c.SetErr(redis.Nil)
+				return redis.Nil
+			}
+			c.SetVal(string(value))
+		case "set":
+			key := args[1].(string)
+			var nx bool
+			for _, arg := range args[3:] {
+				nx = nx || strings.EqualFold(toString(arg), "nx")
+			}
+			if _, exists := f.lookup(key); nx && exists {
+				cmd.(*redis.BoolCmd).SetVal(false)
+				return nil
+			}
+
+			f.values[key] = toBytes(args[2])
+			delete(f.expires, key)
+			if len(args) >= 5 {
+				n, _ := strconv.Atoi(toString(args[4]))
+				unit := time.Second
+				if strings.EqualFold(toString(args[3]), "px") {
+					unit = time.Millisecond
+				}
+				f.expires[key] = time.Now().Add(time.Duration(n) * unit)
+			}
+
+			// SET ... NX (SetNX) reports whether the key was set
+			if c, ok := cmd.(*redis.BoolCmd); ok {
+				c.SetVal(true)
+			} else {
+				cmd.(*redis.StatusCmd).SetVal("OK")
+			}
+		case "scan":
+			pattern := "*"
+			for i := 2; i+1 < len(args); i++ {
+				if strings.EqualFold(toString(args[i]), "match") {
+					pattern = toString(args[i+1])
+				}
+			}
+			var keys []string
+			for key := range f.values {
+				if _, ok := f.lookup(key); ok && matchPrefixPattern(pattern, key) {
+					keys = append(keys, key)
+				}
+			}
+			cmd.(*redis.ScanCmd).SetVal(keys, 0)
+		case "del":
+			var deleted int64
+			for _, arg := range args[1:] {
+				key := toString(arg)
+				if _, ok := f.values[key]; ok {
+					delete(f.values, key)
+					delete(f.expires, key)
+					deleted++
+				}
+			}
+			cmd.(*redis.IntCmd).SetVal(deleted)
+		case "evalsha":
+			// Scripts are never cached, so redis.Script.Run falls back to EVAL
+			err := replyError("NOSCRIPT No matching script")
+			cmd.SetErr(err)
+			return err
+		case "eval":
+			src := toString(args[1])
+			if !isCompareAndDelete(src) || len(args) < 5 {
+				return next(ctx, cmd)
+			}
+			key := toString(args[3])
+			var deleted int64
+			if value, ok := f.lookup(key); ok && string(value) == toString(args[4]) {
+				delete(f.values, key)
+				delete(f.expires, key)
+				deleted = 1
+			}
+			cmd.(*redis.Cmd).SetVal(deleted)
+		default:
+			return next(ctx, cmd)
+		}
+		return nil
+	}
+}
+
+// Keys returns the live keys currently stored
+func (f *Fake) Keys() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var keys []string
+	for key := range f.values {
+		if _, ok := f.lookup(key); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func (f *Fake) lookup(key string) ([]byte, bool) {
+	value, ok := f.values[key]
+	if !ok {
+		return nil, false
+	}
+	if exp, ok := f.expires[key]; ok && !time.Now().Before(exp) {
+		delete(f.values, key)
+		delete(f.expires, key)
+		return nil, false
+	}
+	return value, true
+}
+
+// replyError is an error reply from the server, as redis.HasErrorPrefix expects
+type replyError string
+
+func (e replyError) Error() string { return string(e) }
+
+func (replyError) RedisError() {}
+
+// isCompareAndDelete recognises the "DEL KEYS[1] if it still holds ARGV[1]" script used to
+// release locks
+func isCompareAndDelete(src string) bool {
+	return strings.Contains(src, "redis.call('GET', KEYS[1]) == ARGV[1]") &&
+		strings.Contains(src, "redis.call('DEL', KEYS[1])")
+}
+
+// matchPrefixPattern supports the "<escaped literal>*" patterns the middlewares issue
+func matchPrefixPattern(pattern, key string) bool {
+	literal := strings.TrimSuffix(pattern, "*")
+	literal = strings.NewReplacer(`\*`, `*`, `\?`, `?`, `\[`, `[`, `\]`, `]`, `\\`, `\`).Replace(literal)
+	return strings.HasPrefix(key, literal)
+}
+
+func toBytes(v interface{}) []byte {
+	switch b := v.(type) {
+	case []byte:
+		return b
+	case string:
+		return []byte(b)
+	default:
+		return []byte(toString(v))
+	}
+}
+
+func toString(v interface{}) string {
+	switch s := v.(type) {
+	case string:
+		return s
+	case []byte:
+		return string(s)
+	case int64:
+		return strconv.FormatInt(s, 10)
+	case int:
+		return strconv.Itoa(s)
+	default:
+		return ""
+	}
+}
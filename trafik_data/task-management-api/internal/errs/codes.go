@@ -0,0 +1,44 @@
+package errs
+
+import "strings"
+
+// Code is a stable, machine-readable identifier for a specific error. Unlike ErrorType,
+// which only groups errors into broad categories, a Code names the exact condition so
+// clients can branch on it. Codes are part of the API contract: never rename one.
+type Code string
+
+const (
+	// Generic codes, used when nothing more specific applies. They match ErrorType.
+	CodeValidationFailed Code = "VALIDATION_ERROR"
+	CodeNotFound         Code = "NOT_FOUND"
+	CodeUnauthorized     Code = "UNAUTHORIZED"
+	CodeForbidden        Code = "FORBIDDEN"
+	CodeConflict         Code = "CONFLICT"
+	CodeInternal         Code = "INTERNAL_ERROR"
+	CodeBadRequest       Code = "BAD_REQUEST"
+	CodeUnprocessable    Code = "UNPROCESSABLE_ENTITY"
+	CodeRateLimited      Code = "RATE_LIMITED"
+
+	// Request decoding
+	CodeInvalidRequestBody Code = "INVALID_REQUEST_BODY"
+	CodeInvalidQuery       Code = "INVALID_QUERY_PARAMETERS"
+	CodeInvalidID          Code = "INVALID_ID"
+
+	// Resources
+	CodeTodoNotFound     Code = "TODO_NOT_FOUND"
+	CodeCategoryNotFound Code = "CATEGORY_NOT_FOUND"
+	CodeCommentNotFound  Code = "COMMENT_NOT_FOUND"
+
+	// Categories
+	CodeCategoryNameTaken Code = "CATEGORY_NAME_TAKEN"
+)
+
+// defaultCode is the code an error carries when its construction site sets none
+func defaultCode(errType ErrorType) Code {
+	return Code(errType)
+}
+
+// notFoundCode derives the resource specific code, e.g. "Category" -> CATEGORY_NOT_FOUND
+func notFoundCode(resource string) Code {
+	return Code(strings.ToUpper(strings.ReplaceAll(resource, " ", "_")) + "_NOT_FOUND")
+}
@@ -24,6 +24,7 @@ const (
 // AppError represents an application error with context
 type AppError struct {
 	Type       ErrorType              `json:"type"`
+	Code       Code                   `json:"code"`
 	Message    string                 `json:"message"`
 	StatusCode int                    `json:"-"`
 	Details    map[string]interface{} `json:"details,omitempty"`
@@ -46,6 +47,7 @@ func (e *AppError) Unwrap() error {
 // ErrorResponse represents the JSON error response
 type ErrorResponse struct {
 	Type    ErrorType              `json:"type"`
+	Code    Code                   `json:"code"`
 	Message string                 `json:"message"`
 	Details map[string]interface{} `json:"details,omitempty"`
 }
@@ -54,6 +56,7 @@ type ErrorResponse struct {
 func (e *AppError) ToErrorResponse() ErrorResponse {
 	return ErrorResponse{
 		Type:    e.Type,
+		Code:    e.Code,
 		Message: e.Message,
 		Details: e.Details,
 	}
@@ -63,6 +66,7 @@ func (e *AppError) ToErrorResponse() ErrorResponse {
 func New(errType ErrorType, message string) *AppError {
 	return &AppError{
 		Type:       errType,
+		Code:       defaultCode(errType),
 		Message:    message,
 		StatusCode: getStatusCode(errType),
 	}
@@ -72,6 +76,7 @@ func New(errType ErrorType, message string) *AppError {
 func Wrap(err error, errType ErrorType, message string) *AppError {
 	return &AppError{
 		Type:       errType,
+		Code:       defaultCode(errType),
 		Message:    message,
 		StatusCode: getStatusCode(errType),
 		Err:        err,
@@ -84,6 +89,12 @@ func (e *AppError) WithDetails(details map[string]interface{}) *AppError {
 	return e
 }
 
+// WithCode sets the specific catalog code for an error
+func (e *AppError) WithCode(code Code) *AppError {
+	e.Code = code
+	return e
+}
+
 // getStatusCode returns the HTTP status code for an error type
 func getStatusCode(errType ErrorType) int {
 	switch errType {
@@ -135,7 +146,7 @@ func NewValidationError(message string, details map[string]interface{}) *AppErro
 
 // NewNotFoundError creates a new not found error
 func NewNotFoundError(resource string) *AppError {
-	return New(ErrorTypeNotFound, fmt.Sprintf("%s not found", resource))
+	return New(ErrorTypeNotFound, fmt.Sprintf("%s not found", resource)).WithCode(notFoundCode(resource))
 }
 
 // NewConflictError creates a new conflict error
@@ -143,6 +154,11 @@ func NewConflictError(message string) *AppError {
 	return New(ErrorTypeConflict, message)
 }
 
+// NewCategoryNameTakenError reports that another category already uses the name
+func NewCategoryNameTakenError() *AppError {
+	return NewConflictError("Category with this name already exists").WithCode(CodeCategoryNameTaken)
+}
+
 // NewInternalError creates a new internal error
 func NewInternalError(message string, err error) *AppError {
 	return Wrap(err, ErrorTypeInternal, message)
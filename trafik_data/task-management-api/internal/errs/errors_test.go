@@ -0,0 +1,56 @@
+package errs_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/yourusername/task-management-api/internal/errs"
+)
+
+func TestNewCategoryNameTakenError(t *testing.T) {
+	err := errs.NewCategoryNameTakenError()
+
+	if err.StatusCode != http.StatusConflict {
+		t.Fatalf("status = %d, want %d", err.StatusCode, http.StatusConflict)
+	}
+	if err.Type != errs.ErrorTypeConflict {
+		t.Fatalf("type = %q, want %q", err.Type, errs.ErrorTypeConflict)
+	}
+	if err.Code != errs.CodeCategoryNameTaken {
+		t.Fatalf("code = %q, want %q", err.Code, errs.CodeCategoryNameTaken)
+	}
+
+	body, marshalErr := json.Marshal(err.ToErrorResponse())
+	if marshalErr != nil {
+		t.Fatalf("marshal error response: %v", marshalErr)
+	}
+
+	var response map[string]interface{}
+	if unmarshalErr := json.Unmarshal(body, &response); unmarshalErr != nil {
+		t.Fatalf("unmarshal error response: %v", unmarshalErr)
+	}
+	if response["code"] != "CATEGORY_NAME_TAKEN" {
+		t.Fatalf("response code = %v, want CATEGORY_NAME_TAKEN", response["code"])
+	}
+}
+
+func TestAppErrorCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *errs.AppError
+		want errs.Code
+	}{
+		{"generic error uses its type", errs.New(errs.ErrorTypeBadRequest, "Bad"), errs.CodeBadRequest},
+		{"not found names the resource", errs.NewNotFoundError("Category"), errs.CodeCategoryNotFound},
+		{"explicit code wins", errs.New(errs.ErrorTypeBadRequest, "Invalid todo ID").WithCode(errs.CodeInvalidID), errs.CodeInvalidID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err.Code != tt.want {
+				t.Fatalf("code = %q, want %q", tt.err.Code, tt.want)
+			}
+		})
+	}
+}
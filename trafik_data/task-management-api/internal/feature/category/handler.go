@@ -38,7 +38,7 @@ func NewHandler(service *Service, logger *zerolog.Logger) *Handler {
 func (h *Handler) Create(c echo.Context) error {
 	var req CreateCategoryRequest
 	if err := c.Bind(&req); err != nil {
-		return errs.New(errs.ErrorTypeBadRequest, "Invalid request body")
+		return errs.New(errs.ErrorTypeBadRequest, "Invalid request body").WithCode(errs.CodeInvalidRequestBody)
 	}
 
 	category, err := h.service.Create(c.Request().Context(), &req)
@@ -63,7 +63,7 @@ func (h *Handler) Create(c echo.Context) error {
 func (h *Handler) GetByID(c echo.Context) error {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		return errs.New(errs.ErrorTypeBadRequest, "Invalid category ID")
+		return errs.New(errs.ErrorTypeBadRequest, "Invalid category ID").WithCode(errs.CodeInvalidID)
 	}
 
 	category, err := h.service.GetByID(c.Request().Context(), id)
@@ -88,7 +88,7 @@ func (h *Handler) GetByID(c echo.Context) error {
 func (h *Handler) List(c echo.Context) error {
 	var req ListCategoriesRequest
 	if err := c.Bind(&req); err != nil {
-		return errs.New(errs.ErrorTypeBadRequest, "Invalid query parameters")
+		return errs.New(errs.ErrorTypeBadRequest, "Invalid query parameters").WithCode(errs.CodeInvalidQuery)
 	}
 
 	categories, err := h.service.List(c.Request().Context(), &req)
@@ -116,12 +116,12 @@ func (h *Handler) List(c echo.Context) error {
 func (h *Handler) Update(c echo.Context) error {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		return errs.New(errs.ErrorTypeBadRequest, "Invalid category ID")
+		return errs.New(errs.ErrorTypeBadRequest, "Invalid category ID").WithCode(errs.CodeInvalidID)
 	}
 
 	var req UpdateCategoryRequest
 	if err := c.Bind(&req); err != nil {
-		return errs.New(errs.ErrorTypeBadRequest, "Invalid request body")
+		return errs.New(errs.ErrorTypeBadRequest, "Invalid request body").WithCode(errs.CodeInvalidRequestBody)
 	}
 
 	category, err := h.service.Update(c.Request().Context(), id, &req)
@@ -145,7 +145,7 @@ func (h *Handler) Update(c echo.Context) error {
 func (h *Handler) Delete(c echo.Context) error {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		return errs.New(errs.ErrorTypeBadRequest, "Invalid category ID")
+		return errs.New(errs.ErrorTypeBadRequest, "Invalid category ID").WithCode(errs.CodeInvalidID)
 	}
 
 	if err := h.service.Delete(c.Request().Context(), id); err != nil {
@@ -20,7 +20,7 @@ func NewHandler(service *Service, logger *zerolog.Logger) *Handler {
 func (h *Handler) Create(c echo.Context) error {
 	var req CreateCommentRequest
 	if err := c.Bind(&req); err != nil {
-		return errs.New(errs.ErrorTypeBadRequest, "Invalid request body")
+		return errs.New(errs.ErrorTypeBadRequest, "Invalid request body").WithCode(errs.CodeInvalidRequestBody)
 	}
 	comment, err := h.service.Create(c.Request().Context(), &req)
 	if err != nil {
@@ -32,7 +32,7 @@ func (h *Handler) Create(c echo.Context) error {
 func (h *Handler) GetByID(c echo.Context) error {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		return errs.New(errs.ErrorTypeBadRequest, "Invalid comment ID")
+		return errs.New(errs.ErrorTypeBadRequest, "Invalid comment ID").WithCode(errs.CodeInvalidID)
 	}
 	comment, err := h.service.GetByID(c.Request().Context(), id)
 	if err != nil {
@@ -44,13 +44,13 @@ func (h *Handler) GetByID(c echo.Context) error {
 func (h *Handler) ListByTodoID(c echo.Context) error {
 	todoID, err := uuid.Parse(c.Param("todoId"))
 	if err != nil {
-		return errs.New(errs.ErrorTypeBadRequest, "Invalid todo ID")
+		return errs.New(errs.ErrorTypeBadRequest, "Invalid todo ID").WithCode(errs.CodeInvalidID)
 	}
 	
 	var req ListCommentsRequest
 	req.TodoID = todoID
 	if err := c.Bind(&req); err != nil {
-		return errs.New(errs.ErrorTypeBadRequest, "Invalid query parameters")
+		return errs.New(errs.ErrorTypeBadRequest, "Invalid query parameters").WithCode(errs.CodeInvalidQuery)
 	}
 	
 	comments, err := h.service.ListByTodoID(c.Request().Context(), &req)
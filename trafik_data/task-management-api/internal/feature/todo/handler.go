@@ -20,7 +20,7 @@ func NewHandler(service *Service, logger *zerolog.Logger) *Handler {
 func (h *Handler) Create(c echo.Context) error {
 	var req CreateTodoRequest
 	if err := c.Bind(&req); err != nil {
-		return errs.New(errs.ErrorTypeBadRequest, "Invalid request body")
+		return errs.New(errs.ErrorTypeBadRequest, "Invalid request body").WithCode(errs.CodeInvalidRequestBody)
 	}
 	todo, err := h.service.Create(c.Request().Context(), &req)
 	if err != nil {
@@ -32,7 +32,7 @@ func (h *Handler) Create(c echo.Context) error {
 func (h *Handler) GetByID(c echo.Context) error {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		return errs.New(errs.ErrorTypeBadRequest, "Invalid todo ID")
+		return errs.New(errs.ErrorTypeBadRequest, "Invalid todo ID").WithCode(errs.CodeInvalidID)
 	}
 	todo, err := h.service.GetByID(c.Request().Context(), id)
 	if err != nil {
@@ -44,7 +44,7 @@ func (h *Handler) GetByID(c echo.Context) error {
 func (h *Handler) List(c echo.Context) error {
 	var req ListTodosRequest
 	if err := c.Bind(&req); err != nil {
-		return errs.New(errs.ErrorTypeBadRequest, "Invalid query parameters")
+		return errs.New(errs.ErrorTypeBadRequest, "Invalid query parameters").WithCode(errs.CodeInvalidQuery)
 	}
 	todos, err := h.service.List(c.Request().Context(), &req)
 	if err != nil {
@@ -35,7 +35,7 @@ func (s *Service) Create(ctx context.Context, req *CreateCategoryRequest) (*Cate
 		return nil, err
 	}
 	if existing != nil {
-		return nil, errs.NewConflictError("Category with this name already exists")
+		return nil, errs.NewCategoryNameTakenError()
 	}
 
 	// Create category
@@ -117,7 +117,7 @@ func (s *Service) Update(ctx context.Context, id uuid.UUID, req *UpdateCategoryR
 			return nil, err
 		}
 		if existing != nil && existing.ID != id {
-			return nil, errs.NewConflictError("Category with this name already exists")
+			return nil, errs.NewCategoryNameTakenError()
 		}
 	}
 
@@ -31,6 +31,7 @@ func ErrorHandler(logger *zerolog.Logger) echo.HTTPErrorHandler {
 				logger.Error().
 					Err(appErr.Err).
 					Str("type", string(appErr.Type)).
+					Str("code", string(appErr.Code)).
 					Str("message", appErr.Message).
 					Str("path", c.Request().URL.Path).
 					Str("method", c.Request().Method).
@@ -38,6 +39,7 @@ func ErrorHandler(logger *zerolog.Logger) echo.HTTPErrorHandler {
 			} else if code >= 400 {
 				logger.Warn().
 					Str("type", string(appErr.Type)).
+					Str("code", string(appErr.Code)).
 					Str("message", appErr.Message).
 					Str("path", c.Request().URL.Path).
 					Str("method", c.Request().Method).
@@ -48,6 +50,7 @@ func ErrorHandler(logger *zerolog.Logger) echo.HTTPErrorHandler {
 			code = echoErr.Code
 			message = map[string]interface{}{
 				"type":    "HTTP_ERROR",
+				"code":    "HTTP_ERROR",
 				"message": echoErr.Message,
 			}
 
@@ -64,6 +67,7 @@ func ErrorHandler(logger *zerolog.Logger) echo.HTTPErrorHandler {
 			code = http.StatusInternalServerError
 			message = map[string]interface{}{
 				"type":    "INTERNAL_ERROR",
+				"code":    errs.CodeInternal,
 				"message": "An unexpected error occurred",
 			}
 